@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"suitop/internal/check"
+	"suitop/internal/maintenance"
+	rpcPb "suitop/pb/sui/rpc/v2beta"
+)
+
+// Nagios plugin exit codes.
+const (
+	checkOK       = 0
+	checkWarning  = 1
+	checkCritical = 2
+	checkUnknown  = 3
+)
+
+var checkStatusNames = [...]string{"OK", "WARNING", "CRITICAL", "UNKNOWN"}
+
+// runCheckCommand implements `suitop check`, a Nagios/Icinga-style plugin.
+func runCheckCommand(args []string) int {
+	fs := newFlagSet("check", "--validator <addr|name> [flags]", "Check a validator's uptime over recent checkpoints. Prints one status line with perfdata and exits 0 (OK), 1 (WARNING), 2 (CRITICAL) or 3 (UNKNOWN).")
+	configFlags := addConfigFlags(fs)
+	validatorSpec := fs.String("validator", "", "Validator to check, by name, address or protocol pubkey (required)")
+	window := fs.Uint64("window", 200, fmt.Sprintf("Number of recent checkpoints to sample, at most %d", check.MaxWindow))
+	warn := fs.Float64("warn", 95, "Warn if the window uptime is below this percentage")
+	crit := fs.Float64("crit", 80, "Critical if the window uptime is below this percentage")
+	timeout := fs.Duration("timeout", 30*time.Second, "Give up with UNKNOWN after this long")
+	verbose := addVerboseFlag(fs)
+	// Monitoring systems read any other exit code as a check result, so even
+	// -h exits UNKNOWN, as the Nagios plugin guidelines ask
+	if _, ok := parseFlags(fs, args); !ok {
+		return checkUnknown
+	}
+	setupCommandLogging(*verbose)
+
+	unknown := func(format string, a ...interface{}) int {
+		fmt.Printf("SUITOP UNKNOWN - %s\n", fmt.Sprintf(format, a...))
+		return checkUnknown
+	}
+	switch {
+	case fs.NArg() > 0:
+		return unknown("unexpected arguments: %v", fs.Args())
+	case *validatorSpec == "":
+		return unknown("--validator is required")
+	case *window == 0 || *window > check.MaxWindow:
+		return unknown("--window must be between 1 and %d", check.MaxWindow)
+	case *crit > *warn:
+		return unknown("--crit (%g) must not be above --warn (%g)", *crit, *warn)
+	}
+
+	cfg, err := configFlags.loadFinal()
+	if err != nil {
+		return unknown("%v", err)
+	}
+	// Misses inside planned maintenance do not count against the validator
+	schedule, err := maintenance.Open(cfg.MaintenanceConfig.File)
+	if err != nil {
+		return unknown("%v", err)
+	}
+
+	loader, err := newValidatorLoader(cfg)
+	if err != nil {
+		return unknown("%v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	conn, err := dialNode(ctx, cfg)
+	if err != nil {
+		return unknown("connecting to %s: %v", cfg.SuiNode, err)
+	}
+	defer conn.Close()
+
+	sampler := check.NewSampler(rpcPb.NewLedgerServiceClient(conn), loader, schedule)
+	result, err := sampler.Sample(ctx, *validatorSpec, *window)
+	if err != nil {
+		return unknown("%v", err)
+	}
+
+	status := checkStatus(result, *warn, *crit)
+	fmt.Println(checkLine(status, result, *warn, *crit))
+	return status
+}
+
+// checkStatus compares the window uptime with the thresholds. A window whose
+// every miss was planned has no uptime and is OK.
+func checkStatus(r check.Result, warn, crit float64) int {
+	if !r.Stats.HasUptime(r.Sampled) {
+		return checkOK
+	}
+	uptime := r.Uptime() * 100
+	switch {
+	case uptime < crit:
+		return checkCritical
+	case uptime < warn:
+		return checkWarning
+	}
+	return checkOK
+}
+
+// checkLine formats the plugin output: status, summary and perfdata. The
+// thresholds are ranges that alert below their value, as in "95:".
+func checkLine(status int, r check.Result, warn, crit float64) string {
+	var streak string
+	switch {
+	case r.Stats.MissStreak > 0 && r.Maintenance:
+		streak = fmt.Sprintf("missed the last %d in maintenance", r.Stats.MissStreak)
+	case r.Stats.MissStreak > 0:
+		streak = fmt.Sprintf("missed the last %d", r.Stats.MissStreak)
+	default:
+		streak = fmt.Sprintf("signed the last %d", r.SignStreak)
+	}
+	var summary, uptime string
+	if r.Stats.HasUptime(r.Sampled) {
+		summary = fmt.Sprintf("%s uptime %.2f%% (%d/%d) over checkpoints %d-%d, %s",
+			r.Validator.Name, r.Uptime()*100, r.Stats.AttestedCount, r.Sampled, r.From, r.To, streak)
+		uptime = fmt.Sprintf("%.2f%%", r.Uptime()*100)
+	} else {
+		summary = fmt.Sprintf("%s uptime N/A over checkpoints %d-%d, no unplanned checkpoints in window (%d missed in maintenance)",
+			r.Validator.Name, r.From, r.To, r.Stats.PlannedMisses)
+		uptime = "U" // Undetermined
+	}
+
+	perfdata := []string{
+		fmt.Sprintf("uptime=%s;%g:;%g:;0;100", uptime, warn, crit),
+		fmt.Sprintf("signed=%d;;;0;%d", r.Stats.AttestedCount, r.Sampled),
+		fmt.Sprintf("missed=%d;;;0;%d", r.Missed(), r.Sampled),
+		fmt.Sprintf("planned_missed=%d;;;0;%d", r.Stats.PlannedMisses, r.Sampled),
+		fmt.Sprintf("miss_streak=%d;;;0", r.Stats.MissStreak),
+	}
+	return fmt.Sprintf("SUITOP %s - %s | %s", checkStatusNames[status], summary, strings.Join(perfdata, " "))
+}
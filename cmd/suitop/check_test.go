@@ -0,0 +1,117 @@
+package main
+
+import (
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"suitop/internal/check"
+	"suitop/internal/types"
+	"suitop/internal/validator"
+)
+
+// checkResult is a window of 200 checkpoints with the given signatures and misses
+func checkResult(attested, planned, streak uint64, maintenance bool) check.Result {
+	return check.Result{
+		Validator:   validator.ValidatorInfo{Name: "Example"},
+		From:        801,
+		To:          1000,
+		Sampled:     200,
+		Stats:       types.ValidatorStats{AttestedCount: attested, PlannedMisses: planned, MissStreak: streak},
+		Maintenance: maintenance,
+	}
+}
+
+func TestCheckStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		result check.Result
+		want   int
+	}{
+		{"all signed", checkResult(200, 0, 0, false), checkOK},
+		{"at warn", checkResult(190, 0, 0, false), checkOK},
+		{"below warn", checkResult(189, 0, 0, false), checkWarning},
+		{"at crit", checkResult(160, 0, 0, false), checkWarning},
+		{"below crit", checkResult(159, 0, 0, false), checkCritical},
+		{"planned misses left out", checkResult(150, 50, 0, false), checkOK},
+		{"every miss planned", checkResult(0, 200, 200, true), checkOK},
+		{"nothing signed", checkResult(0, 0, 200, false), checkCritical},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := checkStatus(tt.result, 95, 80); got != tt.want {
+				t.Errorf("checkStatus() = %s, want %s", checkStatusNames[got], checkStatusNames[tt.want])
+			}
+		})
+	}
+}
+
+func TestCheckLine(t *testing.T) {
+	tests := []struct {
+		name   string
+		result check.Result
+		status int
+		want   []string
+	}{
+		{
+			"warning", checkResult(183, 0, 4, false), checkWarning,
+			[]string{
+				"SUITOP WARNING - Example uptime 91.50% (183/200) over checkpoints 801-1000, missed the last 4 | ",
+				"uptime=91.50%;95:;80:;0;100 signed=183;;;0;200 missed=17;;;0;200 planned_missed=0;;;0;200 miss_streak=4;;;0",
+			},
+		},
+		{
+			"signing", checkResult(200, 0, 0, false), checkOK,
+			[]string{"signed the last 0", "uptime=100.00%;95:;80:;0;100"},
+		},
+		{
+			"in maintenance", checkResult(190, 10, 10, true), checkOK,
+			[]string{"uptime 100.00% (190/200)", "missed the last 10 in maintenance", "planned_missed=10;;;0;200"},
+		},
+		{
+			"every miss planned", checkResult(0, 200, 200, true), checkOK,
+			[]string{"SUITOP OK - Example uptime N/A over checkpoints 801-1000, no unplanned checkpoints in window (200 missed in maintenance)", "uptime=U;95:;80:;0;100", "missed=0;;;0;200"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			line := checkLine(tt.status, tt.result, 95, 80)
+			for _, want := range tt.want {
+				if !strings.Contains(line, want) {
+					t.Errorf("checkLine() = %s\nwant it to contain %s", line, want)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckArguments(t *testing.T) {
+	devNull, err := os.Open(os.DevNull)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer devNull.Close()
+	stdout, stderr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = devNull, devNull
+	defer func() { os.Stdout, os.Stderr = stdout, stderr }()
+	defer log.SetOutput(os.Stderr) // The command silences the log
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"help", []string{"-h"}},
+		{"unknown flag", []string{"--nope"}},
+		{"no validator", nil},
+		{"window too large", []string{"--validator", "0xab", "--window", "10001"}},
+		{"crit above warn", []string{"--validator", "0xab", "--warn", "80", "--crit", "90"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := runCheckCommand(tt.args); got != checkUnknown {
+				t.Errorf("runCheckCommand(%q) = %d, want %d (UNKNOWN)", tt.args, got, checkUnknown)
+			}
+		})
+	}
+}
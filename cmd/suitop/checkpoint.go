@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"suitop/internal/checkpoint"
+	"suitop/internal/types"
+	"suitop/internal/validator"
+	rpcPb "suitop/pb/sui/rpc/v2beta"
+)
+
+// checkpointReport is the JSON output of `suitop checkpoint`.
+type checkpointReport struct {
+	SequenceNumber  uint64             `json:"sequence_number"`
+	Epoch           uint64             `json:"epoch"`
+	Timestamp       time.Time          `json:"timestamp"`
+	Digest          string             `json:"digest"`
+	PreviousDigest  string             `json:"previous_digest"`
+	Transactions    int                `json:"transactions"`
+	TotalPower      int                `json:"total_power"`
+	SignedPower     int                `json:"signed_power"`
+	QuorumThreshold int                `json:"quorum_threshold"`
+	QuorumMargin    int                `json:"quorum_margin"` // Signed power above the quorum threshold
+	QuorumMarginPct float64            `json:"quorum_margin_pct"`
+	Signers         []checkpointMember `json:"signers"`
+	NonSigners      []checkpointMember `json:"non_signers"`
+}
+
+type checkpointMember struct {
+	BitmapIndex int    `json:"bitmap_index"`
+	Name        string `json:"name"`
+	Address     string `json:"address"`
+	VotingPower int    `json:"voting_power"`
+}
+
+// runCheckpointCommand implements `suitop checkpoint <seq|latest|digest>`.
+func runCheckpointCommand(args []string) int {
+	fs := newFlagSet("checkpoint", "<seq|latest|digest> [flags]", "Print a checkpoint and which committee members signed it.")
+	configFlags := addConfigFlags(fs)
+	output := addOutputFlag(fs, "table", "json")
+	verbose := addVerboseFlag(fs)
+	target, code, ok := parseFlagsAndArg(fs, args)
+	if !ok {
+		return code
+	}
+	setupCommandLogging(*verbose)
+
+	cfg, err := configFlags.loadFinal()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	loader, err := newValidatorLoader(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 3*cfg.DefaultRPCTimeout)
+	defer cancel()
+	conn, err := dialNode(ctx, cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: connecting to %s: %v\n", cfg.SuiNode, err)
+		return 1
+	}
+	defer conn.Close()
+	ledger := rpcPb.NewLedgerServiceClient(conn)
+
+	req := &rpcPb.GetCheckpointRequest{
+		ReadMask: &fieldmaskpb.FieldMask{Paths: []string{
+			"sequence_number", "digest", "signature", "contents",
+			"summary.epoch", "summary.timestamp", "summary.previous_digest",
+		}},
+	}
+	switch seq, err := strconv.ParseUint(target, 10, 64); {
+	case target == "latest":
+		info, err := ledger.GetServiceInfo(ctx, &rpcPb.GetServiceInfoRequest{})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: GetServiceInfo: %v\n", err)
+			return 1
+		}
+		req.CheckpointId = &rpcPb.GetCheckpointRequest_SequenceNumber{SequenceNumber: info.GetCheckpointHeight()}
+	case err == nil:
+		req.CheckpointId = &rpcPb.GetCheckpointRequest_SequenceNumber{SequenceNumber: seq}
+	default:
+		req.CheckpointId = &rpcPb.GetCheckpointRequest_Digest{Digest: target}
+	}
+
+	cp, err := ledger.GetCheckpoint(ctx, req)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: GetCheckpoint %s: %v\n", target, err)
+		return 1
+	}
+
+	epoch := cp.GetSummary().GetEpoch()
+	committee, _, err := loader.LoadEpochValidatorData(ctx, epoch)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: loading the committee of epoch %d: %v\n", epoch, err)
+		return 1
+	}
+
+	report := newCheckpointReport(cp, committee)
+	if *output == "json" {
+		out, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		fmt.Println(string(out))
+		return 0
+	}
+	printCheckpointReport(report)
+	return 0
+}
+
+func newCheckpointReport(cp *rpcPb.Checkpoint, committee []validator.ValidatorInfo) checkpointReport {
+	summary := cp.GetSummary()
+	r := checkpointReport{
+		SequenceNumber: cp.GetSequenceNumber(),
+		Epoch:          summary.GetEpoch(),
+		Digest:         cp.GetDigest(),
+		PreviousDigest: summary.GetPreviousDigest(),
+		Transactions:   len(cp.GetContents().GetTransactions()),
+		Signers:        []checkpointMember{},
+		NonSigners:     []checkpointMember{},
+	}
+	if ts := summary.GetTimestamp(); ts != nil {
+		r.Timestamp = time.Unix(ts.GetSeconds(), int64(ts.GetNanos())).UTC()
+	}
+
+	bitmap := cp.GetSignature().GetBitmap()
+	for _, v := range committee {
+		m := checkpointMember{BitmapIndex: v.BitmapIndex, Name: v.Name, Address: v.SuiAddress, VotingPower: v.VotingPower}
+		r.TotalPower += v.VotingPower
+		if checkpoint.IsValidatorSigned(bitmap, v.BitmapIndex) {
+			r.SignedPower += v.VotingPower
+			r.Signers = append(r.Signers, m)
+		} else {
+			r.NonSigners = append(r.NonSigners, m)
+		}
+	}
+	r.QuorumThreshold = types.QuorumThreshold(r.TotalPower)
+	r.QuorumMargin = r.SignedPower - r.QuorumThreshold
+	if r.TotalPower > 0 {
+		r.QuorumMarginPct = float64(r.QuorumMargin) / float64(r.TotalPower) * 100
+	}
+	return r
+}
+
+func printCheckpointReport(r checkpointReport) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Checkpoint:\t%d\n", r.SequenceNumber)
+	fmt.Fprintf(w, "Epoch:\t%d\n", r.Epoch)
+	if !r.Timestamp.IsZero() {
+		fmt.Fprintf(w, "Timestamp:\t%s\n", r.Timestamp.Local().Format("2006-01-02 15:04:05.000 MST"))
+	}
+	fmt.Fprintf(w, "Digest:\t%s\n", r.Digest)
+	fmt.Fprintf(w, "Previous digest:\t%s\n", r.PreviousDigest)
+	fmt.Fprintf(w, "Transactions:\t%d\n", r.Transactions)
+	fmt.Fprintf(w, "Signed power:\t%d / %d (%d of %d validators)\n", r.SignedPower, r.TotalPower, len(r.Signers), len(r.Signers)+len(r.NonSigners))
+	fmt.Fprintf(w, "Quorum margin:\t%+d above %d (%+.2f%% of total)\n", r.QuorumMargin, r.QuorumThreshold, r.QuorumMarginPct)
+	w.Flush()
+
+	printMembers := func(title string, members []checkpointMember) {
+		fmt.Printf("\n%s (%d):\n", title, len(members))
+		if len(members) == 0 {
+			return
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "INDEX\tNAME\tADDRESS\tVOTING POWER")
+		for _, m := range members {
+			fmt.Fprintf(w, "%d\t%s\t%s\t%d\n", m.BitmapIndex, m.Name, m.Address, m.VotingPower)
+		}
+		w.Flush()
+	}
+	printMembers("Did not sign", r.NonSigners)
+	printMembers("Signed", r.Signers)
+}
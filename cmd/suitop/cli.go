@@ -0,0 +1,296 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"suitop/internal/config"
+	"suitop/internal/labels"
+	"suitop/internal/validator"
+)
+
+// newFlagSet creates the flag set of a subcommand with a usage message built
+// from its argument synopsis and summary.
+func newFlagSet(name, args, summary string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {
+		out := fs.Output()
+		fmt.Fprintf(out, "Usage: %s %s %s\n%s\n", os.Args[0], name, args, summary)
+		fmt.Fprintf(out, "\nFlags:\n")
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseFlags parses a subcommand's flags. If parsing ends the command, for
+// -h or a bad flag, it returns the exit code and false.
+func parseFlags(fs *flag.FlagSet, args []string) (int, bool) {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0, false
+		}
+		return 2, false
+	}
+	return 0, true
+}
+
+// parseFlagsAndArg parses the flags of a subcommand that takes one argument,
+// before or after it as in `suitop checkpoint --output json latest` and
+// `suitop checkpoint latest --output json`. If parsing ends the command, for
+// -h, a bad flag or a missing or extra argument, it returns the exit code and
+// false.
+func parseFlagsAndArg(fs *flag.FlagSet, args []string) (string, int, bool) {
+	if code, ok := parseFlags(fs, args); !ok {
+		return "", code, false
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return "", 2, false
+	}
+	arg := fs.Arg(0)
+	if code, ok := parseFlags(fs, fs.Args()[1:]); !ok {
+		return "", code, false
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(fs.Output(), "Error: unexpected arguments: %v\n", fs.Args())
+		return "", 2, false
+	}
+	return arg, 0, true
+}
+
+// wasSet reports whether a flag was given on the command line.
+func wasSet(fs *flag.FlagSet, name string) bool {
+	set := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
+// configFlags selects the configuration of commands that talk to a node.
+type configFlags struct {
+	fs      *flag.FlagSet
+	path    *string
+	profile *string
+	network *string
+}
+
+func addConfigFlags(fs *flag.FlagSet) *configFlags {
+	return &configFlags{
+		fs:      fs,
+		path:    fs.String("config", "", "Path to a YAML config file (default: ~/.suitop/config.yaml if it exists)"),
+		profile: fs.String("profile", "", "Config file profile to use (overrides SUITOP_PROFILE env var and default_profile)"),
+		network: fs.String("network", "mainnet", "Network to connect to: mainnet, testnet, devnet, localnet or a network defined in the config file (overrides the config file)"),
+	}
+}
+
+// load applies the defaults, the config file, environment variables and the
+// --network flag. The caller applies its own flags and then calls Finalize.
+func (f *configFlags) load() (*config.Config, error) {
+	cfg, err := config.Load(*f.path, *f.profile)
+	if err != nil {
+		return nil, err
+	}
+	if wasSet(f.fs, "network") {
+		cfg.Network = *f.network
+	}
+	return cfg, nil
+}
+
+// loadFinal loads the configuration of a one-shot command, which has no
+// settings of its own to apply.
+func (f *configFlags) loadFinal() (*config.Config, error) {
+	cfg, err := f.load()
+	if err != nil {
+		return nil, err
+	}
+	return cfg, cfg.Finalize()
+}
+
+// defaultLogFilePath is the --log-file default, also used by the TUI when no
+// log file is configured
+const defaultLogFilePath = "./logs/suitop.log"
+
+// monitorFlags are the settings of the live monitor that can be set on the
+// command line. `config show --effective` takes them too, to print the
+// configuration a monitor with the same flags would run with.
+type monitorFlags struct {
+	fs              *flag.FlagSet
+	plain           *bool
+	output          *string
+	watch           *string
+	watchFile       *string
+	watchOnly       *bool
+	emit            *string
+	noAltScreen     *bool
+	logToFile       *bool
+	logFile         *string
+	history         *bool
+	apiListen       *string
+	apiToken        *string
+	alertRules      *string
+	alertmanagerURL *string
+	notifyConfig    *string
+	maintenanceFile *string
+	labelsFile      *string
+	metricsListen   *string
+}
+
+func addMonitorFlags(fs *flag.FlagSet) *monitorFlags {
+	return &monitorFlags{
+		fs:              fs,
+		plain:           fs.Bool("plain", false, "Use plain text output (overrides PLAIN_MODE env var)"),
+		output:          addOutputFlag(fs, config.OutputFormats()...),
+		watch:           fs.String("watch", "", "Comma-separated validators to watch, by name, address or protocol pubkey (overrides WATCH env var)"),
+		watchFile:       fs.String("watch-file", "", "YAML file listing validators to watch (overrides WATCH_FILE env var)"),
+		watchOnly:       fs.Bool("watch-only", false, "Show only the watched validators (overrides WATCH_ONLY env var)"),
+		emit:            fs.String("emit", "all", "When plain mode writes reports: all, changes, every=<duration> or every=<checkpoints> (overrides EMIT env var)"),
+		noAltScreen:     fs.Bool("no-alt-screen", false, "Run inside current terminal buffer (overrides NO_ALT_SCREEN env var, useful for tmux logs)"),
+		logToFile:       fs.Bool("log-to-file", false, "Write logs to a file (overrides LOG_TO_FILE env var)"),
+		logFile:         fs.String("log-file", defaultLogFilePath, "Path to log file (overrides LOG_FILE_PATH env var)"),
+		history:         fs.Bool("history", false, "Record per-checkpoint signer history (overrides HISTORY_ENABLED env var)"),
+		apiListen:       fs.String("api-listen", "", "Serve the read-only HTTP/JSON API on this address, e.g. :8080 (overrides API_LISTEN env var)"),
+		apiToken:        fs.String("api-token", "", "Bearer token required by the HTTP/JSON API (overrides API_TOKEN env var)"),
+		alertRules:      fs.String("alert-rules", "", "Path to a YAML alert rules file (overrides ALERT_RULES_FILE env var)"),
+		alertmanagerURL: fs.String("alertmanager-url", "", "Comma-separated Alertmanager URLs to push alerts to (overrides ALERTMANAGER_URL env var)"),
+		notifyConfig:    fs.String("notify-config", "", "Path to a YAML notification sinks file (overrides NOTIFY_CONFIG_FILE env var)"),
+		maintenanceFile: fs.String("maintenance-file", "", "YAML file of planned maintenance windows (overrides MAINTENANCE_FILE env var)"),
+		labelsFile:      fs.String("labels-file", "", "YAML file of validator display names, groups and tags (overrides LABELS_FILE env var)"),
+		metricsListen:   fs.String("metrics-listen", "", "Serve Prometheus metrics on this address, e.g. :9184 (overrides METRICS_LISTEN env var)"),
+	}
+}
+
+// apply overrides cfg with the flags given on the command line.
+func (f *monitorFlags) apply(cfg *config.Config) {
+	if wasSet(f.fs, "plain") {
+		cfg.UIConfig.PlainMode = *f.plain
+	}
+	if wasSet(f.fs, "output") {
+		cfg.UIConfig.Output = config.OutputFormat(*f.output)
+	}
+	if wasSet(f.fs, "watch") {
+		cfg.WatchConfig.Validators = config.SplitList(*f.watch)
+	}
+	if wasSet(f.fs, "watch-file") {
+		cfg.WatchConfig.File = *f.watchFile
+	}
+	if wasSet(f.fs, "watch-only") {
+		cfg.WatchConfig.Only = *f.watchOnly
+	}
+	if wasSet(f.fs, "emit") {
+		cfg.UIConfig.Emit = *f.emit
+	}
+	if wasSet(f.fs, "no-alt-screen") {
+		cfg.UIConfig.NoAltScreen = *f.noAltScreen
+	}
+	if wasSet(f.fs, "log-to-file") {
+		cfg.LogConfig.ToFile = *f.logToFile
+	}
+	if wasSet(f.fs, "log-file") {
+		// If --log-file is explicitly set, its value (even if it's the flag's own default path) overrides cfg.
+		cfg.LogConfig.FilePath = *f.logFile
+	}
+	if wasSet(f.fs, "history") {
+		cfg.HistoryConfig.Enabled = *f.history
+	}
+	if wasSet(f.fs, "metrics-listen") {
+		cfg.MetricsConfig.ListenAddr = *f.metricsListen
+	}
+	if wasSet(f.fs, "api-listen") {
+		cfg.APIConfig.ListenAddr = *f.apiListen
+	}
+	if wasSet(f.fs, "api-token") {
+		cfg.APIConfig.Token = *f.apiToken
+	}
+	if wasSet(f.fs, "alert-rules") {
+		cfg.AlertConfig.RulesFile = *f.alertRules
+	}
+	if wasSet(f.fs, "alertmanager-url") {
+		cfg.AlertConfig.AlertmanagerURLs = config.SplitList(*f.alertmanagerURL)
+	}
+	if wasSet(f.fs, "maintenance-file") {
+		cfg.MaintenanceConfig.File = *f.maintenanceFile
+	}
+	if wasSet(f.fs, "labels-file") {
+		cfg.LabelsConfig.File = *f.labelsFile
+	}
+	if wasSet(f.fs, "notify-config") {
+		cfg.NotifyConfig.ConfigFile = *f.notifyConfig
+	}
+}
+
+// dialNode connects to the gRPC endpoint of cfg, blocking until connected or
+// ctx is done.
+func dialNode(ctx context.Context, cfg *config.Config, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	creds := insecure.NewCredentials()
+	if cfg.GRPC.UseTLS {
+		creds = credentials.NewTLS(&tls.Config{InsecureSkipVerify: cfg.GRPC.InsecureSkipVerify})
+	} else {
+		log.Println("gRPC TLS is disabled; connecting over plaintext.")
+	}
+	opts = append([]grpc.DialOption{grpc.WithTransportCredentials(creds), grpc.WithBlock()}, opts...)
+	return grpc.DialContext(ctx, cfg.SuiNode, opts...)
+}
+
+// newValidatorLoader creates a validator loader that applies the labels file
+// of cfg to the committees it loads.
+func newValidatorLoader(cfg *config.Config) (*validator.Loader, error) {
+	set, err := labels.Open(cfg.LabelsConfig.File)
+	if err != nil {
+		return nil, err
+	}
+	loader := validator.NewLoader(cfg.RPCClientConfig)
+	loader.SetLabels(set)
+	return loader, nil
+}
+
+// addVerboseFlag adds -v to a one-shot command. Without it, progress messages
+// of the standard logger are discarded so that only the command's output is
+// printed.
+func addVerboseFlag(fs *flag.FlagSet) *bool {
+	return fs.Bool("v", false, "Log progress messages to stderr")
+}
+
+func setupCommandLogging(verbose bool) {
+	if !verbose {
+		log.SetOutput(io.Discard)
+	}
+}
+
+// choiceValue is a string flag restricted to a fixed set of values.
+type choiceValue struct {
+	value   string
+	choices []string
+}
+
+func (c *choiceValue) String() string { return c.value }
+
+func (c *choiceValue) Set(s string) error {
+	for _, choice := range c.choices {
+		if s == choice {
+			c.value = s
+			return nil
+		}
+	}
+	return fmt.Errorf("must be one of %s", strings.Join(c.choices, ", "))
+}
+
+// addOutputFlag adds --output to a command. The first choice is the default.
+func addOutputFlag(fs *flag.FlagSet, choices ...string) *string {
+	c := &choiceValue{value: choices[0], choices: choices}
+	fs.Var(c, "output", "Output format: "+strings.Join(choices, ", "))
+	return &c.value
+}
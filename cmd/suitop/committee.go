@@ -0,0 +1,236 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+
+	"suitop/internal/types"
+	"suitop/internal/validator"
+)
+
+// committeeReport is the JSON output of `suitop committee`.
+type committeeReport struct {
+	Epoch      uint64             `json:"epoch"`
+	TotalPower int                `json:"total_power"`
+	Quorum     committeeThreshold `json:"quorum"`   // 2f+1
+	Validity   committeeThreshold `json:"validity"` // f+1
+	Validators []committeeMember  `json:"validators"`
+}
+
+// committeeThreshold is a voting power threshold and the fewest validators
+// whose combined power reaches it.
+type committeeThreshold struct {
+	Power         int `json:"power"`
+	MinValidators int `json:"min_validators"`
+}
+
+type committeeMember struct {
+	BitmapIndex    int     `json:"bitmap_index"`
+	Name           string  `json:"name"`
+	Address        string  `json:"address"`
+	ProtocolPubkey string  `json:"protocol_pubkey"`
+	VotingPower    int     `json:"voting_power"`
+	SharePct       float64 `json:"share_pct"`
+	CumulativePct  float64 `json:"cumulative_pct"` // Share of this and all lower bitmap indices
+
+	// From the labels file, if it lists the validator
+	ChainName string   `json:"chain_name,omitempty"`
+	Group     string   `json:"group,omitempty"`
+	Tags      []string `json:"tags,omitempty"`
+}
+
+// runCommitteeCommand implements `suitop committee [epoch]`.
+func runCommitteeCommand(args []string) int {
+	fs := newFlagSet("committee", "[epoch] [flags]", "Print the committee of an epoch (default: the current one) in bitmap-index order, with stake shares and thresholds.")
+	configFlags := addConfigFlags(fs)
+	output := addOutputFlag(fs, "table", "json", "csv")
+	verbose := addVerboseFlag(fs)
+	args, epoch, hasEpoch, err := leadingUint(args)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid epoch: %v\n", err)
+		return 2
+	}
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(os.Stderr, "Error: unexpected arguments: %v\n", fs.Args())
+		return 2
+	}
+	setupCommandLogging(*verbose)
+
+	cfg, err := configFlags.loadFinal()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*cfg.DefaultRPCTimeout)
+	defer cancel()
+
+	loader, err := newValidatorLoader(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	var committee []validator.ValidatorInfo
+	var loadedEpoch uint64
+	if hasEpoch {
+		committee, loadedEpoch, err = loader.LoadEpochValidatorData(ctx, epoch)
+	} else {
+		committee, loadedEpoch, err = loader.LoadLatestValidatorData(ctx)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	report := newCommitteeReport(loadedEpoch, committee)
+
+	switch *output {
+	case "json":
+		out, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		fmt.Println(string(out))
+	case "csv":
+		if err := writeCommitteeCSV(os.Stdout, report); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+	default:
+		printCommitteeReport(report)
+	}
+	return 0
+}
+
+func newCommitteeReport(epoch uint64, committee []validator.ValidatorInfo) committeeReport {
+	sorted := append([]validator.ValidatorInfo(nil), committee...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].BitmapIndex < sorted[j].BitmapIndex })
+
+	r := committeeReport{Epoch: epoch, Validators: []committeeMember{}}
+	powers := make([]int, 0, len(sorted))
+	for _, v := range sorted {
+		r.TotalPower += v.VotingPower
+		powers = append(powers, v.VotingPower)
+	}
+	cumulative := 0
+	for _, v := range sorted {
+		cumulative += v.VotingPower
+		m := committeeMember{
+			BitmapIndex:    v.BitmapIndex,
+			Name:           v.Name,
+			Address:        v.SuiAddress,
+			ProtocolPubkey: validator.ShortPubKey(v.ProtocolPubkeyBytes),
+			VotingPower:    v.VotingPower,
+			ChainName:      v.ChainName,
+			Group:          v.Group,
+			Tags:           v.Tags,
+		}
+		if r.TotalPower > 0 {
+			m.SharePct = float64(v.VotingPower) / float64(r.TotalPower) * 100
+			m.CumulativePct = float64(cumulative) / float64(r.TotalPower) * 100
+		}
+		r.Validators = append(r.Validators, m)
+	}
+
+	sort.Sort(sort.Reverse(sort.IntSlice(powers)))
+	r.Quorum = committeeThreshold{Power: types.QuorumThreshold(r.TotalPower)}
+	r.Quorum.MinValidators = minValidators(powers, r.Quorum.Power)
+	r.Validity = committeeThreshold{Power: types.ValidityThreshold(r.TotalPower)}
+	r.Validity.MinValidators = minValidators(powers, r.Validity.Power)
+	return r
+}
+
+// minValidators returns how many of the largest powers, sorted in descending
+// order, are needed to reach threshold, or 0 if all of them together do not.
+func minValidators(powersDesc []int, threshold int) int {
+	sum := 0
+	for i, p := range powersDesc {
+		sum += p
+		if sum >= threshold {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+func printCommitteeReport(r committeeReport) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "INDEX\tNAME\tADDRESS\tPUBKEY\tVOTING POWER\tSHARE\tCUMULATIVE")
+	for _, m := range r.Validators {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%d\t%.2f%%\t%.2f%%\n", m.BitmapIndex, m.Name, m.Address, m.ProtocolPubkey, m.VotingPower, m.SharePct, m.CumulativePct)
+	}
+	w.Flush()
+
+	fmt.Println()
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Epoch:\t%d\n", r.Epoch)
+	fmt.Fprintf(w, "Validators:\t%d\n", len(r.Validators))
+	fmt.Fprintf(w, "Total power:\t%d\n", r.TotalPower)
+	fmt.Fprintf(w, "Quorum (2f+1):\t%d\t(at least %d validators)\n", r.Quorum.Power, r.Quorum.MinValidators)
+	fmt.Fprintf(w, "Validity (f+1):\t%d\t(at least %d validators)\n", r.Validity.Power, r.Validity.MinValidators)
+	w.Flush()
+}
+
+// writeCommitteeCSV writes one row per validator followed by the summary
+// rows: the total, quorum and validity powers with the fewest validators that
+// reach each. The row_type column tells them apart.
+func writeCommitteeCSV(out io.Writer, r committeeReport) error {
+	w := csv.NewWriter(out)
+	w.Write([]string{"epoch", "bitmap_index", "name", "address", "protocol_pubkey", "voting_power", "share_pct", "cumulative_pct", "row_type", "min_validators"})
+	epoch := strconv.FormatUint(r.Epoch, 10)
+	for _, m := range r.Validators {
+		w.Write([]string{
+			epoch,
+			strconv.Itoa(m.BitmapIndex),
+			m.Name,
+			m.Address,
+			m.ProtocolPubkey,
+			strconv.Itoa(m.VotingPower),
+			strconv.FormatFloat(m.SharePct, 'f', 4, 64),
+			strconv.FormatFloat(m.CumulativePct, 'f', 4, 64),
+			"validator",
+			"",
+		})
+	}
+	summary := []struct {
+		rowType       string
+		power         int
+		minValidators int
+	}{
+		{"total", r.TotalPower, len(r.Validators)},
+		{"quorum", r.Quorum.Power, r.Quorum.MinValidators},
+		{"validity", r.Validity.Power, r.Validity.MinValidators},
+	}
+	for _, row := range summary {
+		share := ""
+		if r.TotalPower > 0 {
+			share = strconv.FormatFloat(float64(row.power)/float64(r.TotalPower)*100, 'f', 4, 64)
+		}
+		w.Write([]string{epoch, "", "", "", "", strconv.Itoa(row.power), share, "", row.rowType, strconv.Itoa(row.minValidators)})
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// leadingUint takes an optional number before the flags of a command, e.g.
+// the epoch in `suitop committee 42 --network testnet`. It returns the
+// remaining arguments, the number and whether there was one.
+func leadingUint(args []string) ([]string, uint64, bool, error) {
+	if len(args) == 0 || len(args[0]) == 0 || args[0][0] == '-' {
+		return args, 0, false, nil
+	}
+	n, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		return args, 0, false, err
+	}
+	return args[1:], n, true, nil
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"reflect"
+	"testing"
+
+	"suitop/internal/validator"
+)
+
+func TestNewCommitteeReport(t *testing.T) {
+	committee := []validator.ValidatorInfo{
+		{Name: "gamma", SuiAddress: "0xc", BitmapIndex: 2, VotingPower: 1000},
+		{Name: "alpha", SuiAddress: "0xa", BitmapIndex: 0, VotingPower: 5000},
+		{Name: "beta", SuiAddress: "0xb", BitmapIndex: 1, VotingPower: 4000},
+	}
+	r := newCommitteeReport(7, committee)
+	if r.TotalPower != 10000 {
+		t.Fatalf("TotalPower = %d, want 10000", r.TotalPower)
+	}
+	if r.Quorum != (committeeThreshold{Power: 6667, MinValidators: 2}) {
+		t.Errorf("Quorum = %+v, want 6667 reached by 2 validators", r.Quorum)
+	}
+	if r.Validity != (committeeThreshold{Power: 3334, MinValidators: 1}) {
+		t.Errorf("Validity = %+v, want 3334 reached by 1 validator", r.Validity)
+	}
+	var names []string
+	for _, m := range r.Validators {
+		names = append(names, m.Name)
+	}
+	if want := []string{"alpha", "beta", "gamma"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("validators %v, want bitmap order %v", names, want)
+	}
+	if last := r.Validators[2]; last.SharePct != 10 || last.CumulativePct != 100 {
+		t.Errorf("gamma share %v cumulative %v, want 10 and 100", last.SharePct, last.CumulativePct)
+	}
+}
+
+func TestMinValidators(t *testing.T) {
+	tests := []struct {
+		powers    []int
+		threshold int
+		want      int
+	}{
+		{[]int{50, 30, 20}, 67, 2},
+		{[]int{50, 30, 20}, 50, 1},
+		{[]int{50, 30, 20}, 100, 3},
+		{[]int{50, 30, 20}, 101, 0},
+		{nil, 1, 0},
+	}
+	for _, tt := range tests {
+		if got := minValidators(tt.powers, tt.threshold); got != tt.want {
+			t.Errorf("minValidators(%v, %d) = %d, want %d", tt.powers, tt.threshold, got, tt.want)
+		}
+	}
+}
+
+func TestWriteCommitteeCSV(t *testing.T) {
+	r := newCommitteeReport(7, []validator.ValidatorInfo{
+		{Name: "alpha", SuiAddress: "0xa", BitmapIndex: 0, VotingPower: 6000},
+		{Name: "beta", SuiAddress: "0xb", BitmapIndex: 1, VotingPower: 4000},
+	})
+	var buf bytes.Buffer
+	if err := writeCommitteeCSV(&buf, r); err != nil {
+		t.Fatalf("writeCommitteeCSV: %v", err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v", err)
+	}
+	if len(rows) != 6 {
+		t.Fatalf("got %d rows, want a header, 2 validators and 3 summary rows:\n%v", len(rows), rows)
+	}
+	tests := []struct {
+		row  int
+		want []string
+	}{
+		{1, []string{"7", "0", "alpha", "0xa", "", "6000", "60.0000", "60.0000", "validator", ""}},
+		{3, []string{"7", "", "", "", "", "10000", "100.0000", "", "total", "2"}},
+		{4, []string{"7", "", "", "", "", "6667", "66.6700", "", "quorum", "2"}},
+		{5, []string{"7", "", "", "", "", "3334", "33.3400", "", "validity", "1"}},
+	}
+	for _, tt := range tests {
+		if !reflect.DeepEqual(rows[tt.row], tt.want) {
+			t.Errorf("row %d = %q, want %q", tt.row, rows[tt.row], tt.want)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"suitop/internal/alert"
+	"suitop/internal/config"
+	"suitop/internal/labels"
+	"suitop/internal/maintenance"
+	"suitop/internal/notify"
+	"suitop/internal/watch"
+)
+
+// runConfigCommand implements `suitop config validate|show` and returns the
+// process exit code.
+func runConfigCommand(args []string) int {
+	usage := func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s config <validate|show> [flags]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  validate  Check the config file and the files it refers to\n")
+		fmt.Fprintf(os.Stderr, "  show      Print the selected profile, or with --effective the merged configuration;\n")
+		fmt.Fprintf(os.Stderr, "            --effective also takes the monitor's flags, such as --network or --plain\n")
+	}
+	if len(args) == 0 {
+		usage()
+		return 1
+	}
+
+	fs := flag.NewFlagSet("config "+args[0], flag.ContinueOnError)
+
+	switch args[0] {
+	case "validate":
+		path := fs.String("config", "", "Path to a YAML config file (default: ~/.suitop/config.yaml if it exists)")
+		profile := fs.String("profile", "", "Config file profile (overrides SUITOP_PROFILE env var and default_profile)")
+		all := fs.Bool("all", false, "Validate every profile in the file instead of the selected one")
+		if err := fs.Parse(args[1:]); err != nil {
+			return 2
+		}
+		profiles := []string{*profile}
+		if *all {
+			file := *path
+			if file == "" {
+				file = config.DefaultPath()
+			}
+			names, err := config.Profiles(file)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				return 1
+			}
+			profiles = names
+		}
+
+		code := 0
+		for _, p := range profiles {
+			cfg, err := validateConfig(*path, p)
+			name := p
+			if name == "" {
+				name = "configuration"
+			}
+			if cfg != nil {
+				name = describeSource(cfg)
+			}
+			if err != nil {
+				fmt.Printf("%s: INVALID: %v\n", name, err)
+				code = 1
+				continue
+			}
+			fmt.Printf("%s: ok\n", name)
+		}
+		return code
+
+	case "show":
+		effective := fs.Bool("effective", false, "Print the configuration after applying defaults, the file, environment variables and the monitor flags given")
+		configFlags := addConfigFlags(fs)
+		flags := addMonitorFlags(fs)
+		if err := fs.Parse(args[1:]); err != nil {
+			return 2
+		}
+		if !*effective {
+			file, name, raw, err := config.ReadProfile(*configFlags.path, *configFlags.profile)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				return 1
+			}
+			if file == "" {
+				fmt.Println("# No config file; run with --effective to see the defaults and environment settings")
+				return 0
+			}
+			fmt.Printf("# %s, profile %q\n%s", file, name, raw)
+			return 0
+		}
+
+		cfg, err := configFlags.load()
+		if err == nil {
+			flags.apply(cfg)
+			err = cfg.Finalize()
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		out, err := cfg.EffectiveYAML()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		fmt.Printf("# Effective configuration: %s (defaults < file < environment < flags)\n%s", describeSource(cfg), out)
+		return 0
+	}
+
+	fmt.Fprintf(os.Stderr, "Error: unknown config command %q\n\n", args[0])
+	usage()
+	return 1
+}
+
+// validateConfig loads a profile and the alert, notification and maintenance
+// files it refers to.
+func validateConfig(path, profile string) (*config.Config, error) {
+	cfg, err := config.Load(path, profile)
+	if err != nil {
+		return nil, err
+	}
+	if err := cfg.Finalize(); err != nil {
+		return cfg, err
+	}
+	if cfg.AlertConfig.RulesFile != "" {
+		if _, err := alert.LoadRules(cfg.AlertConfig.RulesFile); err != nil {
+			return cfg, err
+		}
+	}
+	if cfg.NotifyConfig.ConfigFile != "" {
+		if _, err := notify.LoadConfig(cfg.NotifyConfig.ConfigFile); err != nil {
+			return cfg, err
+		}
+	}
+	if _, err := maintenance.ReadFile(cfg.MaintenanceConfig.File); err != nil {
+		return cfg, err
+	}
+	if _, err := watch.Load(cfg.WatchConfig); err != nil {
+		return cfg, err
+	}
+	if _, err := labels.ReadFile(cfg.LabelsConfig.File); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+func describeSource(cfg *config.Config) string {
+	switch {
+	case cfg.File == "":
+		return "no config file"
+	case cfg.Profile == "":
+		return cfg.File
+	default:
+		return fmt.Sprintf("%s [%s]", cfg.File, cfg.Profile)
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"text/tabwriter"
+)
+
+// runDatasetCommand implements `suitop dataset record|list`.
+func runDatasetCommand(args []string) int {
+	if len(args) == 0 || isHelpArg(args[0]) {
+		fmt.Fprintf(os.Stderr, "Usage: %s dataset <record|list> [flags]\n", os.Args[0])
+		fmt.Fprintln(os.Stderr, "  record  Run the monitor and write one signing bitmap file per finished epoch")
+		fmt.Fprintln(os.Stderr, "  list    List the epoch files in the dataset folder")
+		if len(args) == 0 {
+			return 2
+		}
+		return 0
+	}
+	switch args[0] {
+	case "record":
+		return runMonitor("dataset record", args[1:], true)
+	case "list":
+		return runDatasetList(args[1:])
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unknown dataset command %q\n", args[0])
+		return 2
+	}
+}
+
+// datasetFile is the header of an epoch file written by the dataset manager.
+type datasetFile struct {
+	Epoch           uint64            `json:"epoch"`
+	StartCheckpoint uint64            `json:"start_checkpoint"`
+	EndCheckpoint   uint64            `json:"end_checkpoint"`
+	Validators      []json.RawMessage `json:"validators"`
+}
+
+func runDatasetList(args []string) int {
+	fs := newFlagSet("dataset list", "[flags]", "List the epoch files in the dataset folder.")
+	configFlags := addConfigFlags(fs)
+	folder := fs.String("folder", "", "Dataset folder (overrides the config file and DATASET_FOLDER)")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	cfg, err := configFlags.load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	if *folder != "" {
+		cfg.DatasetConfig.Folder = *folder
+	}
+
+	paths, err := filepath.Glob(filepath.Join(cfg.DatasetConfig.Folder, "epoch_*.json"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	var files []datasetFile
+	names := make(map[uint64]string)
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			continue
+		}
+		var f datasetFile
+		if err := json.Unmarshal(data, &f); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %s: %v\n", path, err)
+			continue
+		}
+		files = append(files, f)
+		names[f.Epoch] = filepath.Base(path)
+	}
+	if len(files) == 0 {
+		fmt.Printf("No epoch files in %s\n", cfg.DatasetConfig.Folder)
+		return 0
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Epoch < files[j].Epoch })
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "EPOCH\tCHECKPOINTS\tCOUNT\tVALIDATORS\tFILE")
+	for _, f := range files {
+		fmt.Fprintf(w, "%d\t%d-%d\t%d\t%d\t%s\n", f.Epoch, f.StartCheckpoint, f.EndCheckpoint, f.EndCheckpoint-f.StartCheckpoint+1, len(f.Validators), names[f.Epoch])
+	}
+	w.Flush()
+	return 0
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLegacyArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want []string
+		warn bool
+	}{
+		{"no legacy flag", []string{"--plain", "--network", "testnet"}, []string{"--plain", "--network", "testnet"}, false},
+		{"generate dataset", []string{"--plain", "--generate-dataset"}, []string{"dataset", "record", "--plain"}, true},
+		{"single dash", []string{"-generate-dataset", "-network=testnet"}, []string{"dataset", "record", "-network=testnet"}, true},
+		{"explicit true", []string{"--generate-dataset=true"}, []string{"dataset", "record"}, true},
+		{"explicit false", []string{"--generate-dataset=false", "--plain"}, []string{"--plain"}, true},
+		{"invalid value", []string{"--generate-dataset=maybe"}, []string{"--generate-dataset=maybe"}, false},
+		{"flag value", []string{"--log-file", "generate-dataset"}, []string{"--log-file", "generate-dataset"}, false},
+		{"after terminator", []string{"--plain", "--", "--generate-dataset"}, []string{"--plain", "--", "--generate-dataset"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var warn bytes.Buffer
+			got := legacyArgs(tt.args, &warn)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("legacyArgs(%q) = %q, want %q", tt.args, got, tt.want)
+			}
+			if warned := strings.Contains(warn.String(), "deprecated"); warned != tt.warn {
+				t.Errorf("legacyArgs(%q) warned %v, want %v: %q", tt.args, warned, tt.warn, warn.String())
+			}
+		})
+	}
+}
+
+func TestParseFlagsAndArg(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		want     string
+		wantCode int
+		wantOK   bool
+		output   string
+	}{
+		{"argument first", []string{"latest", "--output", "json"}, "latest", 0, true, "json"},
+		{"flags first", []string{"--output", "json", "latest"}, "latest", 0, true, "json"},
+		{"flags around", []string{"--output=json", "42", "-v"}, "42", 0, true, "json"},
+		{"no flags", []string{"42"}, "42", 0, true, "table"},
+		{"missing argument", []string{"--output", "json"}, "", 2, false, "json"},
+		{"extra argument", []string{"42", "43"}, "", 2, false, "table"},
+		{"bad flag", []string{"latest", "--nope"}, "", 2, false, "table"},
+		{"help", []string{"-h"}, "", 0, false, "table"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := newFlagSet("checkpoint", "<seq|latest|digest> [flags]", "")
+			fs.SetOutput(io.Discard)
+			output := fs.String("output", "table", "")
+			fs.Bool("v", false, "")
+			got, code, ok := parseFlagsAndArg(fs, tt.args)
+			if got != tt.want || code != tt.wantCode || ok != tt.wantOK {
+				t.Errorf("parseFlagsAndArg(%q) = %q, %d, %v, want %q, %d, %v", tt.args, got, code, ok, tt.want, tt.wantCode, tt.wantOK)
+			}
+			if *output != tt.output {
+				t.Errorf("--output = %q, want %q", *output, tt.output)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"suitop/internal/config"
+	"suitop/internal/maintenance"
+)
+
+// runMaintenanceCommand implements `suitop maintenance add|list|remove` and
+// returns the process exit code. A running suitop picks up changes to the
+// maintenance file within a few seconds.
+func runMaintenanceCommand(args []string) int {
+	usage := func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s maintenance <add|list|remove> [flags]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  add     Schedule a maintenance window for a validator\n")
+		fmt.Fprintf(os.Stderr, "  list    List scheduled maintenance windows\n")
+		fmt.Fprintf(os.Stderr, "  remove  Remove a maintenance window by ID (or --expired)\n")
+	}
+	if len(args) == 0 {
+		usage()
+		return 1
+	}
+
+	defaultFile := config.Defaults().MaintenanceConfig.File
+	if cfg, err := config.Load("", ""); err == nil {
+		defaultFile = cfg.MaintenanceConfig.File
+	}
+	fs := flag.NewFlagSet("maintenance "+args[0], flag.ContinueOnError)
+	file := fs.String("file", defaultFile, "Maintenance windows file (default from MAINTENANCE_FILE or the config file)")
+
+	switch args[0] {
+	case "add":
+		validator := fs.String("validator", "", "Validator name or address (required)")
+		from := fs.String("from", "", "Window start time, RFC 3339 or \"2006-01-02 15:04\" local time (default: now if no checkpoint range)")
+		to := fs.String("to", "", "Window end time, same formats as --from")
+		duration := fs.Duration("duration", 0, "Window length from --from, e.g. 2h (alternative to --to)")
+		fromCheckpoint := fs.Uint64("from-checkpoint", 0, "First checkpoint sequence number in the window")
+		toCheckpoint := fs.Uint64("to-checkpoint", 0, "Last checkpoint sequence number in the window")
+		reason := fs.String("reason", "", "Free-form description, e.g. \"node upgrade\"")
+		if err := fs.Parse(args[1:]); err != nil {
+			return 2
+		}
+
+		w := maintenance.Window{
+			ID:             maintenance.NewID(),
+			Validator:      *validator,
+			Reason:         *reason,
+			FromCheckpoint: *fromCheckpoint,
+			ToCheckpoint:   *toCheckpoint,
+		}
+		var err error
+		if w.Start, err = parseWindowTime(*from); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid --from: %v\n", err)
+			return 1
+		}
+		if w.End, err = parseWindowTime(*to); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid --to: %v\n", err)
+			return 1
+		}
+		if *duration > 0 {
+			if !w.End.IsZero() {
+				fmt.Fprintln(os.Stderr, "Error: --to and --duration are mutually exclusive")
+				return 1
+			}
+			if w.Start.IsZero() {
+				w.Start = time.Now().Truncate(time.Second)
+			}
+			w.End = w.Start.Add(*duration)
+		}
+		if w.Start.IsZero() && !w.End.IsZero() {
+			w.Start = time.Now().Truncate(time.Second)
+		}
+		if err := w.Validate(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+
+		windows, err := maintenance.ReadFile(*file)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		if err := maintenance.WriteFile(*file, append(windows, w)); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		fmt.Printf("Added maintenance window %s\n", w)
+		return 0
+
+	case "list":
+		if err := fs.Parse(args[1:]); err != nil {
+			return 2
+		}
+		windows, err := maintenance.ReadFile(*file)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		if len(windows) == 0 {
+			fmt.Printf("No maintenance windows in %s\n", *file)
+			return 0
+		}
+		now := time.Now()
+		for _, w := range windows {
+			line := w.String()
+			if w.Expired(now) {
+				line += "  [expired]"
+			}
+			fmt.Println(line)
+		}
+		return 0
+
+	case "remove":
+		expired := fs.Bool("expired", false, "Remove all windows that have ended")
+		if err := fs.Parse(args[1:]); err != nil {
+			return 2
+		}
+		ids := make(map[string]bool)
+		for _, id := range fs.Args() {
+			ids[id] = true
+		}
+		if len(ids) == 0 && !*expired {
+			fmt.Fprintln(os.Stderr, "Error: give the IDs of the windows to remove, or --expired")
+			return 1
+		}
+
+		windows, err := maintenance.ReadFile(*file)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		now := time.Now()
+		var kept []maintenance.Window
+		for _, w := range windows {
+			if ids[w.ID] || (*expired && w.Expired(now)) {
+				fmt.Printf("Removed maintenance window %s\n", w)
+				delete(ids, w.ID)
+				continue
+			}
+			kept = append(kept, w)
+		}
+		for id := range ids {
+			fmt.Fprintf(os.Stderr, "Warning: no maintenance window with ID %s\n", id)
+		}
+		if err := maintenance.WriteFile(*file, kept); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	fmt.Fprintf(os.Stderr, "Error: unknown maintenance command %q\n\n", args[0])
+	usage()
+	return 1
+}
+
+// parseWindowTime accepts RFC 3339 or "2006-01-02 15:04" in local time. An
+// empty string is the zero time.
+func parseWindowTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	return time.ParseInLocation("2006-01-02 15:04", s, time.Local)
+}
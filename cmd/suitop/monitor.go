@@ -0,0 +1,625 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"google.golang.org/grpc"
+
+	"suitop/internal/alert"
+	"suitop/internal/api"
+	"suitop/internal/chain"
+	"suitop/internal/checkpoint"
+	"suitop/internal/config"
+	"suitop/internal/events"
+	sgrpc "suitop/internal/grpc"
+	"suitop/internal/history"
+	"suitop/internal/maintenance"
+	"suitop/internal/metrics"
+	"suitop/internal/node"
+	"suitop/internal/notify"
+	"suitop/internal/rpc"
+	"suitop/internal/tui"
+	"suitop/internal/types"
+	"suitop/internal/util"
+	"suitop/internal/validator"
+	"suitop/internal/watch"
+
+	subPb "suitop/pb/sui/rpc/v2alpha"
+	rpcPb "suitop/pb/sui/rpc/v2beta"
+)
+
+// runMonitorCommand implements `suitop monitor`, the live validator monitor,
+// and returns the process exit code.
+func runMonitorCommand(args []string) int {
+	return runMonitor("monitor", args, false)
+}
+
+// runMonitor subscribes to checkpoints and shows validator signatures in the
+// TUI or as plain text. With recordDataset it records an uptime dataset in
+// plain mode instead, for `suitop dataset record`.
+func runMonitor(name string, args []string, recordDataset bool) int {
+	summary := "Monitor validator signatures on checkpoints as they are produced."
+	if recordDataset {
+		summary = "Record per-epoch validator signature datasets until 'q' then Enter is pressed."
+	}
+	fs := newFlagSet(name, "[flags]", summary)
+	configFlags := addConfigFlags(fs)
+	flags := addMonitorFlags(fs)
+	notifyTestFlagVal := fs.Bool("notify-test", false, "Send a test notification to every configured sink and exit")
+	var datasetFolderFlagVal, validatorFlagVal *string
+	if recordDataset {
+		datasetFolderFlagVal = fs.String("folder", "", "Folder to write datasets to (overrides DATASET_FOLDER env var)")
+	} else {
+		validatorFlagVal = fs.String("validator", "", "Show a dashboard of this validator, by name, address or protocol pubkey, instead of the validator table (TUI only)")
+	}
+
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(os.Stderr, "Error: Unrecognized arguments: %v\n\n", fs.Args())
+		fs.Usage()
+		return 2
+	}
+
+	// Load configuration from internal defaults, the config file and environment variables
+	cfg, err := configFlags.load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	// Override configuration with command-line flags if they were explicitly set
+	flags.apply(cfg)
+	if recordDataset {
+		cfg.DatasetConfig.Generate = true
+	}
+	if wasSet(fs, "folder") {
+		cfg.DatasetConfig.Folder = *datasetFolderFlagVal
+	}
+
+	// Datasets are recorded in plain mode, and structured output is meant for
+	// pipes, which the TUI cannot drive.
+	if cfg.DatasetConfig.Generate || (cfg.UIConfig.Output != "" && cfg.UIConfig.Output != config.OutputText) {
+		cfg.UIConfig.PlainMode = true
+	}
+	focusSpec := ""
+	if validatorFlagVal != nil {
+		focusSpec = *validatorFlagVal
+	}
+	if focusSpec != "" && cfg.UIConfig.PlainMode {
+		fmt.Fprintln(os.Stderr, "Error: --validator shows a TUI dashboard and cannot be used in plain mode; use --watch with --watch-only instead")
+		return 2
+	}
+	// If --log-file was NOT set, cfg.LogConfig.FilePath retains the value from config.Load()
+	// (which is from LOG_FILE_PATH env var or config's internal default like ~/.suitop/logs/suitop.log).
+	// The flag's own default "./logs/suitop.log" (held in *logFilePathFlagVal if flag not set) is not automatically applied here yet.
+
+	// Special handling for TUI mode logging
+	if !cfg.UIConfig.PlainMode { // If current mode is TUI (after considering env vars and --plain flag)
+		cfg.LogConfig.ToFile = true    // Force logging to file for TUI
+		cfg.LogConfig.ToStderr = false // Don't log to stderr for TUI
+
+		// If LogFilePath is still considered empty or not meaningfully set for TUI mode,
+		// and TUI implies logging to file, ensure a path.
+		// A common convention is for config.Load() to provide a non-empty default.
+		// If LOG_FILE_PATH was set, cfg.LogConfig.FilePath has that.
+		// If --log-file was set, cfg.LogConfig.FilePath has that.
+		// If neither of those, and config.Load() resulted in an empty string (e.g. no env var and no internal default set by config.Load):
+		if cfg.LogConfig.FilePath == "" {
+			// Fallback to the default path defined for the --log-file flag itself.
+			cfg.LogConfig.FilePath = defaultLogFilePath
+		}
+	}
+
+	// Fill in the network's default endpoints and other derived settings
+	if err := cfg.Finalize(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	// Setup logging
+	logConfig := util.LogConfig{
+		ToStderr:  cfg.LogConfig.ToStderr,
+		ToFile:    cfg.LogConfig.ToFile,
+		FilePath:  cfg.LogConfig.FilePath,
+		WithTime:  cfg.LogConfig.WithTime,
+		WithLevel: cfg.LogConfig.WithLevel,
+	}
+	logCleanup, err := util.SetupLogging(logConfig)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error setting up logging: %v\n", err)
+		return 1
+	}
+	defer func() { logCleanup() }() // The log file is reopened if it is archived
+
+	// fail logs a startup error and returns the exit code, unlike log.Fatalf
+	// letting the deferred cleanup run. In TUI mode logs only go to the log
+	// file, so the error is printed to stderr as well.
+	fail := func(format string, args ...interface{}) int {
+		log.Printf(format, args...)
+		if !cfg.LogConfig.ToStderr {
+			fmt.Fprintf(os.Stderr, "Error: "+format+"\n", args...)
+		}
+		return 1
+	}
+
+	var notifyCfg *notify.Config
+	if cfg.NotifyConfig.ConfigFile != "" {
+		notifyCfg, err = notify.LoadConfig(cfg.NotifyConfig.ConfigFile)
+		if err != nil {
+			return fail("Failed to load notification sinks: %v", err)
+		}
+		log.Printf("Loaded %d notification sinks from %s", len(notifyCfg.Sinks), cfg.NotifyConfig.ConfigFile)
+	}
+	if *notifyTestFlagVal {
+		var notifier *notify.Dispatcher
+		if notifyCfg != nil {
+			if notifier, err = notify.NewDispatcher(*notifyCfg, cfg.Network); err != nil {
+				return fail("Failed to set up notification sinks: %v", err)
+			}
+		}
+		return runNotifyTest(notifier)
+	}
+
+	log.Printf("Connecting to Sui node for subscriptions: %s", cfg.SuiNode)
+
+	// Shared context for managing shutdown
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// Setup signal handling using the utility function
+	stopSignalHandler := util.SetupSignalHandler(cancel)
+	defer stopSignalHandler() // Ensure the signal handler goroutine is cleaned up
+
+	if cfg.DatasetConfig.Generate {
+		go func() {
+			scanner := bufio.NewScanner(os.Stdin)
+			for scanner.Scan() {
+				if strings.TrimSpace(scanner.Text()) == "q" {
+					cancel()
+					return
+				}
+			}
+		}()
+	}
+
+	conn, err := dialNode(ctx, cfg, grpc.WithUnaryInterceptor(sgrpc.MetricsUnaryClientInterceptor()))
+	if err != nil {
+		return fail("Failed to connect to gRPC node %s: %v", cfg.SuiNode, err)
+	}
+	defer conn.Close()
+
+	log.Println("Successfully connected to gRPC node for subscriptions.")
+
+	// Check that both endpoints serve the configured network's chain
+	rpcClient := rpc.NewClient(cfg.RPCClientConfig)
+	ledgerClient := rpcPb.NewLedgerServiceClient(conn)
+	queryChain := func(ctx context.Context) (chain.Info, error) {
+		ctx, cancel := context.WithTimeout(ctx, cfg.DefaultRPCTimeout)
+		defer cancel()
+		return chain.Query(ctx, ledgerClient, rpcClient)
+	}
+	chainInfo, err := queryChain(ctx)
+	if err != nil {
+		return fail("Failed to identify the node's chain: %v", err)
+	}
+	if err := chainInfo.Verify(cfg.ExpectedChainID); err != nil {
+		return fail("Refusing to start: %v", err)
+	}
+	log.Printf("Node serves chain %s (chain ID %s)", chainInfo.Identifier, chainInfo.ChainID)
+
+	// Move data and logs recorded on a previous chain out of the way
+	logArchived, err := archiveOtherChains(cfg, chainInfo)
+	if err != nil {
+		return fail("Failed to archive data from a previous chain: %v", err)
+	}
+	if logArchived {
+		logCleanup()
+		if logCleanup, err = util.SetupLogging(logConfig); err != nil {
+			fmt.Fprintf(os.Stderr, "Error setting up logging: %v\n", err)
+			return 1
+		}
+	}
+
+	// Label the UI, metrics and notifications with the chain the node reports,
+	// which also names custom networks and localnets correctly.
+	networkLabel := cfg.Network
+	if chainInfo.Chain != "" && chainInfo.Chain != cfg.Network {
+		networkLabel = chainInfo.Chain
+		log.Printf("Node reports chain %q (configured network %q)", networkLabel, cfg.Network)
+	}
+
+	var notifier *notify.Dispatcher
+	if notifyCfg != nil {
+		if notifier, err = notify.NewDispatcher(*notifyCfg, networkLabel); err != nil {
+			return fail("Failed to set up notification sinks: %v", err)
+		}
+	}
+
+	subClient := subPb.NewSubscriptionServiceClient(conn)
+
+	// Initial committee load
+	// The validator.Loader will use the rpc.Client internally, which gets its URL from config
+	// Labels from the labels file replace on-chain names in every committee it loads.
+	valLoader, err := newValidatorLoader(cfg)
+	if err != nil {
+		return fail("Failed to load validator labels: %v", err)
+	}
+
+	initialCommittee, initialEpoch, err := valLoader.LoadLatestValidatorData(ctx)
+	if err != nil {
+		return fail("Failed to load initial committee data: %v", err)
+	}
+	log.Printf("Initial committee for epoch %d loaded with %d validators.", initialEpoch, len(initialCommittee))
+
+	watchList, err := watch.Load(cfg.WatchConfig)
+	if err != nil {
+		return fail("Failed to load the watchlist: %v", err)
+	}
+	if watchList.Len() > 0 {
+		committeeInfo := make([]types.ValidatorInfo, len(initialCommittee))
+		for i, v := range initialCommittee {
+			committeeInfo[i] = v.ToTypesInfo()
+		}
+		for _, spec := range watchList.Unmatched(committeeInfo) {
+			log.Printf("Warning: watched validator %q is not in the committee of epoch %d", spec, initialEpoch)
+		}
+	}
+
+	// Single-validator mode
+	var focusValidator validator.ValidatorInfo
+	if focusSpec != "" {
+		found := false
+		for _, v := range initialCommittee {
+			if v.Matches(focusSpec) {
+				focusValidator, found = v, true
+				break
+			}
+		}
+		if !found {
+			return fail("Validator %q is not in the committee of epoch %d", focusSpec, initialEpoch)
+		}
+		log.Printf("Showing the dashboard of %s (%s)", focusValidator.Name, focusValidator.SuiAddress)
+	}
+
+	// Initialize stats for the initial committee
+	// The stats package will manage the map and its lifecycle.
+	statsManager := checkpoint.NewStatsManager()
+	statsManager.InitializeCommitteeStats(initialCommittee)
+
+	// Channel for checkpoints from gRPC subscription
+	checkpointStream := make(chan *rpcPb.Checkpoint, 100) // Using the correct type from pb
+
+	// Start the gRPC subscriber
+	// The subscriber will take the config for retry delays etc.
+	// Events published by the subscriber and the processor
+	eventBus := events.NewBus(0)
+
+	go sgrpc.SubscribeToCheckpoints(ctx, subClient, checkpointStream, cfg.GRPCSubscriberConfig, eventBus)
+
+	// Exit when the node is wiped and starts a new chain, detected by the
+	// chain check or by the processor when the epoch goes backwards. The next
+	// start archives the old chain's data and loads the new committee.
+	var chainReset atomic.Bool
+	resetChain := func(reason string) {
+		if chainReset.Swap(true) {
+			return
+		}
+		msg := reason + "; exiting so that suitop restarts on the new chain"
+		log.Printf("Chain reset detected: %s", msg)
+		eventBus.Publish(events.Event{Kind: events.KindChainReset, Time: time.Now(), Message: msg})
+		// Give notification sinks a moment to deliver the event
+		time.AfterFunc(chainResetGrace, cancel)
+	}
+	if cfg.ChainConfig.CheckInterval > 0 {
+		go chain.Watch(ctx, cfg.ChainConfig.CheckInterval, queryChain, chainInfo, func(info chain.Info) {
+			resetChain(fmt.Sprintf("node now serves chain %s instead of %s", info.Identifier, chainInfo.Identifier))
+		})
+	}
+
+	log.Println("Starting checkpoint processing loop...")
+
+	// The processor will contain the main loop logic
+	var datasetMgr *checkpoint.DatasetManager
+	if cfg.DatasetConfig.Generate {
+		var err error
+		datasetMgr, err = checkpoint.NewDatasetManager(cfg.DatasetConfig.Folder)
+		if err != nil {
+			return fail("failed to create dataset folder: %v", err)
+		}
+	}
+
+	var historyStore *history.Store
+	if cfg.HistoryConfig.Enabled {
+		historyStore, err = history.Open(cfg.HistoryConfig)
+		if err != nil {
+			return fail("failed to open history store: %v", err)
+		}
+	}
+
+	processor := checkpoint.NewProcessor(valLoader, statsManager, cfg.ProcessorConfig, cfg.UIConfig.PlainMode, datasetMgr, historyStore)
+	processor.SetEventBus(eventBus)
+	processor.OnChainReset(resetChain)
+	processor.SetWatchlist(watchList)
+
+	maintenanceSchedule, err := maintenance.Open(cfg.MaintenanceConfig.File)
+	if err != nil {
+		return fail("Failed to load maintenance windows: %v", err)
+	}
+	processor.SetMaintenance(maintenanceSchedule)
+	go maintenanceSchedule.Run(ctx)
+
+	// Poll the node's health; started once the UI hooks are registered below
+	nodeMonitor := node.NewMonitor(ledgerClient, cfg.NodeConfig)
+	processor.SetNodeMonitor(nodeMonitor)
+
+	if cfg.MetricsConfig.ListenAddr != "" {
+		snapshotCollector := metrics.NewSnapshotCollector()
+		processor.OnSnapshot(snapshotCollector.Observe)
+		go metrics.Serve(ctx, cfg.MetricsConfig.ListenAddr, metrics.NewRegistry(networkLabel, snapshotCollector))
+	}
+
+	if cfg.APIConfig.ListenAddr != "" {
+		apiServer := api.NewServer(cfg.APIConfig, networkLabel, eventBus)
+		processor.OnSnapshot(apiServer.Observe)
+		nodeMonitor.OnUpdate(apiServer.ObserveNode)
+		go apiServer.Serve(ctx)
+	}
+
+	if notifier != nil {
+		go notifier.Run(ctx, eventBus)
+	}
+
+	var alertEngine *alert.Engine
+	if cfg.AlertConfig.RulesFile != "" {
+		rules, err := alert.LoadRules(cfg.AlertConfig.RulesFile)
+		if err != nil {
+			return fail("Failed to load alert rules: %v", err)
+		}
+		alertEngine = alert.NewEngine(*rules)
+		log.Printf("Loaded %d alert rules from %s", len(rules.Rules), cfg.AlertConfig.RulesFile)
+	} else if len(cfg.AlertConfig.AlertmanagerURLs) > 0 {
+		alertEngine = alert.NewEngine(alert.DefaultRules(cfg.AlertConfig.MissStreakThreshold, cfg.AlertConfig.StallThreshold))
+		log.Printf("Using built-in alert rules (miss streak > %d, no checkpoints for %v)", cfg.AlertConfig.MissStreakThreshold, cfg.AlertConfig.StallThreshold)
+	}
+	if alertEngine != nil {
+		alertEngine.AddNotifier(ctx, alert.LogNotifier)
+		if len(cfg.AlertConfig.AlertmanagerURLs) > 0 {
+			am := alert.NewAlertmanagerNotifier(cfg.AlertConfig.AlertmanagerURLs, networkLabel, cfg.AlertConfig.AlertmanagerResendInterval, alertEngine.Active)
+			alertEngine.AddNotifier(ctx, am)
+			go am.Run(ctx)
+			log.Printf("Pushing alerts to Alertmanager at %s", strings.Join(cfg.AlertConfig.AlertmanagerURLs, ", "))
+		}
+	}
+
+	if cfg.UIConfig.PlainMode {
+		// In plain mode, run the processor directly in this goroutine
+		if cfg.DatasetConfig.Generate {
+			// Keep stdout to the records when they are structured
+			prompt := os.Stdout
+			if cfg.UIConfig.Output != config.OutputText {
+				prompt = os.Stderr
+			}
+			fmt.Fprintln(prompt, "Dataset generation mode active. Press 'q' then Enter to stop and save.")
+		}
+		if alertEngine != nil {
+			go alertEngine.Run(ctx, eventBus)
+		}
+		go nodeMonitor.Run(ctx)
+		processor.Run(ctx, initialEpoch, initialCommittee, checkpointStream, nil)
+	} else {
+		// Channel for sending state updates to the UI
+		stateChan := make(chan types.SnapshotMsg, 200)
+
+		// Start the processor in a goroutine
+		go processor.Run(ctx, initialEpoch, initialCommittee, checkpointStream, stateChan)
+
+		// Convert the validator info to the types package format for the UI
+		committeeForUI := make([]types.ValidatorInfo, len(initialCommittee))
+		for i, v := range initialCommittee {
+			committeeForUI[i] = v.ToTypesInfo()
+		}
+
+		// Initialize the Bubble Tea model
+		model := tui.New(initialEpoch, committeeForUI, networkLabel)
+		model.SetWatchlist(watchList)
+		model.SetHistory(historyStore)
+		if focusSpec != "" {
+			model.SetFocus(focusValidator.SuiAddress)
+		}
+
+		// Program options based on config
+		programOpts := []tea.ProgramOption{
+			tea.WithMouseCellMotion(), // Scroll and select in the validator table
+		}
+
+		// Add alt screen option if not disabled
+		if !cfg.UIConfig.NoAltScreen {
+			programOpts = append(programOpts, tea.WithAltScreen())
+		}
+
+		// Create the tea program with all necessary options
+		p := tea.NewProgram(model, programOpts...)
+
+		// Relay alert state changes to the UI
+		if alertEngine != nil {
+			alertEngine.OnChange(func(active []alert.Alert) {
+				alerts := make(tui.AlertsMsg, len(active))
+				for i, a := range active {
+					alerts[i] = a.ToTypesInfo()
+				}
+				p.Send(alerts)
+			})
+			go alertEngine.Run(ctx, eventBus)
+		}
+
+		// Relay node health polls to the UI
+		nodeMonitor.OnUpdate(func(h types.NodeHealth) {
+			p.Send(tui.NodeHealthMsg(h))
+		})
+		go nodeMonitor.Run(ctx)
+
+		// Relay the dashboard validator's staking metadata to the UI
+		if focusSpec != "" {
+			go pollValidatorMeta(ctx, valLoader, focusValidator.SuiAddress, func(meta types.ValidatorMeta) {
+				p.Send(tui.ValidatorMetaMsg(meta))
+			})
+		}
+
+		// Set up a goroutine to relay state updates from the processor to the UI
+		go func() {
+			for {
+				select {
+				case msg, ok := <-stateChan:
+					if !ok {
+						return // Channel closed
+					}
+					p.Send(tui.SnapshotMsg(msg))
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+
+		// Allow for graceful shutdown by quitting the program when the context is done
+		go func() {
+			<-ctx.Done()
+			log.Println("Shutdown signal received, closing UI...")
+			p.Quit()
+		}()
+
+		// Start the UI in the main goroutine
+		if err := p.Start(); err != nil {
+			return fail("Error running UI: %v", err)
+		}
+	}
+
+	log.Println("Application shut down.")
+
+	if chainReset.Load() {
+		fmt.Fprintln(os.Stderr, "The node's chain was reset; start suitop again to monitor the new chain")
+		return exitChainReset
+	}
+	return 0
+}
+
+// validatorMetaInterval is how often the single-validator dashboard refreshes
+// the validator's stake, commission and gas price vote.
+const validatorMetaInterval = 5 * time.Minute
+
+// pollValidatorMeta fetches the staking metadata of the validator at address
+// until ctx is done. After a failed fetch, the last fetched values are sent
+// along with the error.
+func pollValidatorMeta(ctx context.Context, loader *validator.Loader, address string, send func(types.ValidatorMeta)) {
+	ticker := time.NewTicker(validatorMetaInterval)
+	defer ticker.Stop()
+	var last types.ValidatorMeta
+	for {
+		meta, err := loader.LoadMeta(ctx, address)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Printf("Failed to load the staking metadata of %s: %v", address, err)
+			meta = last
+			meta.Error = err.Error()
+		} else {
+			last = meta
+		}
+		send(meta)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// chainResetGrace is how long suitop keeps running after a chain reset so
+// that the chain_reset event reaches notification sinks.
+const chainResetGrace = 5 * time.Second
+
+// exitChainReset is the exit code after a chain reset, EX_TEMPFAIL from
+// sysexits.h, so that a supervisor restarts suitop on the new chain.
+const exitChainReset = 75
+
+// archiveOtherChains archives the log file, dataset and history recorded on a
+// chain other than info's and reports whether the log file was archived.
+func archiveOtherChains(cfg *config.Config, info chain.Info) (bool, error) {
+	var logArchived bool
+	if cfg.LogConfig.ToFile && cfg.LogConfig.FilePath != "" {
+		dir, err := chain.ClaimFile(cfg.LogConfig.FilePath, info)
+		if err != nil {
+			return false, err
+		}
+		if dir != "" {
+			log.Printf("Log file is from another chain; archived it to %s", dir)
+			logArchived = true
+		}
+	}
+
+	var folders []string
+	if cfg.DatasetConfig.Generate {
+		folders = append(folders, cfg.DatasetConfig.Folder)
+	}
+	// The default history folder lives inside the dataset folder and is
+	// archived with it.
+	if cfg.HistoryConfig.Enabled && !(cfg.DatasetConfig.Generate && isWithin(cfg.HistoryConfig.Folder, cfg.DatasetConfig.Folder)) {
+		folders = append(folders, cfg.HistoryConfig.Folder)
+	}
+	for _, folder := range folders {
+		dir, err := chain.ClaimFolder(folder, info)
+		if err != nil {
+			return logArchived, err
+		}
+		if dir != "" {
+			log.Printf("%s holds data from another chain; archived it to %s", folder, dir)
+		}
+	}
+	return logArchived, nil
+}
+
+func isWithin(path, folder string) bool {
+	rel, err := filepath.Rel(folder, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// runNotifyTest sends a test notification to every sink and returns the process exit code.
+func runNotifyTest(notifier *notify.Dispatcher) int {
+	if notifier == nil {
+		fmt.Fprintln(os.Stderr, "Error: --notify-test requires --notify-config or NOTIFY_CONFIG_FILE")
+		return 1
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	results := notifier.Test(ctx)
+	names := make([]string, 0, len(results))
+	for name := range results {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	code := 0
+	for _, name := range names {
+		err := results[name]
+		if err != nil {
+			fmt.Printf("%s: FAILED: %v\n", name, err)
+			code = 1
+		} else {
+			fmt.Printf("%s: ok\n", name)
+		}
+	}
+	return code
+}
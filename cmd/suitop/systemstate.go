@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"suitop/internal/rpc"
+)
+
+// runSystemStateCommand implements `suitop system-state`.
+func runSystemStateCommand(args []string) int {
+	fs := newFlagSet("system-state", "[flags]", "Print the latest Sui system state: epoch, protocol version, stake and active validators.")
+	configFlags := addConfigFlags(fs)
+	raw := fs.Bool("json", false, "Print the full suix_getLatestSuiSystemState result as JSON")
+	verbose := addVerboseFlag(fs)
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	setupCommandLogging(*verbose)
+
+	cfg, err := configFlags.loadFinal()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.DefaultRPCTimeout)
+	defer cancel()
+	client := rpc.NewClient(cfg.RPCClientConfig)
+
+	if *raw {
+		var resp struct {
+			rpc.BaseJSONRPCResponse
+			Result json.RawMessage `json:"result"`
+		}
+		if err := client.Call(ctx, "suix_getLatestSuiSystemState", []interface{}{}, &resp); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		out, err := json.MarshalIndent(resp.Result, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		fmt.Println(string(out))
+		return 0
+	}
+
+	state, err := client.GetLatestSuiSystemState(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Epoch:\t%s\n", state.Epoch)
+	fmt.Fprintf(w, "Protocol version:\t%s\n", state.ProtocolVersion)
+	fmt.Fprintf(w, "System state version:\t%s\n", state.SystemStateVersion)
+	fmt.Fprintf(w, "Reference gas price:\t%s MIST\n", state.ReferenceGasPrice)
+	fmt.Fprintf(w, "Total stake:\t%s SUI\n", formatSUI(state.TotalStake))
+	if start, err := strconv.ParseInt(state.EpochStartTimestampMs, 10, 64); err == nil {
+		fmt.Fprintf(w, "Epoch started:\t%s\n", time.UnixMilli(start).Local().Format("2006-01-02 15:04:05 MST"))
+	}
+	if d, err := strconv.ParseInt(state.EpochDurationMs, 10, 64); err == nil {
+		fmt.Fprintf(w, "Epoch duration:\t%s\n", time.Duration(d)*time.Millisecond)
+	}
+	fmt.Fprintf(w, "Active validators:\t%d\n", len(state.ActiveValidators))
+	w.Flush()
+
+	validators := append([]rpc.ActiveValidatorJSON(nil), state.ActiveValidators...)
+	sort.SliceStable(validators, func(i, j int) bool {
+		return atoi(validators[i].VotingPower) > atoi(validators[j].VotingPower)
+	})
+	fmt.Println()
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tADDRESS\tVOTING POWER\tSTAKE (SUI)\tCOMMISSION\tGAS PRICE")
+	for _, v := range validators {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%.2f%%\t%s\n", v.Name, v.SuiAddress, v.VotingPower, formatSUI(v.StakingPoolSuiBalance), float64(atoi(v.CommissionRate))/100, v.GasPrice)
+	}
+	w.Flush()
+	return 0
+}
+
+// formatSUI renders an amount in MIST as whole SUI with thousands separators.
+func formatSUI(mist string) string {
+	n, ok := new(big.Int).SetString(mist, 10)
+	if !ok {
+		return mist
+	}
+	s := n.Div(n, big.NewInt(1_000_000_000)).String()
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
@@ -0,0 +1,18 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+
+	"suitop/internal/version"
+)
+
+// runVersionCommand implements `suitop version`.
+func runVersionCommand(args []string) int {
+	fs := newFlagSet("version", "", "Print version information.")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	fmt.Printf("suitop %s, Platform: %s/%s, Go: %s\n", version.Info(), runtime.GOOS, runtime.GOARCH, runtime.Version())
+	return 0
+}
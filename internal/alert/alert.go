@@ -0,0 +1,80 @@
+package alert
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"suitop/internal/events"
+	"suitop/internal/types"
+)
+
+// State is the lifecycle state of an alert instance.
+type State string
+
+const (
+	StatePending  State = "pending"  // Condition holds but the rule's For duration has not elapsed
+	StateFiring   State = "firing"   // Condition has held for at least For
+	StateResolved State = "resolved" // Condition cleared after firing
+)
+
+// Alert is a single instance of a rule, keyed by rule name and validator.
+type Alert struct {
+	Rule      string               `json:"rule"`
+	Type      RuleType             `json:"type"`
+	Severity  string               `json:"severity"`
+	State     State                `json:"state"`
+	Validator *events.ValidatorRef `json:"validator,omitempty"`
+	Value     float64              `json:"value"`
+	Threshold float64              `json:"threshold"`
+	Summary   string               `json:"summary"`
+	Labels    map[string]string    `json:"labels,omitempty"`
+	Epoch     uint64               `json:"epoch"`
+	Sequence  uint64               `json:"sequence"`
+	ActiveAt  time.Time            `json:"active_at"` // When the condition started holding
+	FiredAt   time.Time            `json:"fired_at,omitempty"`
+	EndsAt    time.Time            `json:"ends_at,omitempty"`
+}
+
+// Key identifies an alert instance for deduplication.
+func (a Alert) Key() string {
+	if a.Validator != nil {
+		return a.Rule + "/" + a.Validator.Address
+	}
+	return a.Rule
+}
+
+// ToTypesInfo converts the alert to the types package format used by the UI.
+func (a Alert) ToTypesInfo() types.AlertInfo {
+	info := types.AlertInfo{
+		Rule:     a.Rule,
+		Severity: a.Severity,
+		State:    string(a.State),
+		Summary:  a.Summary,
+		ActiveAt: a.ActiveAt,
+	}
+	if a.Validator != nil {
+		info.ValidatorName = a.Validator.Name
+		info.ValidatorAddress = a.Validator.Address
+	}
+	return info
+}
+
+// Notifier receives firing and resolved alerts from the Engine.
+type Notifier interface {
+	Notify(ctx context.Context, a Alert) error
+}
+
+// NotifierFunc adapts a function to the Notifier interface.
+type NotifierFunc func(ctx context.Context, a Alert) error
+
+// Notify calls f.
+func (f NotifierFunc) Notify(ctx context.Context, a Alert) error {
+	return f(ctx, a)
+}
+
+// LogNotifier writes alerts to the standard logger.
+var LogNotifier = NotifierFunc(func(ctx context.Context, a Alert) error {
+	log.Printf("[alert] %s %s (%s): %s", a.State, a.Rule, a.Severity, a.Summary)
+	return nil
+})
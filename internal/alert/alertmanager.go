@@ -0,0 +1,209 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const alertmanagerTimeout = 10 * time.Second
+
+// amAlert is an alert in the Alertmanager v2 API format.
+type amAlert struct {
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations"`
+	StartsAt     time.Time         `json:"startsAt"`
+	EndsAt       time.Time         `json:"endsAt"`
+	GeneratorURL string            `json:"generatorURL,omitempty"`
+}
+
+// AlertmanagerNotifier pushes alerts to one or more Alertmanager instances and
+// re-sends firing alerts periodically, as Alertmanager expects from its clients.
+type AlertmanagerNotifier struct {
+	urls     []string
+	network  string
+	interval time.Duration
+	source   func() []Alert
+	client   *http.Client
+
+	mu     sync.Mutex
+	active map[string]amAlert
+}
+
+// NewAlertmanagerNotifier creates a notifier for the given Alertmanager base URLs.
+// Every resendInterval the firing alerts of source, usually Engine.Active, are
+// re-sent and the ones no longer active resolved, so that Alertmanager catches
+// up on notifications the engine's rate limit or queues dropped.
+func NewAlertmanagerNotifier(urls []string, network string, resendInterval time.Duration, source func() []Alert) *AlertmanagerNotifier {
+	return &AlertmanagerNotifier{
+		urls:     urls,
+		network:  network,
+		interval: resendInterval,
+		source:   source,
+		client:   &http.Client{Timeout: alertmanagerTimeout},
+		active:   make(map[string]amAlert),
+	}
+}
+
+// Notify posts a firing or resolved alert.
+func (n *AlertmanagerNotifier) Notify(ctx context.Context, a Alert) error {
+	n.mu.Lock()
+	am, ok := n.active[a.Key()]
+	if !ok {
+		// Labels are fixed when the alert first fires so that it keeps the same
+		// identity in Alertmanager while its value and epoch move on.
+		am = amAlert{Labels: n.labels(a), StartsAt: a.FiredAt}
+	}
+	am.Annotations = annotations(a)
+	if a.State == StateResolved {
+		am.EndsAt = a.EndsAt
+		delete(n.active, a.Key())
+	} else {
+		am.EndsAt = n.expiry(time.Now())
+		n.active[a.Key()] = am
+	}
+	n.mu.Unlock()
+
+	return n.post(ctx, []amAlert{am})
+}
+
+// Run re-sends the active alerts every resend interval until ctx is done.
+func (n *AlertmanagerNotifier) Run(ctx context.Context) {
+	ticker := time.NewTicker(n.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case now := <-ticker.C:
+			batch := n.resend(now)
+			if len(batch) == 0 {
+				continue
+			}
+			if err := n.post(ctx, batch); err != nil {
+				log.Printf("Failed to re-send %d alerts to Alertmanager: %v", len(batch), err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// resend brings the active alerts in line with the source and returns the
+// batch to post: the firing alerts with a new expiry and the ones that are no
+// longer active, resolved.
+func (n *AlertmanagerNotifier) resend(now time.Time) []amAlert {
+	// The source is read under the lock: a resolution notified between the
+	// snapshot and the merge would otherwise be undone by the stale snapshot.
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	var firing map[string]Alert
+	if n.source != nil {
+		firing = make(map[string]Alert)
+		for _, a := range n.source() {
+			if a.State == StateFiring {
+				firing[a.Key()] = a
+			}
+		}
+	}
+	for key, a := range firing {
+		am, ok := n.active[key]
+		if !ok {
+			am = amAlert{Labels: n.labels(a), StartsAt: a.FiredAt}
+		}
+		am.Annotations = annotations(a)
+		n.active[key] = am
+	}
+	batch := make([]amAlert, 0, len(n.active))
+	for key, am := range n.active {
+		if _, ok := firing[key]; firing != nil && !ok {
+			am.EndsAt = now
+			delete(n.active, key)
+		} else {
+			am.EndsAt = n.expiry(now)
+			n.active[key] = am
+		}
+		batch = append(batch, am)
+	}
+	return batch
+}
+
+// expiry is the endsAt sent with firing alerts. If suitop stops re-sending,
+// Alertmanager resolves the alert after a few missed intervals.
+func (n *AlertmanagerNotifier) expiry(now time.Time) time.Time {
+	return now.Add(4 * n.interval)
+}
+
+func (n *AlertmanagerNotifier) labels(a Alert) map[string]string {
+	labels := map[string]string{
+		"alertname": a.Rule,
+		"severity":  a.Severity,
+		"network":   n.network,
+		"epoch":     strconv.FormatUint(a.Epoch, 10),
+		"source":    "suitop",
+	}
+	if a.Validator != nil {
+		labels["validator"] = a.Validator.Name
+		labels["validator_address"] = a.Validator.Address
+	}
+	for k, v := range a.Labels {
+		labels[k] = v
+	}
+	return labels
+}
+
+func annotations(a Alert) map[string]string {
+	return map[string]string{
+		"summary":   a.Summary,
+		"value":     strconv.FormatFloat(a.Value, 'f', -1, 64),
+		"threshold": strconv.FormatFloat(a.Threshold, 'f', -1, 64),
+		"sequence":  strconv.FormatUint(a.Sequence, 10),
+	}
+}
+
+// post sends the alerts to every Alertmanager and fails only if all of them fail.
+func (n *AlertmanagerNotifier) post(ctx context.Context, alerts []amAlert) error {
+	body, err := json.Marshal(alerts)
+	if err != nil {
+		return err
+	}
+	var errs []string
+	for _, base := range n.urls {
+		if err := n.postTo(ctx, base, body); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) == len(n.urls) {
+		return fmt.Errorf("error posting to Alertmanager: %s", strings.Join(errs, "; "))
+	}
+	for _, e := range errs {
+		log.Printf("Warning: %s", e)
+	}
+	return nil
+}
+
+func (n *AlertmanagerNotifier) postTo(ctx context.Context, base string, body []byte) error {
+	url := strings.TrimRight(base, "/") + "/api/v2/alerts"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("%s: %w", url, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("%s returned status %s: %s", url, resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
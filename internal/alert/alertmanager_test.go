@@ -0,0 +1,174 @@
+package alert
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"suitop/internal/events"
+)
+
+// fakeAlertmanager keeps the alert batches posted to it
+type fakeAlertmanager struct {
+	mu      sync.Mutex
+	batches [][]amAlert
+}
+
+func (f *fakeAlertmanager) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/api/v2/alerts" || r.Method != http.MethodPost {
+		http.NotFound(w, r)
+		return
+	}
+	var batch []amAlert
+	if err := json.NewDecoder(r.Body).Decode(&batch); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	f.mu.Lock()
+	f.batches = append(f.batches, batch)
+	f.mu.Unlock()
+}
+
+func (f *fakeAlertmanager) last() []amAlert {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.batches) == 0 {
+		return nil
+	}
+	return f.batches[len(f.batches)-1]
+}
+
+func firingAlert(rule string, v *events.ValidatorRef) Alert {
+	return Alert{Rule: rule, Severity: "warning", State: StateFiring, Validator: v, Summary: rule + " fired", Epoch: 7, FiredAt: time.Now()}
+}
+
+func TestAlertmanagerNotify(t *testing.T) {
+	am := &fakeAlertmanager{}
+	server := httptest.NewServer(am)
+	defer server.Close()
+	n := NewAlertmanagerNotifier([]string{server.URL + "/"}, "testnet", time.Minute, nil)
+
+	a := firingAlert("streak", &alpha)
+	if err := n.Notify(context.Background(), a); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	batch := am.last()
+	if len(batch) != 1 {
+		t.Fatalf("posted %d alerts, want 1", len(batch))
+	}
+	labels := batch[0].Labels
+	for k, want := range map[string]string{"alertname": "streak", "network": "testnet", "validator": "Alpha", "validator_address": "0xa", "epoch": "7", "source": "suitop"} {
+		if labels[k] != want {
+			t.Errorf("label %s = %q, want %q", k, labels[k], want)
+		}
+	}
+	if !batch[0].EndsAt.After(time.Now()) {
+		t.Errorf("firing alert ends at %v, want in the future", batch[0].EndsAt)
+	}
+
+	// The labels stay those of the first notification
+	a.Epoch = 8
+	a.State = StateResolved
+	a.EndsAt = time.Now()
+	if err := n.Notify(context.Background(), a); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if got := am.last()[0]; got.Labels["epoch"] != "7" || !got.EndsAt.Equal(a.EndsAt) {
+		t.Errorf("resolved alert = %+v, want epoch 7 ending at %v", got, a.EndsAt)
+	}
+	if len(n.active) != 0 {
+		t.Errorf("%d alerts still active after resolving", len(n.active))
+	}
+}
+
+func TestAlertmanagerResend(t *testing.T) {
+	var active []Alert
+	n := NewAlertmanagerNotifier(nil, "testnet", time.Minute, func() []Alert { return active })
+
+	// A firing alert the engine dropped is picked up from the active set,
+	// pending ones are not sent
+	pending := firingAlert("pending", nil)
+	pending.State = StatePending
+	active = []Alert{firingAlert("streak", &alpha), pending}
+	now := time.Now()
+	batch := n.resend(now)
+	if len(batch) != 1 || batch[0].Labels["alertname"] != "streak" || !batch[0].EndsAt.Equal(now.Add(4*time.Minute)) {
+		t.Fatalf("resend() = %+v, want the firing alert expiring in four intervals", batch)
+	}
+
+	// An alert notified but gone from the active set is resolved. There is
+	// no Alertmanager to post to, but the alert is tracked all the same.
+	n.Notify(context.Background(), firingAlert("margin", nil))
+	active = nil
+	now = now.Add(time.Minute)
+	batch = n.resend(now)
+	if len(batch) != 2 {
+		t.Fatalf("resend() = %+v, want both alerts resolved", batch)
+	}
+	for _, am := range batch {
+		if !am.EndsAt.Equal(now) {
+			t.Errorf("alert %s ends at %v, want %v", am.Labels["alertname"], am.EndsAt, now)
+		}
+	}
+	if batch = n.resend(now.Add(time.Minute)); len(batch) != 0 {
+		t.Errorf("resend() after resolving = %+v, want nothing", batch)
+	}
+}
+
+func TestAlertmanagerResendKeepsResolution(t *testing.T) {
+	a := firingAlert("streak", &alpha)
+	resolved := a
+	resolved.State = StateResolved
+	resolved.EndsAt = time.Now()
+
+	// The engine resolves the alert while the notifier reads the active set
+	var n *AlertmanagerNotifier
+	done := make(chan struct{})
+	n = NewAlertmanagerNotifier(nil, "testnet", time.Minute, func() []Alert {
+		go func() {
+			n.Notify(context.Background(), resolved)
+			close(done)
+		}()
+		time.Sleep(50 * time.Millisecond)
+		return []Alert{a}
+	})
+	n.Notify(context.Background(), a)
+
+	n.resend(time.Now())
+	<-done
+	if len(n.active) != 0 {
+		t.Errorf("%d alerts active after the resolution, want none", len(n.active))
+	}
+}
+
+func TestAlertmanagerFailover(t *testing.T) {
+	am := &fakeAlertmanager{}
+	up := httptest.NewServer(am)
+	defer up.Close()
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer down.Close()
+
+	tests := []struct {
+		name    string
+		urls    []string
+		wantErr bool
+	}{
+		{"one up", []string{down.URL, up.URL}, false},
+		{"all down", []string{down.URL}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := NewAlertmanagerNotifier(tt.urls, "testnet", time.Minute, nil)
+			err := n.Notify(context.Background(), firingAlert("streak", &beta))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Notify() error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
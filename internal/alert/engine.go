@@ -0,0 +1,421 @@
+package alert
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"suitop/internal/events"
+	"suitop/internal/types"
+)
+
+const (
+	evaluationInterval = time.Second
+	notifyQueueSize    = 100
+)
+
+// instance tracks one alert and when it was last notified.
+type instance struct {
+	alert        Alert
+	lastNotified time.Time
+}
+
+// Engine evaluates a RuleSet against the processor's events and dispatches
+// firing and resolved alerts to notifiers.
+type Engine struct {
+	rules RuleSet
+
+	mu        sync.RWMutex
+	instances map[string]*instance
+	onChange  []func([]Alert)
+	queues    []chan Alert
+
+	// Evaluation state, only touched by the Run goroutine.
+	validators     map[string]events.ValidatorRef
+	streaks        map[string]uint64
+	windows        map[int]*missWindow
+	lastCheckpoint time.Time
+	epoch          uint64
+	sequence       uint64
+	sent           []time.Time
+}
+
+// NewEngine creates an engine for the given rules.
+func NewEngine(rules RuleSet) *Engine {
+	e := &Engine{
+		rules:      rules,
+		instances:  make(map[string]*instance),
+		validators: make(map[string]events.ValidatorRef),
+		streaks:    make(map[string]uint64),
+		windows:    make(map[int]*missWindow),
+	}
+	for _, r := range rules.Rules {
+		if r.Type == RuleUptimeBelow {
+			if _, ok := e.windows[r.Window]; !ok {
+				e.windows[r.Window] = newMissWindow(r.Window)
+			}
+		}
+	}
+	return e
+}
+
+// AddNotifier registers a notifier. Each notifier is fed from its own queue so a
+// slow sink does not delay the others. It must be called before Run.
+func (e *Engine) AddNotifier(ctx context.Context, n Notifier) {
+	queue := make(chan Alert, notifyQueueSize)
+	e.queues = append(e.queues, queue)
+	go func() {
+		for {
+			select {
+			case a := <-queue:
+				if err := n.Notify(ctx, a); err != nil {
+					log.Printf("Failed to deliver alert %s: %v", a.Key(), err)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// OnChange registers a function called with the active alerts whenever an
+// alert becomes pending, fires or resolves. It must be called before Run.
+func (e *Engine) OnChange(fn func([]Alert)) {
+	e.onChange = append(e.onChange, fn)
+}
+
+// Active returns the pending and firing alerts, firing first.
+func (e *Engine) Active() []Alert {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	out := make([]Alert, 0, len(e.instances))
+	for _, inst := range e.instances {
+		out = append(out, inst.alert)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].State != out[j].State {
+			return out[i].State == StateFiring
+		}
+		return out[i].Key() < out[j].Key()
+	})
+	return out
+}
+
+// Run consumes events from the bus until ctx is done.
+func (e *Engine) Run(ctx context.Context, bus *events.Bus) {
+	sub, cancel := bus.Subscribe(1024)
+	defer cancel()
+
+	ticker := time.NewTicker(evaluationInterval)
+	defer ticker.Stop()
+	started := time.Now()
+
+	for {
+		select {
+		case ev := <-sub:
+			if ev.Kind == events.KindCheckpoint && ev.Checkpoint != nil {
+				e.evaluateCheckpoint(ev)
+			}
+		case now := <-ticker.C:
+			e.evaluateTick(now, started)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// evaluateCheckpoint updates the per-validator state and evaluates every
+// checkpoint-driven rule.
+func (e *Engine) evaluateCheckpoint(ev events.Event) {
+	now := time.Now()
+	e.lastCheckpoint = now
+	epochChanged := e.epoch != 0 && ev.Epoch != e.epoch
+	e.epoch = ev.Epoch
+	e.sequence = ev.Sequence
+
+	unplanned := make(map[string]bool, len(ev.Checkpoint.Missing))
+	for _, v := range ev.Checkpoint.Missing {
+		if !v.Maintenance {
+			unplanned[v.Address] = true
+		}
+		v.Maintenance = false
+		e.validators[v.Address] = v
+	}
+	// Validators only become known to the engine once they miss a checkpoint;
+	// until then they have signed everything and cannot trigger a rule. Misses
+	// inside a maintenance window do not count towards a streak.
+	for addr := range e.validators {
+		if unplanned[addr] {
+			e.streaks[addr]++
+		} else {
+			e.streaks[addr] = 0
+		}
+	}
+	// Planned maintenance does not count against uptime
+	for _, w := range e.windows {
+		w.push(unplanned)
+	}
+
+	changed := false
+	for _, r := range e.rules.Rules {
+		switch r.Type {
+		case RuleMissStreak:
+			for addr, ref := range e.validators {
+				if !r.matches(ref.Name, addr) {
+					continue
+				}
+				streak := float64(e.streaks[addr])
+				summary := fmt.Sprintf("%s missed %d consecutive checkpoints (threshold %d)", ref.Name, e.streaks[addr], int(r.Threshold))
+				changed = e.update(r, &ref, streak > r.Threshold, streak, summary, now) || changed
+			}
+		case RuleUptimeBelow:
+			w := e.windows[r.Window]
+			if !w.full() {
+				continue
+			}
+			for addr, ref := range e.validators {
+				if !r.matches(ref.Name, addr) {
+					continue
+				}
+				uptime := w.uptime(addr) * 100
+				summary := fmt.Sprintf("%s uptime over the last %d checkpoints is %.2f%% (threshold %.2f%%)", ref.Name, r.Window, uptime, r.Threshold)
+				changed = e.update(r, &ref, uptime < r.Threshold, uptime, summary, now) || changed
+			}
+		case RuleQuorumMarginBelow:
+			total := ev.Checkpoint.TotalPower
+			if total <= 0 {
+				continue
+			}
+			quorum := types.QuorumThreshold(total)
+			margin := float64(ev.Checkpoint.SignedPower-quorum) / float64(total) * 100
+			summary := fmt.Sprintf("Signed voting power %d is %.2f%% of total above quorum %d (threshold %.2f%%)", ev.Checkpoint.SignedPower, margin, quorum, r.Threshold)
+			changed = e.update(r, nil, margin < r.Threshold, margin, summary, now) || changed
+		}
+	}
+	if epochChanged {
+		e.prune()
+	}
+	if changed {
+		e.notifyChange()
+	}
+}
+
+// prune forgets validators that have no miss streak and no miss in any uptime
+// window. Every rule has just resolved for them, and they become known again
+// on their next miss, so validators that left the committee do not linger.
+func (e *Engine) prune() {
+	for addr := range e.validators {
+		if e.streaks[addr] > 0 {
+			continue
+		}
+		missed := false
+		for _, w := range e.windows {
+			if w.misses[addr] > 0 {
+				missed = true
+				break
+			}
+		}
+		if !missed {
+			delete(e.validators, addr)
+			delete(e.streaks, addr)
+		}
+	}
+}
+
+// evaluateTick evaluates time-driven rules and advances pending and repeating alerts.
+func (e *Engine) evaluateTick(now, started time.Time) {
+	changed := false
+	for _, r := range e.rules.Rules {
+		if r.Type != RuleSourceStalled {
+			continue
+		}
+		last := e.lastCheckpoint
+		if last.IsZero() {
+			last = started
+		}
+		idle := now.Sub(last)
+		summary := fmt.Sprintf("No checkpoint processed for %s (threshold %s)", idle.Truncate(time.Second), r.For)
+		changed = e.update(r, nil, idle >= r.For, idle.Seconds(), summary, now) || changed
+	}
+
+	e.mu.Lock()
+	var notify []Alert
+	for _, inst := range e.instances {
+		rule := e.rule(inst.alert.Rule)
+		switch {
+		case inst.alert.State == StatePending && now.Sub(inst.alert.ActiveAt) >= rule.pendingFor():
+			inst.alert.State = StateFiring
+			inst.alert.FiredAt = now
+			inst.lastNotified = now
+			notify = append(notify, inst.alert)
+			changed = true
+		case inst.alert.State == StateFiring && rule.RepeatInterval > 0 && now.Sub(inst.lastNotified) >= rule.RepeatInterval:
+			inst.lastNotified = now
+			notify = append(notify, inst.alert)
+		}
+	}
+	e.mu.Unlock()
+
+	for _, a := range notify {
+		e.dispatch(a, now)
+	}
+	if changed {
+		e.notifyChange()
+	}
+}
+
+// update applies a rule evaluation to the alert instance identified by the rule
+// and validator. It reports whether the set of active alerts changed.
+func (e *Engine) update(r Rule, ref *events.ValidatorRef, active bool, value float64, summary string, now time.Time) bool {
+	key := r.Name
+	if ref != nil {
+		key += "/" + ref.Address
+	}
+
+	e.mu.Lock()
+	inst, exists := e.instances[key]
+	if !active {
+		if !exists {
+			e.mu.Unlock()
+			return false
+		}
+		delete(e.instances, key)
+		e.mu.Unlock()
+		if inst.alert.State == StateFiring {
+			resolved := inst.alert
+			resolved.State = StateResolved
+			resolved.EndsAt = now
+			resolved.Value = value
+			resolved.Summary = summary
+			resolved.Epoch = e.epoch
+			resolved.Sequence = e.sequence
+			e.dispatch(resolved, now)
+		}
+		return true
+	}
+
+	changed := false
+	if !exists {
+		inst = &instance{alert: Alert{
+			Rule:      r.Name,
+			Type:      r.Type,
+			Severity:  r.Severity,
+			State:     StatePending,
+			Validator: ref,
+			Threshold: r.Threshold,
+			Labels:    r.Labels,
+			ActiveAt:  now,
+		}}
+		e.instances[key] = inst
+		changed = true
+	}
+	inst.alert.Value = value
+	inst.alert.Summary = summary
+	inst.alert.Epoch = e.epoch
+	inst.alert.Sequence = e.sequence
+
+	var fire bool
+	if inst.alert.State == StatePending && now.Sub(inst.alert.ActiveAt) >= r.pendingFor() {
+		inst.alert.State = StateFiring
+		inst.alert.FiredAt = now
+		inst.lastNotified = now
+		fire = true
+		changed = true
+	}
+	firing := inst.alert
+	e.mu.Unlock()
+
+	if fire {
+		e.dispatch(firing, now)
+	}
+	return changed
+}
+
+// dispatch queues an alert for every notifier, subject to the global rate limit.
+// Resolved notifications are never suppressed so that notifiers tracking active
+// alerts do not keep stale ones.
+func (e *Engine) dispatch(a Alert, now time.Time) {
+	if limit := e.rules.MaxNotificationsPerMinute; limit > 0 && a.State != StateResolved {
+		cutoff := now.Add(-time.Minute)
+		drop := 0
+		for drop < len(e.sent) && e.sent[drop].Before(cutoff) {
+			drop++
+		}
+		e.sent = e.sent[drop:]
+		if len(e.sent) >= limit {
+			log.Printf("Alert rate limit of %d per minute reached, suppressing %s notification for %s", limit, a.State, a.Key())
+			return
+		}
+		e.sent = append(e.sent, now)
+	}
+	for _, q := range e.queues {
+		select {
+		case q <- a:
+		default:
+			log.Printf("Warning: alert notifier queue is full, dropped %s notification for %s", a.State, a.Key())
+		}
+	}
+}
+
+func (e *Engine) notifyChange() {
+	if len(e.onChange) == 0 {
+		return
+	}
+	active := e.Active()
+	for _, fn := range e.onChange {
+		fn(active)
+	}
+}
+
+func (e *Engine) rule(name string) Rule {
+	for _, r := range e.rules.Rules {
+		if r.Name == name {
+			return r
+		}
+	}
+	return Rule{}
+}
+
+// missWindow counts misses per validator over the last size checkpoints.
+type missWindow struct {
+	size   int
+	ring   []map[string]bool
+	next   int
+	filled int
+	misses map[string]int
+}
+
+func newMissWindow(size int) *missWindow {
+	return &missWindow{size: size, ring: make([]map[string]bool, size), misses: make(map[string]int)}
+}
+
+func (w *missWindow) push(missing map[string]bool) {
+	for addr := range w.ring[w.next] {
+		w.misses[addr]--
+		if w.misses[addr] == 0 {
+			delete(w.misses, addr)
+		}
+	}
+	w.ring[w.next] = missing
+	for addr := range missing {
+		w.misses[addr]++
+	}
+	w.next = (w.next + 1) % w.size
+	if w.filled < w.size {
+		w.filled++
+	}
+}
+
+func (w *missWindow) full() bool {
+	return w.filled == w.size
+}
+
+func (w *missWindow) uptime(address string) float64 {
+	if w.filled == 0 {
+		return 1
+	}
+	return 1 - float64(w.misses[address])/float64(w.filled)
+}
@@ -0,0 +1,355 @@
+package alert
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"suitop/internal/events"
+)
+
+// testEngine returns an engine for the rules with a queue collecting its notifications
+func testEngine(t *testing.T, rs RuleSet) (*Engine, chan Alert) {
+	t.Helper()
+	if err := rs.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	e := NewEngine(rs)
+	q := make(chan Alert, notifyQueueSize)
+	e.queues = append(e.queues, q)
+	return e, q
+}
+
+// checkpoint returns a checkpoint event missed by the given validators
+func checkpoint(seq uint64, signedPower int, missing ...events.ValidatorRef) events.Event {
+	return events.Event{
+		Kind:     events.KindCheckpoint,
+		Epoch:    1,
+		Sequence: seq,
+		Checkpoint: &events.CheckpointSummary{
+			SignedPower: signedPower,
+			TotalPower:  10000,
+			Missing:     missing,
+		},
+	}
+}
+
+// drain returns the notifications queued so far
+func drain(q chan Alert) []Alert {
+	var out []Alert
+	for {
+		select {
+		case a := <-q:
+			out = append(out, a)
+		default:
+			return out
+		}
+	}
+}
+
+var (
+	alpha = events.ValidatorRef{Name: "Alpha", Address: "0xa"}
+	beta  = events.ValidatorRef{Name: "Beta", Address: "0xb"}
+)
+
+func TestMissStreakRule(t *testing.T) {
+	e, q := testEngine(t, RuleSet{Rules: []Rule{{Name: "streak", Type: RuleMissStreak, Validator: AnyValidator, Threshold: 2}}})
+
+	tests := []struct {
+		missing []events.ValidatorRef
+		want    []State // Notifications sent for the checkpoint
+	}{
+		{[]events.ValidatorRef{alpha}, nil},
+		{[]events.ValidatorRef{alpha}, nil},
+		{[]events.ValidatorRef{alpha, beta}, []State{StateFiring}},
+		{[]events.ValidatorRef{alpha, beta}, nil},
+		{nil, []State{StateResolved}},
+		{nil, nil},
+	}
+	for i, tt := range tests {
+		e.evaluateCheckpoint(checkpoint(uint64(100+i), 10000, tt.missing...))
+		got := drain(q)
+		if len(got) != len(tt.want) {
+			t.Fatalf("checkpoint %d: got %d notifications %+v, want %v", i, len(got), got, tt.want)
+		}
+		for j, a := range got {
+			if a.State != tt.want[j] || a.Validator == nil || a.Validator.Address != "0xa" {
+				t.Errorf("checkpoint %d: notification %+v, want %s for Alpha", i, a, tt.want[j])
+			}
+		}
+	}
+	if active := e.Active(); len(active) != 0 {
+		t.Errorf("Active() = %+v, want none", active)
+	}
+}
+
+func TestMissStreakRuleIgnoresMaintenance(t *testing.T) {
+	e, q := testEngine(t, RuleSet{Rules: []Rule{{Name: "streak", Type: RuleMissStreak, Validator: AnyValidator, Threshold: 2}}})
+	planned := alpha
+	planned.Maintenance = true
+
+	// A long streak inside a maintenance window stays quiet and the streak
+	// only counts from the first unplanned miss
+	for seq := uint64(1); seq <= 5; seq++ {
+		e.evaluateCheckpoint(checkpoint(seq, 10000, planned))
+	}
+	if got := drain(q); len(got) != 0 {
+		t.Fatalf("planned misses fired %+v", got)
+	}
+	e.evaluateCheckpoint(checkpoint(6, 10000, alpha))
+	e.evaluateCheckpoint(checkpoint(7, 10000, alpha))
+	if got := drain(q); len(got) != 0 {
+		t.Fatalf("fired at two unplanned misses after maintenance: %+v", got)
+	}
+	e.evaluateCheckpoint(checkpoint(8, 10000, alpha))
+	if got := drain(q); len(got) != 1 || got[0].Value != 3 {
+		t.Errorf("notifications = %+v, want a streak of 3 firing", got)
+	}
+
+	// Maintenance starting during an outage resolves the alert
+	e.evaluateCheckpoint(checkpoint(9, 10000, planned))
+	if got := drain(q); len(got) != 1 || got[0].State != StateResolved {
+		t.Errorf("notifications = %+v, want the alert resolved", got)
+	}
+}
+
+func TestMissStreakRuleForValidator(t *testing.T) {
+	e, q := testEngine(t, RuleSet{Rules: []Rule{{Name: "beta", Type: RuleMissStreak, Validator: "Beta", Threshold: 1}}})
+	for seq := uint64(1); seq <= 3; seq++ {
+		e.evaluateCheckpoint(checkpoint(seq, 10000, alpha, beta))
+	}
+	got := drain(q)
+	if len(got) != 1 || got[0].Validator.Name != "Beta" {
+		t.Errorf("notifications = %+v, want one for Beta only", got)
+	}
+}
+
+func TestUptimeRuleIgnoresMaintenance(t *testing.T) {
+	e, q := testEngine(t, RuleSet{Rules: []Rule{{Name: "uptime", Type: RuleUptimeBelow, Validator: AnyValidator, Threshold: 75, Window: 4}}})
+
+	planned := alpha
+	planned.Maintenance = true
+	for seq := uint64(1); seq <= 4; seq++ {
+		e.evaluateCheckpoint(checkpoint(seq, 10000, planned))
+	}
+	if got := drain(q); len(got) != 0 {
+		t.Fatalf("planned misses fired %+v", got)
+	}
+
+	e.evaluateCheckpoint(checkpoint(5, 10000, alpha))
+	e.evaluateCheckpoint(checkpoint(6, 10000, alpha))
+	got := drain(q)
+	if len(got) != 1 || got[0].State != StateFiring || got[0].Value != 50 {
+		t.Errorf("notifications = %+v, want uptime 50%% firing", got)
+	}
+}
+
+func TestPruneOnEpochChange(t *testing.T) {
+	e, q := testEngine(t, RuleSet{Rules: []Rule{
+		{Name: "streak", Type: RuleMissStreak, Validator: AnyValidator, Threshold: 1},
+		{Name: "uptime", Type: RuleUptimeBelow, Validator: AnyValidator, Threshold: 10, Window: 2},
+	}})
+
+	e.evaluateCheckpoint(checkpoint(1, 10000, alpha, beta))
+	e.evaluateCheckpoint(checkpoint(2, 10000, alpha))
+	e.evaluateCheckpoint(checkpoint(3, 10000, alpha))
+
+	// Beta left the committee and its miss aged out of the window; Alpha is still missing
+	next := checkpoint(4, 10000, alpha)
+	next.Epoch = 2
+	e.evaluateCheckpoint(next)
+	if _, ok := e.validators["0xb"]; ok {
+		t.Error("Beta still tracked after the epoch change")
+	}
+	if _, ok := e.streaks["0xb"]; ok {
+		t.Error("Beta streak still tracked after the epoch change")
+	}
+	if _, ok := e.validators["0xa"]; !ok || e.streaks["0xa"] != 4 {
+		t.Errorf("Alpha tracked = %v with streak %d, want tracked with streak 4", ok, e.streaks["0xa"])
+	}
+	drain(q)
+
+	// Alpha recovers; its alerts resolve on the next checkpoints and it is pruned at the next epoch
+	e.evaluateCheckpoint(checkpoint(5, 10000))
+	last := checkpoint(6, 10000)
+	last.Epoch = 3
+	e.evaluateCheckpoint(last)
+	if len(e.validators) != 0 || len(e.streaks) != 0 {
+		t.Errorf("validators = %v, streaks = %v, want both empty", e.validators, e.streaks)
+	}
+	if active := e.Active(); len(active) != 0 {
+		t.Errorf("Active() = %+v, want none", active)
+	}
+}
+
+func TestQuorumMarginRule(t *testing.T) {
+	e, q := testEngine(t, RuleSet{Rules: []Rule{{Name: "margin", Type: RuleQuorumMarginBelow, Threshold: 5}}})
+
+	tests := []struct {
+		signedPower int
+		want        []State
+	}{
+		{9000, nil},
+		{7000, []State{StateFiring}}, // Quorum is 6667, a margin of 3.33%
+		{6900, nil},
+		{8000, []State{StateResolved}},
+	}
+	for i, tt := range tests {
+		e.evaluateCheckpoint(checkpoint(uint64(i), tt.signedPower))
+		got := drain(q)
+		if len(got) != len(tt.want) {
+			t.Fatalf("checkpoint %d: got %+v, want %v", i, got, tt.want)
+		}
+		for j, a := range got {
+			if a.State != tt.want[j] {
+				t.Errorf("checkpoint %d: state %s, want %s", i, a.State, tt.want[j])
+			}
+		}
+	}
+}
+
+func TestPendingFor(t *testing.T) {
+	e, q := testEngine(t, RuleSet{Rules: []Rule{{Name: "streak", Type: RuleMissStreak, Validator: AnyValidator, Threshold: 1, For: time.Minute}}})
+	e.evaluateCheckpoint(checkpoint(1, 10000, alpha))
+	e.evaluateCheckpoint(checkpoint(2, 10000, alpha))
+	if got := drain(q); len(got) != 0 {
+		t.Fatalf("pending alert notified: %+v", got)
+	}
+	active := e.Active()
+	if len(active) != 1 || active[0].State != StatePending {
+		t.Fatalf("Active() = %+v, want one pending alert", active)
+	}
+
+	e.evaluateTick(time.Now().Add(2*time.Minute), time.Now())
+	got := drain(q)
+	if len(got) != 1 || got[0].State != StateFiring {
+		t.Errorf("notifications after For = %+v, want one firing", got)
+	}
+}
+
+func TestSourceStalledRule(t *testing.T) {
+	e, q := testEngine(t, RuleSet{Rules: []Rule{{Name: "stalled", Type: RuleSourceStalled, For: 30 * time.Second}}})
+	started := time.Now()
+	e.evaluateTick(started.Add(10*time.Second), started)
+	if got := drain(q); len(got) != 0 {
+		t.Fatalf("fired before the stall duration: %+v", got)
+	}
+	e.evaluateTick(started.Add(31*time.Second), started)
+	if got := drain(q); len(got) != 1 || got[0].State != StateFiring {
+		t.Fatalf("notifications = %+v, want one firing", got)
+	}
+	e.evaluateCheckpoint(checkpoint(1, 10000))
+	e.evaluateTick(time.Now(), started)
+	if got := drain(q); len(got) != 1 || got[0].State != StateResolved {
+		t.Errorf("notifications = %+v, want one resolved", got)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	e, q := testEngine(t, RuleSet{MaxNotificationsPerMinute: 2})
+	now := time.Now()
+	firing := func(name string) Alert { return Alert{Rule: name, State: StateFiring} }
+
+	e.dispatch(firing("a"), now)
+	e.dispatch(firing("b"), now.Add(time.Second))
+	e.dispatch(firing("c"), now.Add(2*time.Second))                            // Over the limit
+	e.dispatch(Alert{Rule: "a", State: StateResolved}, now.Add(3*time.Second)) // Never suppressed
+	e.dispatch(firing("d"), now.Add(61*time.Second))                           // The first one aged out
+
+	var got []string
+	for _, a := range drain(q) {
+		got = append(got, a.Rule+" "+string(a.State))
+	}
+	want := []string{"a firing", "b firing", "a resolved", "d firing"}
+	if len(got) != len(want) {
+		t.Fatalf("notifications = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("notification %d = %s, want %s", i, got[i], want[i])
+		}
+	}
+}
+
+func TestMissWindow(t *testing.T) {
+	w := newMissWindow(3)
+	if got := w.uptime("0xa"); got != 1 {
+		t.Errorf("uptime of an empty window = %v, want 1", got)
+	}
+	pushes := []map[string]bool{{"0xa": true}, {}, {"0xa": true}, {}, {}}
+	want := []float64{0, 0.5, 1.0 / 3, 2.0 / 3, 2.0 / 3}
+	for i, missing := range pushes {
+		w.push(missing)
+		if got := w.uptime("0xa"); math.Abs(got-want[i]) > 1e-9 {
+			t.Errorf("after push %d: uptime = %v, want %v", i, got, want[i])
+		}
+	}
+	if !w.full() {
+		t.Error("window not full after 5 pushes of 3")
+	}
+}
+
+func TestRuleSetValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    Rule
+		wantErr bool
+	}{
+		{"miss streak", Rule{Name: "r", Type: RuleMissStreak, Validator: AnyValidator, Threshold: 3}, false},
+		{"no name", Rule{Type: RuleMissStreak, Validator: AnyValidator, Threshold: 3}, true},
+		{"miss streak without threshold", Rule{Name: "r", Type: RuleMissStreak, Validator: AnyValidator}, true},
+		{"miss streak without validator", Rule{Name: "r", Type: RuleMissStreak, Threshold: 3}, true},
+		{"uptime over 100", Rule{Name: "r", Type: RuleUptimeBelow, Validator: AnyValidator, Threshold: 101, Window: 10}, true},
+		{"uptime without window", Rule{Name: "r", Type: RuleUptimeBelow, Validator: AnyValidator, Threshold: 90}, true},
+		{"quorum margin", Rule{Name: "r", Type: RuleQuorumMarginBelow, Threshold: 5}, false},
+		{"stalled without for", Rule{Name: "r", Type: RuleSourceStalled}, true},
+		{"unknown type", Rule{Name: "r", Type: "nope"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs := RuleSet{Rules: []Rule{tt.rule}}
+			err := rs.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, want error %v", err, tt.wantErr)
+			}
+			if err == nil && rs.Rules[0].Severity != "warning" {
+				t.Errorf("severity = %q, want the warning default", rs.Rules[0].Severity)
+			}
+		})
+	}
+
+	dup := RuleSet{Rules: []Rule{
+		{Name: "r", Type: RuleQuorumMarginBelow, Threshold: 5},
+		{Name: "r", Type: RuleQuorumMarginBelow, Threshold: 5},
+	}}
+	if err := dup.Validate(); err == nil {
+		t.Error("duplicate rule names accepted")
+	}
+}
+
+func TestLoadRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{"valid", "rules:\n  - name: streak\n    type: miss_streak\n    validator: \"*\"\n    threshold: 3\n", false},
+		{"empty", "", false},
+		{"unknown key", "rules:\n  - name: streak\n    type: miss_streak\n    validator: \"*\"\n    treshold: 3\n", true},
+		{"unknown top-level key", "rule:\n  - name: streak\n", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "rules.yaml")
+			if err := os.WriteFile(path, []byte(tt.data), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			_, err := LoadRules(path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoadRules() error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package alert
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RuleType selects the condition a rule evaluates.
+type RuleType string
+
+const (
+	// RuleMissStreak fires when a validator missed more than Threshold consecutive checkpoints.
+	RuleMissStreak RuleType = "miss_streak"
+	// RuleUptimeBelow fires when a validator's uptime over the last Window checkpoints
+	// falls below Threshold percent.
+	RuleUptimeBelow RuleType = "uptime_below"
+	// RuleQuorumMarginBelow fires when the voting power that signed a checkpoint exceeds
+	// the quorum threshold by less than Threshold percent of the total voting power.
+	RuleQuorumMarginBelow RuleType = "quorum_margin_below"
+	// RuleSourceStalled fires when no checkpoint has been processed for the rule's For duration.
+	RuleSourceStalled RuleType = "source_stalled"
+)
+
+// AnyValidator matches every validator in the committee.
+const AnyValidator = "*"
+
+// Rule is a single declarative alerting rule.
+type Rule struct {
+	Name      string   `yaml:"name"`
+	Type      RuleType `yaml:"type"`
+	Validator string   `yaml:"validator,omitempty"` // Name, address or "*" for validator rules
+	Threshold float64  `yaml:"threshold,omitempty"`
+	Window    int      `yaml:"window,omitempty"` // Checkpoints, for uptime_below
+
+	// For is how long the condition must hold before the alert fires.
+	// For source_stalled it is the stall duration itself.
+	For time.Duration `yaml:"for,omitempty"`
+	// RepeatInterval re-sends a firing alert at this interval; zero notifies once.
+	RepeatInterval time.Duration `yaml:"repeat_interval,omitempty"`
+
+	Severity string            `yaml:"severity,omitempty"`
+	Labels   map[string]string `yaml:"labels,omitempty"`
+}
+
+// RuleSet is the contents of an alert rules file.
+type RuleSet struct {
+	// MaxNotificationsPerMinute caps notifications across all rules; zero means unlimited.
+	MaxNotificationsPerMinute int    `yaml:"max_notifications_per_minute,omitempty"`
+	Rules                     []Rule `yaml:"rules"`
+}
+
+// DefaultRules returns the built-in rules used when no rules file is given:
+// a validator missing more than missStreak consecutive checkpoints, and no
+// checkpoint arriving for stallFor.
+func DefaultRules(missStreak int, stallFor time.Duration) RuleSet {
+	return RuleSet{Rules: []Rule{
+		{
+			Name:      "ValidatorMissingCheckpoints",
+			Type:      RuleMissStreak,
+			Validator: AnyValidator,
+			Threshold: float64(missStreak),
+			Severity:  "warning",
+		},
+		{
+			Name:     "CheckpointSubscriptionDown",
+			Type:     RuleSourceStalled,
+			For:      stallFor,
+			Severity: "critical",
+		},
+	}}
+}
+
+// LoadRules reads and validates a YAML rules file.
+func LoadRules(path string) (*RuleSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading alert rules file: %w", err)
+	}
+	// Unknown keys are rejected so that a typo does not silently disable a rule
+	var rs RuleSet
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&rs); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("error parsing alert rules file %s: %w", path, err)
+	}
+	if err := rs.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid alert rules file %s: %w", path, err)
+	}
+	return &rs, nil
+}
+
+// Validate checks the rules and fills in defaults.
+func (rs *RuleSet) Validate() error {
+	seen := make(map[string]bool)
+	for i := range rs.Rules {
+		r := &rs.Rules[i]
+		if r.Name == "" {
+			return fmt.Errorf("rule %d has no name", i)
+		}
+		if seen[r.Name] {
+			return fmt.Errorf("duplicate rule name %q", r.Name)
+		}
+		seen[r.Name] = true
+		if r.Severity == "" {
+			r.Severity = "warning"
+		}
+
+		switch r.Type {
+		case RuleMissStreak:
+			if r.Threshold < 1 {
+				return fmt.Errorf("rule %q: threshold must be at least 1 checkpoint", r.Name)
+			}
+		case RuleUptimeBelow:
+			if r.Threshold <= 0 || r.Threshold > 100 {
+				return fmt.Errorf("rule %q: threshold must be a percentage in (0, 100]", r.Name)
+			}
+			if r.Window <= 0 {
+				return fmt.Errorf("rule %q: window must be a positive number of checkpoints", r.Name)
+			}
+		case RuleQuorumMarginBelow:
+			if r.Threshold <= 0 {
+				return fmt.Errorf("rule %q: threshold must be a positive percentage of total voting power", r.Name)
+			}
+		case RuleSourceStalled:
+			if r.For <= 0 {
+				return fmt.Errorf("rule %q: for must be set to the stall duration", r.Name)
+			}
+		default:
+			return fmt.Errorf("rule %q: unknown type %q", r.Name, r.Type)
+		}
+
+		if r.isValidatorRule() && strings.TrimSpace(r.Validator) == "" {
+			return fmt.Errorf("rule %q: validator must be a name, an address or %q", r.Name, AnyValidator)
+		}
+	}
+	return nil
+}
+
+func (r Rule) isValidatorRule() bool {
+	return r.Type == RuleMissStreak || r.Type == RuleUptimeBelow
+}
+
+// pendingFor returns how long an alert stays pending before it fires. For
+// source_stalled rules the For duration is already part of the condition.
+func (r Rule) pendingFor() time.Duration {
+	if r.Type == RuleSourceStalled {
+		return 0
+	}
+	return r.For
+}
+
+// matches reports whether a validator rule applies to the given validator.
+func (r Rule) matches(name, address string) bool {
+	if r.Validator == AnyValidator {
+		return true
+	}
+	return strings.EqualFold(r.Validator, address) || r.Validator == name
+}
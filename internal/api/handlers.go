@@ -0,0 +1,298 @@
+package api
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"suitop/internal/types"
+)
+
+const maxCheckpointsLimit = 1000
+
+type stateJSON struct {
+	Network       string  `json:"network"`
+	Epoch         uint64  `json:"epoch"`
+	CheckpointSeq uint64  `json:"checkpoint"`
+	TotalWithSig  uint64  `json:"total_with_sig"`
+	SignedPower   int     `json:"signed_power"`
+	TotalPower    int     `json:"total_power"`
+	SignedPercent float64 `json:"signed_power_percent"`
+	CommitteeSize int     `json:"committee_size"`
+	SignerCount   int     `json:"signer_count"`
+}
+
+type nodeJSON struct {
+	Network                   string    `json:"network"`
+	Chain                     string    `json:"chain"`
+	ServerVersion             string    `json:"server_version"`
+	Epoch                     uint64    `json:"epoch"`
+	CheckpointHeight          uint64    `json:"checkpoint_height"`
+	LowestAvailableCheckpoint uint64    `json:"lowest_available_checkpoint"`
+	NodeTime                  time.Time `json:"node_time"`
+	LagSeconds                float64   `json:"lag_seconds"`
+	PolledAt                  time.Time `json:"polled_at"`
+	Error                     string    `json:"error,omitempty"`
+	Warnings                  []string  `json:"warnings"`
+}
+
+type validatorInfoJSON struct {
+	Name           string  `json:"name"`
+	Address        string  `json:"address"`
+	ProtocolPubkey string  `json:"protocol_pubkey"`
+	BitmapIndex    int     `json:"bitmap_index"`
+	VotingPower    int     `json:"voting_power"`
+	StakeShare     float64 `json:"stake_share"`
+
+	// From the labels file, if it lists the validator
+	ChainName string   `json:"chain_name,omitempty"`
+	Group     string   `json:"group,omitempty"`
+	Tags      []string `json:"tags,omitempty"`
+}
+
+type epochJSON struct {
+	Network    string              `json:"network"`
+	Epoch      uint64              `json:"epoch"`
+	TotalPower int                 `json:"total_power"`
+	Committee  []validatorInfoJSON `json:"committee"`
+}
+
+type validatorStatsJSON struct {
+	validatorInfoJSON
+	AttestedCount uint64   `json:"attested"`
+	TotalWithSig  uint64   `json:"total_with_sig"`
+	Uptime        *float64 `json:"uptime"` // Null if every checkpoint was missed during maintenance
+	SignedCurrent bool     `json:"signed_current"`
+	MissStreak    uint64   `json:"miss_streak"`
+	LastSignedSeq uint64   `json:"last_signed_sequence"`
+	PlannedMissed uint64   `json:"planned_missed"`   // Missed inside a maintenance window
+	Unplanned     uint64   `json:"unplanned_missed"` // Missed outside maintenance windows
+	InMaintenance bool     `json:"in_maintenance"`
+}
+
+type recentSignatureJSON struct {
+	Sequence uint64 `json:"sequence"`
+	Signed   bool   `json:"signed"`
+}
+
+type validatorDetailJSON struct {
+	validatorStatsJSON
+	Recent []recentSignatureJSON `json:"recent"`
+}
+
+type signerJSON struct {
+	BitmapIndex int    `json:"bitmap_index"`
+	Name        string `json:"name"`
+	Address     string `json:"address"`
+}
+
+type checkpointJSON struct {
+	Sequence    uint64       `json:"sequence"`
+	Epoch       uint64       `json:"epoch"`
+	Timestamp   time.Time    `json:"timestamp"`
+	SignedPower int          `json:"signed_power"`
+	TotalPower  int          `json:"total_power"`
+	SignerCount int          `json:"signer_count"`
+	Committee   int          `json:"committee_size"`
+	Signers     []signerJSON `json:"signers"`
+}
+
+// newCheckpointJSON resolves a checkpoint's signer indices against the committee
+// that was active when it was processed.
+func newCheckpointJSON(cp types.CheckpointInfo, committee []types.ValidatorInfo) checkpointJSON {
+	byIndex := make(map[int]types.ValidatorInfo, len(committee))
+	for _, v := range committee {
+		byIndex[v.BitmapIndex] = v
+	}
+	out := checkpointJSON{
+		Sequence:    cp.Sequence,
+		Epoch:       cp.Epoch,
+		Timestamp:   time.UnixMilli(cp.Timestamp).UTC(),
+		SignedPower: cp.SignedPower,
+		TotalPower:  cp.TotalPower,
+		SignerCount: len(cp.Signers),
+		Committee:   int(cp.ValidatorCount),
+		Signers:     make([]signerJSON, 0, len(cp.Signers)),
+	}
+	for _, idx := range cp.Signers {
+		v := byIndex[int(idx)]
+		out.Signers = append(out.Signers, signerJSON{BitmapIndex: int(idx), Name: v.Name, Address: v.SuiAddress})
+	}
+	return out
+}
+
+func newValidatorInfoJSON(v types.ValidatorInfo, totalPower int) validatorInfoJSON {
+	share := 0.0
+	if totalPower > 0 {
+		share = float64(v.VotingPower) / float64(totalPower)
+	}
+	return validatorInfoJSON{
+		Name:           v.Name,
+		Address:        v.SuiAddress,
+		ProtocolPubkey: v.ProtocolPubkeyBytes,
+		BitmapIndex:    v.BitmapIndex,
+		VotingPower:    v.VotingPower,
+		StakeShare:     share,
+		ChainName:      v.ChainName,
+		Group:          v.Group,
+		Tags:           v.Tags,
+	}
+}
+
+func newValidatorStatsJSON(v types.ValidatorInfo, snap *types.SnapshotMsg) validatorStatsJSON {
+	stats := snap.Stats[v.SuiAddress]
+	var uptime *float64
+	if stats.HasUptime(snap.TotalWithSig) {
+		u := stats.Uptime(snap.TotalWithSig)
+		uptime = &u
+	}
+	return validatorStatsJSON{
+		validatorInfoJSON: newValidatorInfoJSON(v, snap.TotalPower),
+		AttestedCount:     stats.AttestedCount,
+		TotalWithSig:      snap.TotalWithSig,
+		Uptime:            uptime,
+		SignedCurrent:     stats.SignedCurrent,
+		MissStreak:        stats.MissStreak,
+		LastSignedSeq:     stats.LastSignedSeq,
+		PlannedMissed:     stats.PlannedMisses,
+		Unplanned:         stats.UnplannedMisses(snap.TotalWithSig),
+		InMaintenance:     stats.InMaintenance,
+	}
+}
+
+// committeeByIndex returns the committee sorted in bitmap-index order.
+func committeeByIndex(committee []types.ValidatorInfo) []types.ValidatorInfo {
+	out := append([]types.ValidatorInfo(nil), committee...)
+	sort.Slice(out, func(i, j int) bool { return out[i].BitmapIndex < out[j].BitmapIndex })
+	return out
+}
+
+func (s *Server) handleNode(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	node := s.node
+	s.mu.RUnlock()
+	if node == nil {
+		writeError(w, http.StatusServiceUnavailable, "node not polled yet")
+		return
+	}
+	warnings := node.Warnings
+	if warnings == nil {
+		warnings = []string{}
+	}
+	writeJSON(w, http.StatusOK, nodeJSON{
+		Network:                   s.network,
+		Chain:                     node.Chain,
+		ServerVersion:             node.ServerVersion,
+		Epoch:                     node.Epoch,
+		CheckpointHeight:          node.CheckpointHeight,
+		LowestAvailableCheckpoint: node.LowestAvailableCheckpoint,
+		NodeTime:                  node.NodeTime.UTC(),
+		LagSeconds:                node.Lag.Seconds(),
+		PolledAt:                  node.PolledAt.UTC(),
+		Error:                     node.Error,
+		Warnings:                  warnings,
+	})
+}
+
+func (s *Server) handleState(w http.ResponseWriter, r *http.Request) {
+	snap, _ := s.current()
+	if snap == nil {
+		writeError(w, http.StatusServiceUnavailable, "no checkpoint processed yet")
+		return
+	}
+	pct := 0.0
+	if snap.TotalPower > 0 {
+		pct = float64(snap.SignedPower) / float64(snap.TotalPower) * 100
+	}
+	writeJSON(w, http.StatusOK, stateJSON{
+		Network:       s.network,
+		Epoch:         snap.Epoch,
+		CheckpointSeq: snap.CheckpointSeq,
+		TotalWithSig:  snap.TotalWithSig,
+		SignedPower:   snap.SignedPower,
+		TotalPower:    snap.TotalPower,
+		SignedPercent: pct,
+		CommitteeSize: len(snap.Committee),
+		SignerCount:   len(snap.Checkpoint.Signers),
+	})
+}
+
+func (s *Server) handleEpoch(w http.ResponseWriter, r *http.Request) {
+	snap, _ := s.current()
+	if snap == nil {
+		writeError(w, http.StatusServiceUnavailable, "no checkpoint processed yet")
+		return
+	}
+	out := epochJSON{Network: s.network, Epoch: snap.Epoch, TotalPower: snap.TotalPower}
+	for _, v := range committeeByIndex(snap.Committee) {
+		out.Committee = append(out.Committee, newValidatorInfoJSON(v, snap.TotalPower))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) handleValidators(w http.ResponseWriter, r *http.Request) {
+	snap, _ := s.current()
+	if snap == nil {
+		writeError(w, http.StatusServiceUnavailable, "no checkpoint processed yet")
+		return
+	}
+	out := make([]validatorStatsJSON, 0, len(snap.Committee))
+	for _, v := range committeeByIndex(snap.Committee) {
+		out = append(out, newValidatorStatsJSON(v, snap))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) handleValidator(w http.ResponseWriter, r *http.Request) {
+	snap, recent := s.current()
+	if snap == nil {
+		writeError(w, http.StatusServiceUnavailable, "no checkpoint processed yet")
+		return
+	}
+	address := r.PathValue("address")
+	for _, v := range snap.Committee {
+		if v.SuiAddress != address {
+			continue
+		}
+		out := validatorDetailJSON{validatorStatsJSON: newValidatorStatsJSON(v, snap)}
+		for _, cp := range recent {
+			signed := false
+			for _, signer := range cp.Signers {
+				if signer.Address == address {
+					signed = true
+					break
+				}
+			}
+			out.Recent = append(out.Recent, recentSignatureJSON{Sequence: cp.Sequence, Signed: signed})
+		}
+		writeJSON(w, http.StatusOK, out)
+		return
+	}
+	writeError(w, http.StatusNotFound, "validator not in current committee")
+}
+
+func (s *Server) handleCheckpoints(w http.ResponseWriter, r *http.Request) {
+	_, recent := s.current()
+	limit := 100
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			writeError(w, http.StatusBadRequest, "limit must be a positive integer")
+			return
+		}
+		limit = n
+	}
+	if limit > maxCheckpointsLimit {
+		limit = maxCheckpointsLimit
+	}
+	if len(recent) > limit {
+		recent = recent[len(recent)-limit:]
+	}
+	// Newest first
+	out := make([]checkpointJSON, 0, len(recent))
+	for i := len(recent) - 1; i >= 0; i-- {
+		out = append(out, recent[i])
+	}
+	writeJSON(w, http.StatusOK, out)
+}
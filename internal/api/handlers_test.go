@@ -0,0 +1,194 @@
+package api
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"suitop/internal/config"
+	"suitop/internal/types"
+)
+
+// testServer returns a server that observed the test snapshot on checkpoints
+// 40 to 42; bob did not sign 41
+func testServer() *Server {
+	s := NewServer(config.APIConfig{}, "testnet", nil)
+	snap := testSnapshot()
+	for seq := uint64(40); seq <= 42; seq++ {
+		snap.Checkpoint.Sequence = seq
+		snap.Checkpoint.Signers = []uint32{0, 1}
+		if seq == 41 {
+			snap.Checkpoint.Signers = []uint32{0}
+		}
+		s.Observe(snap)
+	}
+	return s
+}
+
+func TestNotReady(t *testing.T) {
+	h := NewServer(config.APIConfig{}, "testnet", nil).Handler()
+	for _, path := range []string{"/api/v1/state", "/api/v1/epoch", "/api/v1/validators", "/api/v1/validators/0xa", "/api/v1/node"} {
+		var body map[string]string
+		rec := get(t, h, path, nil, &body)
+		if rec.Code != http.StatusServiceUnavailable || body["error"] == "" {
+			t.Errorf("GET %s = %d %v, want 503 with an error", path, rec.Code, body)
+		}
+	}
+	var cps []checkpointJSON
+	if rec := get(t, h, "/api/v1/checkpoints", nil, &cps); rec.Code != http.StatusOK || len(cps) != 0 {
+		t.Errorf("GET /api/v1/checkpoints = %d %v, want an empty list", rec.Code, cps)
+	}
+}
+
+func TestState(t *testing.T) {
+	var got stateJSON
+	rec := get(t, testServer().Handler(), "/api/v1/state", nil, &got)
+	want := stateJSON{
+		Network:       "testnet",
+		Epoch:         7,
+		CheckpointSeq: 42,
+		TotalWithSig:  10,
+		SignedPower:   8000,
+		TotalPower:    10000,
+		SignedPercent: 80,
+		CommitteeSize: 3,
+		SignerCount:   2,
+	}
+	if rec.Code != http.StatusOK || got != want {
+		t.Errorf("GET /api/v1/state = %d %+v, want %+v", rec.Code, got, want)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+}
+
+func TestValidators(t *testing.T) {
+	var got []validatorStatsJSON
+	rec := get(t, testServer().Handler(), "/api/v1/validators", nil, &got)
+	if rec.Code != http.StatusOK || len(got) != 3 {
+		t.Fatalf("GET /api/v1/validators = %d with %d validators, want 3", rec.Code, len(got))
+	}
+	tests := []struct {
+		name      string
+		address   string
+		uptime    *float64
+		unplanned uint64
+		share     float64
+	}{
+		{"alice", "0xa", ptr(1.0), 0, 0.5},
+		{"bob", "0xb", ptr(0.9), 1, 0.3},
+		{"carol", "0xc", nil, 0, 0.2}, // Every miss was planned
+	}
+	for i, tt := range tests {
+		v := got[i] // In bitmap-index order
+		if v.Name != tt.name || v.Address != tt.address || v.StakeShare != tt.share || v.Unplanned != tt.unplanned {
+			t.Errorf("validator %d = %+v, want %s (%s) with share %g and %d unplanned misses", i, v, tt.name, tt.address, tt.share, tt.unplanned)
+		}
+		if !reflect.DeepEqual(v.Uptime, tt.uptime) {
+			t.Errorf("%s uptime = %v, want %v", tt.name, deref(v.Uptime), deref(tt.uptime))
+		}
+	}
+	if got[1].Group != "ops" {
+		t.Errorf("bob group = %q, want ops", got[1].Group)
+	}
+}
+
+func TestValidator(t *testing.T) {
+	h := testServer().Handler()
+	tests := []struct {
+		address string
+		status  int
+		recent  []recentSignatureJSON
+	}{
+		{"0xa", http.StatusOK, []recentSignatureJSON{{40, true}, {41, true}, {42, true}}},
+		{"0xb", http.StatusOK, []recentSignatureJSON{{40, true}, {41, false}, {42, true}}},
+		{"0xc", http.StatusOK, []recentSignatureJSON{{40, false}, {41, false}, {42, false}}},
+		{"0xd", http.StatusNotFound, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			var got validatorDetailJSON
+			rec := get(t, h, "/api/v1/validators/"+tt.address, nil, &got)
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.status)
+			}
+			if !reflect.DeepEqual(got.Recent, tt.recent) {
+				t.Errorf("recent = %v, want %v", got.Recent, tt.recent)
+			}
+		})
+	}
+}
+
+func TestCheckpoints(t *testing.T) {
+	h := testServer().Handler()
+	tests := []struct {
+		query  string
+		status int
+		want   []uint64
+	}{
+		{"", http.StatusOK, []uint64{42, 41, 40}},
+		{"?limit=2", http.StatusOK, []uint64{42, 41}},
+		{"?limit=5000", http.StatusOK, []uint64{42, 41, 40}},
+		{"?limit=0", http.StatusBadRequest, nil},
+		{"?limit=many", http.StatusBadRequest, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			rec := get(t, h, "/api/v1/checkpoints"+tt.query, nil, nil)
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.status)
+			}
+			if tt.status != http.StatusOK {
+				return
+			}
+			var got []checkpointJSON
+			get(t, h, "/api/v1/checkpoints"+tt.query, nil, &got)
+			var seqs []uint64
+			for _, cp := range got {
+				seqs = append(seqs, cp.Sequence)
+			}
+			if !reflect.DeepEqual(seqs, tt.want) {
+				t.Errorf("checkpoints = %v, want %v", seqs, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckpointSigners(t *testing.T) {
+	var got []checkpointJSON
+	get(t, testServer().Handler(), "/api/v1/checkpoints?limit=2", nil, &got)
+	want := []signerJSON{{BitmapIndex: 0, Name: "alice", Address: "0xa"}}
+	if len(got) != 2 || !reflect.DeepEqual(got[1].Signers, want) || got[1].SignerCount != 1 || got[1].Committee != 3 {
+		t.Errorf("checkpoint 41 = %+v, want signed by alice only", got)
+	}
+}
+
+func TestEpochAndNode(t *testing.T) {
+	s := testServer()
+	var epoch epochJSON
+	get(t, s.Handler(), "/api/v1/epoch", nil, &epoch)
+	var names []string
+	for _, v := range epoch.Committee {
+		names = append(names, v.Name)
+	}
+	if epoch.Epoch != 7 || !reflect.DeepEqual(names, []string{"alice", "bob", "carol"}) {
+		t.Errorf("GET /api/v1/epoch = epoch %d committee %v", epoch.Epoch, names)
+	}
+
+	s.ObserveNode(types.NodeHealth{Chain: "4c78adac", CheckpointHeight: 50, Lag: 1500 * time.Millisecond})
+	var node nodeJSON
+	get(t, s.Handler(), "/api/v1/node", nil, &node)
+	if node.Chain != "4c78adac" || node.LagSeconds != 1.5 || node.Warnings == nil {
+		t.Errorf("GET /api/v1/node = %+v", node)
+	}
+}
+
+func ptr(f float64) *float64 { return &f }
+
+func deref(f *float64) interface{} {
+	if f == nil {
+		return nil
+	}
+	return *f
+}
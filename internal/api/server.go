@@ -0,0 +1,142 @@
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"suitop/internal/config"
+	"suitop/internal/events"
+	"suitop/internal/types"
+)
+
+const defaultRecentCheckpoints = 1000
+
+// Server is a read-only HTTP/JSON API over the processor's snapshots.
+type Server struct {
+	cfg     config.APIConfig
+	network string
+	events  *events.Bus
+
+	mu       sync.RWMutex
+	snapshot *types.SnapshotMsg
+	recent   []checkpointJSON  // Ring of recent checkpoints, oldest first
+	node     *types.NodeHealth // Latest node health poll
+}
+
+// NewServer creates an API server. Feed it with Observe and start it with Serve.
+// The stream endpoint relays events from bus.
+func NewServer(cfg config.APIConfig, network string, bus *events.Bus) *Server {
+	if cfg.RecentCheckpoints <= 0 {
+		cfg.RecentCheckpoints = defaultRecentCheckpoints
+	}
+	return &Server{cfg: cfg, network: network, events: bus}
+}
+
+// Observe stores the latest snapshot and appends its checkpoint to the recent
+// checkpoint buffer. It is meant to be registered as a processor snapshot hook.
+func (s *Server) Observe(msg types.SnapshotMsg) {
+	cp := newCheckpointJSON(msg.Checkpoint, msg.Committee)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.snapshot = &msg
+	s.recent = append(s.recent, cp)
+	if len(s.recent) > s.cfg.RecentCheckpoints {
+		s.recent = s.recent[len(s.recent)-s.cfg.RecentCheckpoints:]
+	}
+}
+
+// ObserveNode stores the latest node health. It is meant to be registered as
+// a node monitor hook.
+func (s *Server) ObserveNode(h types.NodeHealth) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.node = &h
+}
+
+// Handler returns the API routes wrapped with bearer-token auth if configured.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/state", s.handleState)
+	mux.HandleFunc("GET /api/v1/epoch", s.handleEpoch)
+	mux.HandleFunc("GET /api/v1/validators", s.handleValidators)
+	mux.HandleFunc("GET /api/v1/validators/{address}", s.handleValidator)
+	mux.HandleFunc("GET /api/v1/checkpoints", s.handleCheckpoints)
+	mux.HandleFunc("GET /api/v1/node", s.handleNode)
+	mux.HandleFunc("GET /api/v1/stream", s.handleStream)
+
+	root := http.NewServeMux()
+	root.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	root.Handle("/api/", s.withAuth(mux))
+	return root
+}
+
+// Serve runs the API server until ctx is done.
+func (s *Server) Serve(ctx context.Context) {
+	srv := &http.Server{
+		Addr:              s.cfg.ListenAddr,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 5 * time.Second,
+		// Requests end with ctx, so that open event streams do not hold up
+		// the shutdown
+		BaseContext: func(net.Listener) context.Context { return ctx },
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	}()
+
+	log.Printf("Serving HTTP API on http://%s/api/v1/", s.cfg.ListenAddr)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Printf("API server on %s failed: %v", s.cfg.ListenAddr, err)
+	}
+}
+
+// withAuth enforces the configured bearer token, if any.
+func (s *Server) withAuth(next http.Handler) http.Handler {
+	if s.cfg.Token == "" {
+		return next
+	}
+	expected := []byte(s.cfg.Token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), expected) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="suitop"`)
+			writeError(w, http.StatusUnauthorized, "missing or invalid bearer token")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// current returns the latest snapshot and a copy of the recent checkpoints.
+func (s *Server) current() (*types.SnapshotMsg, []checkpointJSON) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.snapshot, append([]checkpointJSON(nil), s.recent...)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("Failed to encode API response: %v", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"suitop/internal/config"
+	"suitop/internal/types"
+)
+
+// testSnapshot returns a snapshot of a three-validator committee on
+// checkpoint 42, which alice and bob signed
+func testSnapshot() types.SnapshotMsg {
+	committee := []types.ValidatorInfo{
+		{Name: "carol", SuiAddress: "0xc", BitmapIndex: 2, VotingPower: 2000},
+		{Name: "alice", SuiAddress: "0xa", BitmapIndex: 0, VotingPower: 5000},
+		{Name: "bob", SuiAddress: "0xb", BitmapIndex: 1, VotingPower: 3000, Group: "ops"},
+	}
+	return types.SnapshotMsg{
+		Epoch:         7,
+		CheckpointSeq: 42,
+		TotalWithSig:  10,
+		SignedPower:   8000,
+		TotalPower:    10000,
+		Committee:     committee,
+		Stats: map[string]types.ValidatorStats{
+			"0xa": {AttestedCount: 10, SignedCurrent: true, LastSignedSeq: 42},
+			"0xb": {AttestedCount: 9, SignedCurrent: true, LastSignedSeq: 42},
+			"0xc": {AttestedCount: 0, MissStreak: 10, PlannedMisses: 10, InMaintenance: true},
+		},
+		Checkpoint: types.CheckpointInfo{Sequence: 42, Epoch: 7, ValidatorCount: 3, SignedPower: 8000, TotalPower: 10000, Signers: []uint32{0, 1}},
+	}
+}
+
+// get serves a GET request and decodes the JSON response into out, if not nil
+func get(t *testing.T, h http.Handler, path string, header http.Header, out interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if out != nil {
+		if err := json.Unmarshal(rec.Body.Bytes(), out); err != nil {
+			t.Fatalf("GET %s: decoding %q: %v", path, rec.Body.String(), err)
+		}
+	}
+	return rec
+}
+
+func TestAuth(t *testing.T) {
+	h := NewServer(config.APIConfig{Token: "s3cret"}, "testnet", nil).Handler()
+	tests := []struct {
+		name   string
+		path   string
+		auth   string
+		status int
+	}{
+		{"health check is public", "/healthz", "", http.StatusOK},
+		{"missing token", "/api/v1/state", "", http.StatusUnauthorized},
+		{"wrong token", "/api/v1/state", "Bearer nope", http.StatusUnauthorized},
+		{"wrong scheme", "/api/v1/state", "Basic s3cret", http.StatusUnauthorized},
+		{"token without scheme", "/api/v1/state", "s3cret", http.StatusUnauthorized},
+		{"valid token", "/api/v1/state", "Bearer s3cret", http.StatusServiceUnavailable}, // No snapshot yet
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.auth != "" {
+				header.Set("Authorization", tt.auth)
+			}
+			rec := get(t, h, tt.path, header, nil)
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.status)
+			}
+			if tt.status == http.StatusUnauthorized {
+				if got := rec.Header().Get("WWW-Authenticate"); got == "" {
+					t.Error("401 response has no WWW-Authenticate header")
+				}
+				var body map[string]string
+				if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil || body["error"] == "" {
+					t.Errorf("401 body = %q, want a JSON error", rec.Body.String())
+				}
+			}
+		})
+	}
+}
+
+func TestNoToken(t *testing.T) {
+	s := NewServer(config.APIConfig{}, "testnet", nil)
+	s.Observe(testSnapshot())
+	if rec := get(t, s.Handler(), "/api/v1/state", nil, nil); rec.Code != http.StatusOK {
+		t.Errorf("status without a configured token = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+func TestObserveKeepsRecentCheckpoints(t *testing.T) {
+	s := NewServer(config.APIConfig{RecentCheckpoints: 3}, "testnet", nil)
+	snap := testSnapshot()
+	for seq := uint64(1); seq <= 5; seq++ {
+		snap.Checkpoint.Sequence = seq
+		s.Observe(snap)
+	}
+	_, recent := s.current()
+	if len(recent) != 3 || recent[0].Sequence != 3 || recent[2].Sequence != 5 {
+		t.Errorf("recent checkpoints = %+v, want 3 to 5", recent)
+	}
+}
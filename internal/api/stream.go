@@ -0,0 +1,159 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"suitop/internal/events"
+)
+
+const streamHeartbeat = 15 * time.Second
+
+// streamFilter selects which events a stream client receives.
+type streamFilter struct {
+	validators map[string]bool
+	kinds      map[events.Kind]bool
+}
+
+func parseStreamFilter(r *http.Request) streamFilter {
+	f := streamFilter{}
+	for _, v := range r.URL.Query()["validator"] {
+		for _, addr := range strings.Split(v, ",") {
+			if addr = strings.TrimSpace(addr); addr != "" {
+				if f.validators == nil {
+					f.validators = make(map[string]bool)
+				}
+				f.validators[addr] = true
+			}
+		}
+	}
+	for _, v := range r.URL.Query()["kind"] {
+		for _, k := range strings.Split(v, ",") {
+			if k = strings.TrimSpace(k); k != "" {
+				if f.kinds == nil {
+					f.kinds = make(map[events.Kind]bool)
+				}
+				f.kinds[events.Kind(k)] = true
+			}
+		}
+	}
+	return f
+}
+
+// apply returns the event as the client should see it, or false if it is filtered out.
+// Checkpoint events are always delivered, but their missing list is narrowed to
+// the requested validators.
+func (f streamFilter) apply(e events.Event) (events.Event, bool) {
+	if f.kinds != nil && !f.kinds[e.Kind] {
+		return e, false
+	}
+	if f.validators == nil {
+		return e, true
+	}
+	if e.Validator != nil {
+		return e, f.validators[e.Validator.Address]
+	}
+	if e.Checkpoint != nil {
+		summary := *e.Checkpoint
+		summary.Missing = nil
+		for _, v := range e.Checkpoint.Missing {
+			if f.validators[v.Address] {
+				summary.Missing = append(summary.Missing, v)
+			}
+		}
+		e.Checkpoint = &summary
+	}
+	return e, true
+}
+
+// handleStream serves events as Server-Sent Events. Clients can resume with the
+// standard Last-Event-ID header or with ?since=<checkpoint sequence>; buffered
+// events after that point are replayed before live events. A client that falls
+// so far behind that the bus drops its events is disconnected, so that it
+// resumes with Last-Event-ID instead of silently missing them.
+func (s *Server) handleStream(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "streaming not supported")
+		return
+	}
+	filter := parseStreamFilter(r)
+
+	var replay func(events.Event) bool
+	if lastID, err := strconv.ParseUint(r.Header.Get("Last-Event-ID"), 10, 64); err == nil {
+		replay = func(e events.Event) bool { return e.ID > lastID }
+	} else if v := r.URL.Query().Get("since"); v != "" {
+		since, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "since must be a checkpoint sequence number")
+			return
+		}
+		// Events without a sequence, such as source health and chain resets,
+		// are replayed if they came after the last event of checkpoint since
+		// or earlier.
+		after := s.events.LastID(func(e events.Event) bool { return e.Sequence != 0 && e.Sequence <= since })
+		replay = func(e events.Event) bool {
+			return e.Sequence > since || (e.Sequence == 0 && e.ID > after)
+		}
+	} else {
+		replay = func(events.Event) bool { return false }
+	}
+
+	past, live, cancel := s.events.SubscribeWithReplay(256, replay)
+	defer cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	send := func(e events.Event) error {
+		e, ok := filter.apply(e)
+		if !ok {
+			return nil
+		}
+		data, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Kind, data)
+		return err
+	}
+
+	for _, e := range past {
+		if err := send(e); err != nil {
+			return
+		}
+	}
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+	var lastID uint64 // Of the last live event; IDs are consecutive unless the bus dropped some
+	for {
+		select {
+		case e := <-live:
+			if lastID != 0 && e.ID != lastID+1 {
+				log.Printf("Closing event stream of %s, which missed events %d-%d", r.RemoteAddr, lastID+1, e.ID-1)
+				return
+			}
+			lastID = e.ID
+			if err := send(e); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
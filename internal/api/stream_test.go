@@ -0,0 +1,217 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"suitop/internal/config"
+	"suitop/internal/events"
+)
+
+// streamIDs serves a stream request whose client has already gone away, so
+// that the handler returns after the replay, and returns the IDs it sent
+func streamIDs(t *testing.T, s *Server, query string, header http.Header) []uint64 {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/stream"+query, nil).WithContext(ctx)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET stream%s = %d %s", query, rec.Code, rec.Body.String())
+	}
+	return parseIDs(t, rec.Body.String())
+}
+
+func parseIDs(t *testing.T, body string) []uint64 {
+	t.Helper()
+	var ids []uint64
+	for _, line := range strings.Split(body, "\n") {
+		if v, ok := strings.CutPrefix(line, "id: "); ok {
+			id, err := strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				t.Fatalf("bad event id %q", line)
+			}
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func TestStreamResume(t *testing.T) {
+	bus := events.NewBus(0)
+	s := NewServer(config.APIConfig{}, "testnet", bus)
+	for _, e := range []events.Event{
+		{Kind: events.KindSourceHealth, Status: events.SourceConnected}, // 1
+		{Kind: events.KindCheckpoint, Sequence: 10},                     // 2
+		{Kind: events.KindValidatorStatus, Sequence: 10, Status: events.StatusMissing, Validator: &events.ValidatorRef{Address: "0xa"}},
+		{Kind: events.KindSourceHealth, Status: events.SourceStalled}, // 4
+		{Kind: events.KindCheckpoint, Sequence: 11},                   // 5
+		{Kind: events.KindChainReset, Message: "wiped"},               // 6
+		{Kind: events.KindValidatorStatus, Sequence: 12, Status: events.StatusSigning, Validator: &events.ValidatorRef{Address: "0xb"}},
+	} {
+		bus.Publish(e)
+	}
+
+	tests := []struct {
+		name   string
+		query  string
+		lastID string
+		want   []uint64
+	}{
+		{"live only", "", "", nil},
+		{"since a checkpoint", "?since=10", "", []uint64{4, 5, 6, 7}},
+		{"since the latest checkpoint", "?since=11", "", []uint64{6, 7}},
+		{"since before the buffer", "?since=1", "", []uint64{1, 2, 3, 4, 5, 6, 7}},
+		{"since after the buffer", "?since=99", "", nil},
+		{"since with a kind filter", "?since=10&kind=source_health,chain_reset", "", []uint64{4, 6}},
+		{"since with a validator filter", "?since=9&validator=0xb", "", []uint64{1, 2, 4, 5, 6, 7}},
+		{"last event ID", "", "3", []uint64{4, 5, 6, 7}},
+		{"last event ID over since", "?since=1", "5", []uint64{6, 7}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.lastID != "" {
+				header.Set("Last-Event-ID", tt.lastID)
+			}
+			if got := streamIDs(t, s, tt.query, header); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("replayed %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	rec := get(t, s.Handler(), "/api/v1/stream?since=soon", nil, nil)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("GET stream?since=soon = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+// stalledWriter is a ResponseWriter whose writes block until released, like a
+// client that stopped reading
+type stalledWriter struct {
+	header  http.Header
+	started chan struct{} // Closed once the stream is subscribed
+	release chan struct{}
+
+	mu   sync.Mutex
+	body strings.Builder
+}
+
+func (w *stalledWriter) Header() http.Header    { return w.header }
+func (w *stalledWriter) WriteHeader(status int) { close(w.started) }
+func (w *stalledWriter) Flush()                 {}
+
+func (w *stalledWriter) Write(p []byte) (int, error) {
+	<-w.release
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.body.Write(p)
+}
+
+func TestStreamDisconnectsSlowClient(t *testing.T) {
+	log.SetOutput(io.Discard) // The bus warns about every dropped event
+	defer log.SetOutput(os.Stderr)
+
+	bus := events.NewBus(0)
+	s := NewServer(config.APIConfig{}, "testnet", bus)
+	w := &stalledWriter{header: http.Header{}, started: make(chan struct{}), release: make(chan struct{})}
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/stream", nil)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.Handler().ServeHTTP(w, req)
+	}()
+	<-w.started
+
+	// The first event blocks the handler in Write, then the buffer fills up
+	// and the bus drops events for it
+	for i := 0; i < 400; i++ {
+		bus.Publish(events.Event{Kind: events.KindCheckpoint, Sequence: uint64(i + 1)})
+	}
+	close(w.release)
+
+	// The gap shows once an event arrives after the buffered ones
+	deadline := time.After(5 * time.Second)
+	for seq := uint64(401); ; seq++ {
+		bus.Publish(events.Event{Kind: events.KindCheckpoint, Sequence: seq})
+		select {
+		case <-done:
+		case <-time.After(10 * time.Millisecond):
+			continue
+		case <-deadline:
+			t.Fatal("the stream of a client that missed events was not closed")
+		}
+		break
+	}
+	w.mu.Lock()
+	ids := parseIDs(t, w.body.String())
+	w.mu.Unlock()
+	for i, id := range ids {
+		if id != uint64(i+1) {
+			t.Fatalf("sent events %v, want consecutive IDs up to the gap", ids)
+		}
+	}
+	if n := len(ids); n == 0 || n >= 400 {
+		t.Errorf("sent %d events before closing, want those before the gap", n)
+	}
+}
+
+func TestServeClosesStreams(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := l.Addr().String()
+	l.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := NewServer(config.APIConfig{ListenAddr: addr}, "testnet", events.NewBus(0))
+	go s.Serve(ctx)
+
+	var resp *http.Response
+	for i := 0; i < 50; i++ {
+		if resp, err = http.Get("http://" + addr + "/api/v1/stream"); err == nil {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatalf("GET stream: %v", err)
+	}
+	defer resp.Body.Close()
+
+	cancel()
+	start := time.Now()
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+		}
+	}()
+	select {
+	case <-closed:
+		if elapsed := time.Since(start); elapsed > 2*time.Second {
+			t.Errorf("stream closed %v after shutdown", elapsed)
+		}
+	case <-time.After(4 * time.Second):
+		t.Fatal("stream still open after shutdown")
+	}
+}
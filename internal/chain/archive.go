@@ -0,0 +1,103 @@
+package chain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	markerName  = ".chain.json"
+	archiveName = "archive"
+)
+
+// marker records the chain that the files next to it were recorded on.
+type marker struct {
+	ChainID    string    `json:"chain_id"`
+	Identifier string    `json:"identifier"`
+	Chain      string    `json:"chain,omitempty"`
+	FirstSeen  time.Time `json:"first_seen"`
+}
+
+// ClaimFolder ties the contents of folder to the chain. If the folder was
+// recorded on a different chain, its contents are first moved to
+// folder/archive/<old identifier>-<time>, which is returned.
+func ClaimFolder(folder string, info Info) (string, error) {
+	if err := os.MkdirAll(folder, 0755); err != nil {
+		return "", err
+	}
+	entries, err := os.ReadDir(folder)
+	if err != nil {
+		return "", err
+	}
+	var paths []string
+	for _, e := range entries {
+		if e.Name() != markerName && e.Name() != archiveName {
+			paths = append(paths, filepath.Join(folder, e.Name()))
+		}
+	}
+	return claim(filepath.Join(folder, markerName), filepath.Join(folder, archiveName), paths, info)
+}
+
+// ClaimFile ties a file, such as the log file, to the chain, using a marker
+// next to it. A file recorded on a different chain is moved to an archive
+// folder beside it, which is returned.
+func ClaimFile(path string, info Info) (string, error) {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	var paths []string
+	if _, err := os.Stat(path); err == nil {
+		paths = append(paths, path)
+	}
+	return claim(path+markerName, filepath.Join(dir, archiveName), paths, info)
+}
+
+func claim(markerPath, archiveRoot string, paths []string, info Info) (string, error) {
+	var old marker
+	data, err := os.ReadFile(markerPath)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		// Files from before chain tracking are assumed to belong to this chain.
+		return "", writeMarker(markerPath, info)
+	case err != nil:
+		return "", fmt.Errorf("error reading chain marker: %w", err)
+	}
+	if err := json.Unmarshal(data, &old); err != nil {
+		return "", fmt.Errorf("error parsing chain marker %s: %w", markerPath, err)
+	}
+	if old.ChainID == info.ChainID {
+		return "", nil
+	}
+
+	dir := filepath.Join(archiveRoot, fmt.Sprintf("%s-%s", old.Identifier, time.Now().Format("20060102T150405")))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("error creating archive folder: %w", err)
+	}
+	for _, p := range append(paths, markerPath) {
+		if err := os.Rename(p, filepath.Join(dir, filepath.Base(p))); err != nil {
+			return "", fmt.Errorf("error archiving %s: %w", p, err)
+		}
+	}
+	return dir, writeMarker(markerPath, info)
+}
+
+func writeMarker(path string, info Info) error {
+	data, err := json.MarshalIndent(marker{
+		ChainID:    info.ChainID,
+		Identifier: info.Identifier,
+		Chain:      info.Chain,
+		FirstSeen:  time.Now().UTC(),
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("error writing chain marker: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+// Package chain identifies the chain a node serves and keeps data recorded
+// for one chain apart from data recorded for another, e.g. after a devnet wipe.
+package chain
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"time"
+
+	"suitop/internal/rpc"
+	rpcPb "suitop/pb/sui/rpc/v2beta"
+)
+
+// Info identifies the chain served by the configured endpoints.
+type Info struct {
+	ChainID       string // Genesis checkpoint digest (base58) reported over gRPC
+	Identifier    string // First 4 bytes of ChainID in hex
+	Chain         string // Chain name reported over gRPC, e.g. "mainnet"; may be empty
+	RPCIdentifier string // Chain identifier reported over JSON-RPC
+}
+
+// Query fetches the chain identity from the gRPC and JSON-RPC endpoints.
+func Query(ctx context.Context, ledger rpcPb.LedgerServiceClient, client *rpc.Client) (Info, error) {
+	var info Info
+	resp, err := ledger.GetServiceInfo(ctx, &rpcPb.GetServiceInfoRequest{})
+	if err != nil {
+		return info, fmt.Errorf("error getting service info over gRPC: %w", err)
+	}
+	info.ChainID = resp.GetChainId()
+	info.Chain = resp.GetChain()
+	if info.Identifier, err = Identifier(info.ChainID); err != nil {
+		return info, err
+	}
+	if info.RPCIdentifier, err = client.GetChainIdentifier(ctx); err != nil {
+		return info, err
+	}
+	return info, nil
+}
+
+// Verify checks that both endpoints serve the same chain and, if expected is
+// set, that it is the expected one.
+func (i Info) Verify(expected string) error {
+	if !strings.EqualFold(i.Identifier, i.RPCIdentifier) {
+		return fmt.Errorf("gRPC endpoint serves chain %s but JSON-RPC endpoint serves chain %s; SUI_NODE and SUI_JSON_RPC_URL must point at the same network", i.Identifier, i.RPCIdentifier)
+	}
+	if expected != "" && !strings.EqualFold(i.Identifier, expected) {
+		return fmt.Errorf("node serves chain %s (%s) but the configured network is chain %s", i.Identifier, i.name(), expected)
+	}
+	return nil
+}
+
+func (i Info) name() string {
+	if i.Chain == "" {
+		return "unnamed"
+	}
+	return i.Chain
+}
+
+// Watch queries the chain identity every interval and calls onReset once if
+// the gRPC endpoint starts serving a different chain than initial. Query
+// errors are logged and retried on the next tick.
+func Watch(ctx context.Context, interval time.Duration, query func(context.Context) (Info, error), initial Info, onReset func(Info)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			info, err := query(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					log.Printf("Warning: chain identity check failed: %v", err)
+				}
+				continue
+			}
+			if info.ChainID != initial.ChainID {
+				onReset(info)
+				return
+			}
+			if err := info.Verify(""); err != nil {
+				log.Printf("Warning: %v", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Identifier returns the 8 hex digit chain identifier for a base58 chain ID,
+// matching sui_getChainIdentifier.
+func Identifier(chainID string) (string, error) {
+	digest, err := decodeBase58(chainID)
+	if err != nil {
+		return "", fmt.Errorf("invalid chain ID %q: %w", chainID, err)
+	}
+	if len(digest) < 4 {
+		return "", fmt.Errorf("invalid chain ID %q: too short", chainID)
+	}
+	return hex.EncodeToString(digest[:4]), nil
+}
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+func decodeBase58(s string) ([]byte, error) {
+	if s == "" {
+		return nil, errors.New("empty")
+	}
+	n := new(big.Int)
+	radix := big.NewInt(58)
+	for _, r := range s {
+		d := strings.IndexRune(base58Alphabet, r)
+		if d < 0 {
+			return nil, fmt.Errorf("invalid base58 character %q", r)
+		}
+		n.Mul(n, radix)
+		n.Add(n, big.NewInt(int64(d)))
+	}
+	// Each leading '1' encodes a leading zero byte.
+	zeros := len(s) - len(strings.TrimLeft(s, "1"))
+	return append(make([]byte, zeros), n.Bytes()...), nil
+}
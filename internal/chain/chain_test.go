@@ -0,0 +1,87 @@
+package chain
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+func TestDecodeBase58(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string // hex
+		wantErr bool
+	}{
+		{"1", "00", false},
+		{"11", "0000", false},
+		{"1112", "00000001", false},
+		{"2g", "61", false},
+		{"a3gV", "626262", false},
+		{"aPEr", "636363", false},
+		{"StV1DL6CwTryKyV", hex.EncodeToString([]byte("hello world")), false},
+		{"2NEpo7TZRRrLZSi2U", hex.EncodeToString([]byte("Hello World!")), false},
+		{"", "", true},
+		{"0", "", true}, // 0, O, I and l are not in the alphabet
+		{"O1", "", true},
+		{"abcIl", "", true},
+		{"héllo", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := decodeBase58(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("decodeBase58(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			want, _ := hex.DecodeString(tt.want)
+			if !tt.wantErr && !bytes.Equal(got, want) {
+				t.Errorf("decodeBase58(%q) = %x, want %s", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIdentifier(t *testing.T) {
+	tests := []struct {
+		name    string
+		chainID string
+		want    string
+		wantErr bool
+	}{
+		{"mainnet", "4btiuiMPvEENsttpZC7CZ53DruC3MAgfznDbASZ7DR6S", "35834a8a", false},
+		{"testnet", "69WiPg3DAQiwdxfncX6wYQ2siKwAe6L9BZthQea3JNMD", "4c78adac", false},
+		{"too short", "2NEp", "", true},
+		{"not base58", "0xdeadbeef", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Identifier(tt.chainID)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Identifier(%q) error = %v, wantErr %v", tt.chainID, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Identifier(%q) = %q, want %q", tt.chainID, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	tests := []struct {
+		name     string
+		info     Info
+		expected string
+		wantErr  bool
+	}{
+		{"same chain", Info{Identifier: "35834a8a", RPCIdentifier: "35834A8A"}, "35834a8a", false},
+		{"any chain", Info{Identifier: "01020304", RPCIdentifier: "01020304"}, "", false},
+		{"endpoints disagree", Info{Identifier: "35834a8a", RPCIdentifier: "4c78adac"}, "", true},
+		{"other network", Info{Identifier: "4c78adac", RPCIdentifier: "4c78adac", Chain: "testnet"}, "35834a8a", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.info.Verify(tt.expected); (err != nil) != tt.wantErr {
+				t.Errorf("Verify(%q) error = %v, wantErr %v", tt.expected, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+// Package check samples a validator's recent signatures for monitoring-system
+// checks such as `suitop check`.
+package check
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"suitop/internal/checkpoint"
+	"suitop/internal/maintenance"
+	"suitop/internal/types"
+	val "suitop/internal/validator"
+	rpcPb "suitop/pb/sui/rpc/v2beta"
+)
+
+// fetchWorkers is the number of checkpoints fetched from the node concurrently.
+const fetchWorkers = 16
+
+// MaxWindow is the largest number of checkpoints Sample fetches, which are
+// all held in memory at once.
+const MaxWindow = 10000
+
+// Result is a validator's signing record over a window of recent checkpoints.
+type Result struct {
+	Validator val.ValidatorInfo // As listed in the committee of the latest checkpoint sampled
+	From, To  uint64            // Sequence range sampled
+	Sampled   uint64            // Checkpoints in the range for which the validator was in the committee
+
+	// Stats counts the sampled checkpoints; AttestedCount and PlannedMisses
+	// are set, MissStreak counts the misses up to To.
+	Stats       types.ValidatorStats
+	SignStreak  uint64 // Checkpoints signed in a row up to To
+	Maintenance bool   // The latest sampled checkpoint was missed inside a maintenance window
+}
+
+// Uptime returns the share of sampled checkpoints signed, leaving out
+// checkpoints missed during planned maintenance.
+func (r Result) Uptime() float64 {
+	return r.Stats.Uptime(r.Sampled)
+}
+
+// Missed returns the sampled checkpoints missed outside maintenance windows.
+func (r Result) Missed() uint64 {
+	return r.Stats.UnplannedMisses(r.Sampled)
+}
+
+// Sampler fetches recent checkpoints from a node's ledger service.
+type Sampler struct {
+	ledger      rpcPb.LedgerServiceClient
+	loader      *val.Loader
+	maintenance *maintenance.Schedule // Optional
+}
+
+// NewSampler creates a sampler. schedule may be nil.
+func NewSampler(ledger rpcPb.LedgerServiceClient, loader *val.Loader, schedule *maintenance.Schedule) *Sampler {
+	return &Sampler{ledger: ledger, loader: loader, maintenance: schedule}
+}
+
+// Sample computes the signing record of the validator identified by spec (a
+// name, address or protocol pubkey) over the last window checkpoints the node
+// has. The window is shortened if the node has pruned older checkpoints.
+func (s *Sampler) Sample(ctx context.Context, spec string, window uint64) (Result, error) {
+	if window == 0 || window > MaxWindow {
+		return Result{}, fmt.Errorf("window must be between 1 and %d checkpoints", MaxWindow)
+	}
+	info, err := s.ledger.GetServiceInfo(ctx, &rpcPb.GetServiceInfoRequest{})
+	if err != nil {
+		return Result{}, fmt.Errorf("GetServiceInfo: %w", err)
+	}
+	to := info.GetCheckpointHeight()
+	from := uint64(0)
+	if to+1 > window {
+		from = to + 1 - window
+	}
+	if lowest := info.GetLowestAvailableCheckpoint(); from < lowest {
+		from = lowest
+	}
+	if from > to {
+		return Result{}, fmt.Errorf("node has no checkpoints available")
+	}
+
+	checkpoints, err := s.fetch(ctx, from, to)
+	if err != nil {
+		return Result{}, err
+	}
+
+	// The window may span an epoch change, so each epoch's committee is used
+	// for its own checkpoints.
+	committees := make(map[uint64][]val.ValidatorInfo)
+	r := Result{From: from, To: to}
+	found := false
+	for _, cp := range checkpoints { // In sequence order
+		epoch := cp.GetSignature().GetEpoch()
+		committee, ok := committees[epoch]
+		if !ok {
+			committee, _, err = s.loader.LoadEpochValidatorData(ctx, epoch)
+			if err != nil {
+				return Result{}, fmt.Errorf("loading the committee of epoch %d: %w", epoch, err)
+			}
+			committees[epoch] = committee
+		}
+		v, ok := findValidator(committee, spec)
+		if !ok {
+			continue
+		}
+		found = true
+		r.Validator = v
+		r.Sampled++
+		if checkpoint.IsValidatorSigned(cp.GetSignature().GetBitmap(), v.BitmapIndex) {
+			r.Stats.AttestedCount++
+			r.SignStreak++
+			r.Stats.MissStreak = 0
+			r.Maintenance = false
+			continue
+		}
+		r.SignStreak = 0
+		r.Stats.MissStreak++
+		ts := time.Time{}
+		if pbTs := cp.GetSummary().GetTimestamp(); pbTs != nil {
+			ts = pbTs.AsTime()
+		}
+		_, r.Maintenance = s.maintenance.Active(v.SuiAddress, v.Names(), cp.GetSequenceNumber(), ts)
+		if r.Maintenance {
+			r.Stats.PlannedMisses++
+		}
+	}
+	if !found {
+		return Result{}, fmt.Errorf("validator %q is not in the committee of checkpoints %d-%d", spec, from, to)
+	}
+	return r, nil
+}
+
+// fetch gets the checkpoints from..to, in order, with their signatures.
+func (s *Sampler) fetch(ctx context.Context, from, to uint64) ([]*rpcPb.Checkpoint, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	out := make([]*rpcPb.Checkpoint, to-from+1)
+	seqs := make(chan uint64)
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	for i := 0; i < fetchWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for seq := range seqs {
+				cp, err := s.ledger.GetCheckpoint(ctx, &rpcPb.GetCheckpointRequest{
+					CheckpointId: &rpcPb.GetCheckpointRequest_SequenceNumber{SequenceNumber: seq},
+					ReadMask:     &fieldmaskpb.FieldMask{Paths: []string{"sequence_number", "signature", "summary.timestamp"}},
+				})
+				if err != nil {
+					mu.Lock()
+					if firstErr == nil {
+						firstErr = fmt.Errorf("GetCheckpoint %d: %w", seq, err)
+						cancel()
+					}
+					mu.Unlock()
+					continue
+				}
+				out[seq-from] = cp
+			}
+		}()
+	}
+feed:
+	for seq := from; seq <= to; seq++ {
+		select {
+		case seqs <- seq:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(seqs)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func findValidator(committee []val.ValidatorInfo, spec string) (val.ValidatorInfo, bool) {
+	for _, v := range committee {
+		if v.Matches(spec) {
+			return v, true
+		}
+	}
+	return val.ValidatorInfo{}, false
+}
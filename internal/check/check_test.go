@@ -0,0 +1,41 @@
+package check
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"suitop/internal/types"
+)
+
+func TestSampleRejectsWindow(t *testing.T) {
+	s := NewSampler(nil, nil, nil)
+	for _, window := range []uint64{0, MaxWindow + 1} {
+		if _, err := s.Sample(context.Background(), "alpha", window); err == nil || !strings.Contains(err.Error(), "window") {
+			t.Errorf("Sample(window %d) error = %v, want a window error", window, err)
+		}
+	}
+}
+
+func TestResult(t *testing.T) {
+	tests := []struct {
+		name   string
+		result Result
+		uptime float64
+		missed uint64
+	}{
+		{"all signed", Result{Sampled: 10, Stats: types.ValidatorStats{AttestedCount: 10}}, 1, 0},
+		{"some missed", Result{Sampled: 10, Stats: types.ValidatorStats{AttestedCount: 8}}, 0.8, 2},
+		{"planned left out", Result{Sampled: 10, Stats: types.ValidatorStats{AttestedCount: 8, PlannedMisses: 2}}, 1, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.result.Uptime(); got != tt.uptime {
+				t.Errorf("Uptime() = %v, want %v", got, tt.uptime)
+			}
+			if got := tt.result.Missed(); got != tt.missed {
+				t.Errorf("Missed() = %d, want %d", got, tt.missed)
+			}
+		})
+	}
+}
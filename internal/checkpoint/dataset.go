@@ -8,11 +8,13 @@ import (
 )
 
 type validatorEntry struct {
-	Name    string `json:"name"`
-	Address string `json:"address"`
-	Signed  uint64 `json:"signed"`
-	Total   uint64 `json:"total"`
-	Bitmap  []byte `json:"bitmap"`
+	Name            string `json:"name"` // On-chain name, even if a labels file renames the validator
+	Address         string `json:"address"`
+	Signed          uint64 `json:"signed"`
+	Total           uint64 `json:"total"`
+	PlannedMissed   uint64 `json:"planned_missed"`   // Missed inside a maintenance window
+	UnplannedMissed uint64 `json:"unplanned_missed"` // Missed outside maintenance windows
+	Bitmap          []byte `json:"bitmap"`
 }
 
 type epochData struct {
@@ -48,25 +50,31 @@ func (dm *DatasetManager) startEpoch(epoch uint64, committee []val.ValidatorInfo
 		Order:           make([]string, 0, len(committee)),
 	}
 	for _, v := range committee {
-		dm.data.Validators[v.SuiAddress] = &validatorEntry{Name: v.Name, Address: v.SuiAddress}
+		dm.data.Validators[v.SuiAddress] = &validatorEntry{Name: v.OnChainName(), Address: v.SuiAddress}
 		dm.data.Order = append(dm.data.Order, v.SuiAddress)
 	}
 }
 
-func (dm *DatasetManager) appendBit(v *validatorEntry, signed bool) {
+func (dm *DatasetManager) appendBit(v *validatorEntry, signed bool, planned bool) {
 	byteIndex := int(v.Total / 8)
 	if byteIndex >= len(v.Bitmap) {
 		v.Bitmap = append(v.Bitmap, 0)
 	}
-	if signed {
+	switch {
+	case signed:
 		v.Bitmap[byteIndex] |= 1 << (v.Total % 8)
 		v.Signed++
+	case planned:
+		v.PlannedMissed++
+	default:
+		v.UnplannedMissed++
 	}
 	v.Total++
 }
 
-// RecordCheckpoint records signatures for a checkpoint.
-func (dm *DatasetManager) RecordCheckpoint(epoch uint64, seq uint64, bitmap []uint32, committee []val.ValidatorInfo) {
+// RecordCheckpoint records signatures for a checkpoint. planned holds the
+// addresses of validators whose miss falls inside a maintenance window.
+func (dm *DatasetManager) RecordCheckpoint(epoch uint64, seq uint64, bitmap []uint32, committee []val.ValidatorInfo, planned map[string]bool) {
 	if dm.data == nil {
 		dm.startEpoch(epoch, committee, seq)
 	}
@@ -78,12 +86,12 @@ func (dm *DatasetManager) RecordCheckpoint(epoch uint64, seq uint64, bitmap []ui
 	for _, v := range committee {
 		entry, ok := dm.data.Validators[v.SuiAddress]
 		if !ok {
-			entry = &validatorEntry{Name: v.Name, Address: v.SuiAddress}
+			entry = &validatorEntry{Name: v.OnChainName(), Address: v.SuiAddress}
 			dm.data.Validators[v.SuiAddress] = entry
 			dm.data.Order = append(dm.data.Order, v.SuiAddress)
 		}
 		signed := IsValidatorSigned(bitmap, v.BitmapIndex)
-		dm.appendBit(entry, signed)
+		dm.appendBit(entry, signed, planned[v.SuiAddress])
 	}
 }
 
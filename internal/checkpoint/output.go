@@ -0,0 +1,155 @@
+package checkpoint
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"suitop/internal/config"
+)
+
+// Record types of the structured plain-mode output.
+const (
+	recordCheckpoint = "checkpoint" // Summary of a processed checkpoint
+	recordValidator  = "validator"  // One committee member's status at a checkpoint
+	recordEpoch      = "epoch"      // Epoch change
+	recordEvent      = "event"      // Validator status flip or source event, in changes mode
+	recordGroup      = "group"      // Aggregate status of an operator group from the labels file
+)
+
+// Validator statuses in validator records.
+const (
+	statusSigned      = "signed"
+	statusMissed      = "missed"
+	statusMaintenance = "maintenance" // Missed inside a maintenance window
+)
+
+// recordColumns are the fields of all record types, in output order. CSV
+// output has one column per field; fields a record type does not carry are
+// left empty.
+var recordColumns = []string{
+	"record", "time", "epoch", "previous_epoch", "sequence",
+	"signers", "committee_size", "signed_power", "total_power", "signed_pct", "total_checkpoints",
+	"name", "address", "voting_power", "status", "attested", "uptime_pct", "miss_streak", "planned_misses", "unplanned_misses",
+	"kind", "message", "watched", "group", "tags", "members", "missing",
+}
+
+// field is one key/value pair of a record.
+type field struct {
+	key   string
+	value interface{}
+}
+
+// record is one line of structured output. Its fields keep the order of
+// recordColumns.
+type record []field
+
+// recordWriter writes records as lines in one of the structured formats.
+type recordWriter struct {
+	format config.OutputFormat
+	w      io.Writer
+	header bool // CSV header written
+}
+
+// newRecordWriter returns a writer for a structured format, or nil for the
+// text report.
+func newRecordWriter(format config.OutputFormat, w io.Writer) *recordWriter {
+	switch format {
+	case config.OutputJSON, config.OutputCSV, config.OutputLogfmt:
+		return &recordWriter{format: format, w: w}
+	}
+	return nil
+}
+
+// write writes r as one line. Write errors are ignored, as for the text report.
+func (rw *recordWriter) write(r record) {
+	var buf bytes.Buffer
+	switch rw.format {
+	case config.OutputJSON:
+		buf.WriteByte('{')
+		for i, f := range r {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			key, _ := json.Marshal(f.key)
+			value, err := json.Marshal(f.value)
+			if err != nil {
+				value = []byte("null")
+			}
+			buf.Write(key)
+			buf.WriteByte(':')
+			buf.Write(value)
+		}
+		buf.WriteString("}\n")
+	case config.OutputLogfmt:
+		for i, f := range r {
+			if i > 0 {
+				buf.WriteByte(' ')
+			}
+			buf.WriteString(f.key)
+			buf.WriteByte('=')
+			buf.WriteString(logfmtValue(formatValue(f.value)))
+		}
+		buf.WriteByte('\n')
+	case config.OutputCSV:
+		cw := csv.NewWriter(&buf)
+		if !rw.header {
+			cw.Write(recordColumns)
+			rw.header = true
+		}
+		values := make(map[string]string, len(r))
+		for _, f := range r {
+			values[f.key] = formatValue(f.value)
+		}
+		row := make([]string, len(recordColumns))
+		for i, column := range recordColumns {
+			row[i] = values[column]
+		}
+		cw.Write(row)
+		cw.Flush()
+	}
+	rw.w.Write(buf.Bytes())
+}
+
+// formatValue renders a field value for the CSV and logfmt formats.
+func formatValue(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case int:
+		return strconv.Itoa(v)
+	case uint64:
+		return strconv.FormatUint(v, 10)
+	case bool:
+		return strconv.FormatBool(v)
+	case []string:
+		return strings.Join(v, ",")
+	}
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+// logfmtValue quotes a value if it is empty or contains spaces, quotes or '='.
+func logfmtValue(s string) string {
+	if s == "" || strings.ContainsAny(s, " \t\n\"=\\") {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// pct returns part/total as a percentage rounded to two decimals.
+func pct(part, total float64) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return math.Round(part/total*10000) / 100
+}
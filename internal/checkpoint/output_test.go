@@ -0,0 +1,179 @@
+package checkpoint
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"suitop/internal/config"
+)
+
+var testTime = time.Date(2026, 5, 1, 12, 0, 0, 500, time.UTC)
+
+// testRecords are a checkpoint and a validator record in recordColumns order
+func testRecords() []record {
+	return []record{
+		{
+			{"record", recordCheckpoint}, {"time", testTime}, {"epoch", uint64(7)}, {"sequence", uint64(1000)},
+			{"signers", 2}, {"signed_pct", 66.67},
+		},
+		{
+			{"record", recordValidator}, {"epoch", uint64(7)}, {"name", "Node \"One\", Inc"},
+			{"address", "0xa"}, {"status", statusMissed}, {"watched", true}, {"tags", []string{"eu", "prod"}},
+		},
+	}
+}
+
+func TestNewRecordWriter(t *testing.T) {
+	for _, format := range []config.OutputFormat{config.OutputJSON, config.OutputCSV, config.OutputLogfmt} {
+		if newRecordWriter(format, &bytes.Buffer{}) == nil {
+			t.Errorf("newRecordWriter(%s) = nil", format)
+		}
+	}
+	if rw := newRecordWriter(config.OutputText, &bytes.Buffer{}); rw != nil {
+		t.Errorf("newRecordWriter(text) = %+v, want nil", rw)
+	}
+}
+
+func TestRecordWriterJSON(t *testing.T) {
+	var buf bytes.Buffer
+	rw := newRecordWriter(config.OutputJSON, &buf)
+	for _, r := range testRecords() {
+		rw.write(r)
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2:\n%s", len(lines), buf.String())
+	}
+	// Keys keep the record's field order
+	if !strings.HasPrefix(lines[0], `{"record":"checkpoint","time":"2026-05-01T12:00:00.0000005Z","epoch":7,`) {
+		t.Errorf("checkpoint line = %s", lines[0])
+	}
+	var v map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[1]), &v); err != nil {
+		t.Fatalf("validator line is not JSON: %v\n%s", err, lines[1])
+	}
+	if v["name"] != `Node "One", Inc` || v["watched"] != true || len(v["tags"].([]interface{})) != 2 {
+		t.Errorf("validator line = %v", v)
+	}
+}
+
+func TestRecordWriterCSV(t *testing.T) {
+	var buf bytes.Buffer
+	rw := newRecordWriter(config.OutputCSV, &buf)
+	for _, r := range testRecords() {
+		rw.write(r)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v\n%s", err, buf.String())
+	}
+	if len(rows) != 3 {
+		t.Fatalf("got %d rows, want a header and 2 records", len(rows))
+	}
+	column := make(map[string]int)
+	for i, name := range rows[0] {
+		column[name] = i
+	}
+	if len(rows[0]) != len(recordColumns) {
+		t.Errorf("header has %d columns, want %d", len(rows[0]), len(recordColumns))
+	}
+	tests := []struct {
+		row    int
+		column string
+		want   string
+	}{
+		{1, "record", "checkpoint"},
+		{1, "time", "2026-05-01T12:00:00.0000005Z"},
+		{1, "signed_pct", "66.67"},
+		{1, "name", ""}, // Not carried by checkpoint records
+		{2, "name", `Node "One", Inc`},
+		{2, "watched", "true"},
+		{2, "tags", "eu,prod"},
+		{2, "sequence", ""},
+	}
+	for _, tt := range tests {
+		if got := rows[tt.row][column[tt.column]]; got != tt.want {
+			t.Errorf("row %d %s = %q, want %q", tt.row, tt.column, got, tt.want)
+		}
+	}
+}
+
+func TestRecordWriterLogfmt(t *testing.T) {
+	var buf bytes.Buffer
+	rw := newRecordWriter(config.OutputLogfmt, &buf)
+	for _, r := range testRecords() {
+		rw.write(r)
+	}
+	want := "record=checkpoint time=2026-05-01T12:00:00.0000005Z epoch=7 sequence=1000 signers=2 signed_pct=66.67\n" +
+		`record=validator epoch=7 name="Node \"One\", Inc" address=0xa status=missed watched=true tags=eu,prod` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("logfmt output:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestFormatValue(t *testing.T) {
+	tests := []struct {
+		name string
+		in   interface{}
+		want string
+	}{
+		{"string", "abc", "abc"},
+		{"time", testTime, "2026-05-01T12:00:00.0000005Z"},
+		{"float", 99.5, "99.5"},
+		{"whole float", 100.0, "100"},
+		{"int", -3, "-3"},
+		{"uint64", uint64(18446744073709551615), "18446744073709551615"},
+		{"bool", false, "false"},
+		{"strings", []string{"a", "b"}, "a,b"},
+		{"no strings", []string(nil), ""},
+		{"other", map[string]int{"a": 1}, `{"a":1}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatValue(tt.in); got != tt.want {
+				t.Errorf("formatValue(%v) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLogfmtValue(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"plain", "plain"},
+		{"", `""`},
+		{"two words", `"two words"`},
+		{"a=b", `"a=b"`},
+		{`say "hi"`, `"say \"hi\""`},
+		{"line\nbreak", `"line\nbreak"`},
+		{`back\slash`, `"back\\slash"`},
+	}
+	for _, tt := range tests {
+		if got := logfmtValue(tt.in); got != tt.want {
+			t.Errorf("logfmtValue(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestPct(t *testing.T) {
+	tests := []struct {
+		part, total, want float64
+	}{
+		{1, 3, 33.33},
+		{2, 3, 66.67},
+		{5, 5, 100},
+		{0, 10, 0},
+		{1, 0, 0},
+		{1, -1, 0},
+	}
+	for _, tt := range tests {
+		if got := pct(tt.part, tt.total); got != tt.want {
+			t.Errorf("pct(%v, %v) = %v, want %v", tt.part, tt.total, got, tt.want)
+		}
+	}
+}
@@ -5,12 +5,21 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"math"
 	"os"
 	"sort"
+	"strings"
+	"time"
 
 	"suitop/internal/config"
+	"suitop/internal/events"
+	"suitop/internal/history"
+	"suitop/internal/labels"
+	"suitop/internal/maintenance"
+	"suitop/internal/node"
 	"suitop/internal/types"
 	val "suitop/internal/validator" // Alias for validator package
+	"suitop/internal/watch"
 
 	// Assuming pb types will be accessible via this path after go.mod setup
 	// For CheckpointData type from subscription
@@ -25,20 +34,82 @@ type Processor struct {
 	cfg          config.ProcessorConfig // Placeholder for future config
 	currentEpoch uint64
 	committee    []val.ValidatorInfo
-	plainMode    bool // When true, output to stdout instead of TUI
+	plainMode    bool          // When true, output to stdout instead of TUI
+	records      *recordWriter // Structured plain-mode output; nil for the text report
 	dataset      *DatasetManager
+	history      *history.Store // Optional per-checkpoint time-series store
 	reportCount  int
+	lastReport   time.Time // When plain mode last wrote a report
+	sinceReport  uint64    // Checkpoints processed since the last report
+	emitChanges  bool      // Plain mode writes events instead of reports
+
+	epochRegressed bool                // Set while checkpoints arrive from an earlier epoch
+	onChainReset   func(reason string) // Optional; called when the epoch goes backwards
+
+	// Validators announced as missing and not yet as signing again. Misses
+	// inside a maintenance window are not announced.
+	missingAnnounced map[string]bool
+
+	snapshotHooks []func(types.SnapshotMsg)
+	events        *events.Bus           // Optional; receives checkpoint, validator and epoch events
+	maintenance   *maintenance.Schedule // Optional; misses inside its windows count as planned downtime
+	node          *node.Monitor         // Optional; its health is included in plain-mode reports
+	watch         *watch.List           // Optional; watched validators get a summary line in plain-mode reports
 }
 
 // NewProcessor creates a new checkpoint processor.
-func NewProcessor(valLoader *val.Loader, statsManager *StatsManager, cfg config.ProcessorConfig, plainMode bool, dataset *DatasetManager) *Processor {
-	return &Processor{
+func NewProcessor(valLoader *val.Loader, statsManager *StatsManager, cfg config.ProcessorConfig, plainMode bool, dataset *DatasetManager, historyStore *history.Store) *Processor {
+	p := &Processor{
 		valLoader:    valLoader,
 		statsManager: statsManager,
 		cfg:          cfg,
 		plainMode:    plainMode,
 		dataset:      dataset,
+		history:      historyStore,
+
+		missingAnnounced: make(map[string]bool),
+	}
+	if plainMode {
+		p.records = newRecordWriter(cfg.Output, os.Stdout)
 	}
+	return p
+}
+
+// OnSnapshot registers a function that receives a state snapshot after every
+// processed checkpoint. Hooks run on the processor goroutine and must not block.
+// It must be called before Run.
+func (p *Processor) OnSnapshot(fn func(types.SnapshotMsg)) {
+	p.snapshotHooks = append(p.snapshotHooks, fn)
+}
+
+// SetEventBus makes the processor publish checkpoint summaries, validator
+// status changes and epoch transitions to the bus. It must be called before Run.
+func (p *Processor) SetEventBus(bus *events.Bus) {
+	p.events = bus
+}
+
+// SetMaintenance counts misses inside the schedule's windows as planned
+// downtime. It must be called before Run.
+func (p *Processor) SetMaintenance(schedule *maintenance.Schedule) {
+	p.maintenance = schedule
+}
+
+// SetNodeMonitor includes the node's health in plain-mode reports.
+func (p *Processor) SetNodeMonitor(monitor *node.Monitor) {
+	p.node = monitor
+}
+
+// SetWatchlist summarises the listed validators in plain-mode reports and, if
+// the list shows only watched validators, leaves the others out.
+func (p *Processor) SetWatchlist(l *watch.List) {
+	p.watch = l
+}
+
+// OnChainReset registers a function called once checkpoints arrive from an
+// earlier epoch than the current one, which only happens when the node's
+// chain was reset. It must be called before Run.
+func (p *Processor) OnChainReset(fn func(reason string)) {
+	p.onChainReset = fn
 }
 
 // Run starts the checkpoint processing loop.
@@ -48,15 +119,29 @@ func (p *Processor) Run(ctx context.Context, initialEpoch uint64, initialCommitt
 	checkpointStream <-chan *rpcPb.Checkpoint, uiChan chan<- types.SnapshotMsg) {
 	p.currentEpoch = initialEpoch
 	p.committee = initialCommittee
+	p.recordCommittee()
+
+	// In changes mode the processor writes events instead of reports. It
+	// writes its own events as it publishes them, so that none is lost to a
+	// full subscription, and reads those of the subscriber and the chain
+	// check from the bus.
+	var changes <-chan events.Event
+	if p.plainMode && p.cfg.Emit.Mode == config.EmitChanges {
+		p.emitChanges = true
+		ch, unsubscribe := p.events.SubscribeKinds(64, events.KindSourceHealth, events.KindChainReset)
+		defer unsubscribe()
+		changes = ch
+	}
 
 	for {
 		select {
+		case ev := <-changes:
+			p.printChange(ev)
+
 		case receivedCheckpoint, ok := <-checkpointStream:
 			if !ok {
 				log.Println("Checkpoint channel closed, exiting processor loop.")
-				if p.dataset != nil {
-					p.dataset.Close()
-				}
+				p.close()
 				return
 			}
 
@@ -66,16 +151,34 @@ func (p *Processor) Run(ctx context.Context, initialEpoch uint64, initialCommitt
 				continue // Skip checkpoints without signatures for uptime calculation
 			}
 
-			p.statsManager.IncrementTotalCheckpointsWithSig()
-
 			// Epoch value is stored inside the validator aggregated signature
 			// which is guaranteed to be present in the subscription
 			// because we request the full signature message.
 			checkpointEpochVal := receivedCheckpoint.GetSignature().GetEpoch()
 
+			// Epochs never go backwards on one chain. An earlier epoch means the
+			// node was wiped and restarted; its checkpoints do not match the
+			// committee, so skip them until suitop exits to restart.
+			if checkpointEpochVal < p.currentEpoch {
+				if !p.epochRegressed {
+					log.Printf("Checkpoint %d is from epoch %d, before current epoch %d; the chain may have been reset. Skipping such checkpoints.",
+						receivedCheckpoint.GetSequenceNumber(), checkpointEpochVal, p.currentEpoch)
+					p.epochRegressed = true
+					if p.onChainReset != nil {
+						p.onChainReset(fmt.Sprintf("checkpoint %d is from epoch %d, before current epoch %d",
+							receivedCheckpoint.GetSequenceNumber(), checkpointEpochVal, p.currentEpoch))
+					}
+				}
+				continue
+			}
+			p.epochRegressed = false
+
+			p.statsManager.IncrementTotalCheckpointsWithSig()
+
 			// Epoch change detection and committee reload
 			if checkpointEpochVal > p.currentEpoch {
-				if p.plainMode {
+				previousEpoch := p.currentEpoch
+				if p.plainMode && p.records == nil {
 					fmt.Printf("\nEpoch changed from %d to %d. Reloading committee...\n", p.currentEpoch, checkpointEpochVal)
 				} else {
 					log.Printf("Epoch changed from %d to %d. Reloading committee...", p.currentEpoch, checkpointEpochVal)
@@ -85,17 +188,41 @@ func (p *Processor) Run(ctx context.Context, initialEpoch uint64, initialCommitt
 				newCommittee, newLoadedEpoch, err := p.valLoader.LoadEpochValidatorData(ctx, checkpointEpochVal)
 				if err != nil {
 					log.Printf("Failed to load committee for new epoch %d: %v. Continuing with old committee.", checkpointEpochVal, err)
+					p.publish(events.Event{
+						Kind:     events.KindCommitteeReloadFailed,
+						Epoch:    checkpointEpochVal,
+						Sequence: receivedCheckpoint.GetSequenceNumber(),
+						Message:  err.Error(),
+					})
 				} else {
 					p.committee = newCommittee
 					p.currentEpoch = newLoadedEpoch // Ensure currentEpoch matches what was loaded
 					p.statsManager.InitializeCommitteeStats(newCommittee)
+					p.recordCommittee()
 
-					if p.plainMode {
+					if p.plainMode && p.records == nil {
 						fmt.Printf("Successfully reloaded committee for epoch %d with %d validators.\n", p.currentEpoch, len(p.committee))
 					} else {
 						log.Printf("Successfully reloaded committee for epoch %d with %d validators.", p.currentEpoch, len(p.committee))
 					}
 				}
+
+				p.publish(events.Event{
+					Kind:          events.KindEpochChange,
+					Epoch:         p.currentEpoch,
+					PreviousEpoch: previousEpoch,
+					Sequence:      receivedCheckpoint.GetSequenceNumber(),
+				})
+				if p.records != nil {
+					p.records.write(record{
+						{"record", recordEpoch},
+						{"time", checkpointTime(receivedCheckpoint).UTC()},
+						{"epoch", p.currentEpoch},
+						{"previous_epoch", previousEpoch},
+						{"sequence", receivedCheckpoint.GetSequenceNumber()},
+						{"committee_size", len(p.committee)},
+					})
+				}
 			}
 
 			p.statsManager.ResetSignedCurrent(p.committee)
@@ -117,106 +244,484 @@ func (p *Processor) Run(ctx context.Context, initialEpoch uint64, initialCommitt
 				}
 			}
 
+			cpTime := checkpointTime(receivedCheckpoint)
+			var missing []events.ValidatorRef
+			var planned map[string]bool
+			var plannedBits history.Bitset
 			for _, valInfo := range p.committee {
+				previous, _, known := p.statsManager.GetStats(valInfo.SuiAddress)
+				ref := events.ValidatorRef{Name: valInfo.Name, Address: valInfo.SuiAddress, VotingPower: valInfo.VotingPower}
 				if IsValidatorSigned(bitmap, valInfo.BitmapIndex) { // IsValidatorSigned is in this package
-					p.statsManager.UpdateValidatorSigned(valInfo.SuiAddress)
+					p.statsManager.UpdateValidatorSigned(valInfo.SuiAddress, receivedCheckpoint.GetSequenceNumber())
+					if known && p.missingAnnounced[valInfo.SuiAddress] {
+						delete(p.missingAnnounced, valInfo.SuiAddress)
+						p.publishValidatorStatus(ref, events.StatusSigning, previous.MissStreak, receivedCheckpoint.GetSequenceNumber())
+					}
 				} else {
 					// If validator was not in stats map (e.g. committee changed mid-checkpoint processing before stats init for new members)
 					// This is less likely with current flow where stats are init/updated after committee load.
-					// The original code had a warning here, but UpdateValidatorSigned handles the non-existence silently by not updating.
+					// UpdateValidatorMissed handles the non-existence silently by not updating.
+					_, ref.Maintenance = p.maintenance.Active(valInfo.SuiAddress, valInfo.Names(), receivedCheckpoint.GetSequenceNumber(), cpTime)
+					if ref.Maintenance {
+						if planned == nil {
+							planned = make(map[string]bool)
+						}
+						planned[valInfo.SuiAddress] = true
+						plannedBits.Set(valInfo.BitmapIndex)
+					}
+					p.statsManager.UpdateValidatorMissed(valInfo.SuiAddress, ref.Maintenance)
+					missing = append(missing, ref)
+					// A streak that starts inside a maintenance window is
+					// announced once the validator misses outside of it
+					if known && !ref.Maintenance && !p.missingAnnounced[valInfo.SuiAddress] {
+						p.missingAnnounced[valInfo.SuiAddress] = true
+						p.publishValidatorStatus(ref, events.StatusMissing, 0, receivedCheckpoint.GetSequenceNumber())
+					}
 				}
 			}
 
 			if p.dataset != nil {
-				p.dataset.RecordCheckpoint(p.currentEpoch, receivedCheckpoint.GetSequenceNumber(), bitmap, p.committee)
+				p.dataset.RecordCheckpoint(p.currentEpoch, receivedCheckpoint.GetSequenceNumber(), bitmap, p.committee, planned)
 				p.reportCount++
 			}
 
-			// If uiChan is provided, send a snapshot to the UI
-			if uiChan != nil {
-				// Convert the internal validator info to the types package format
-				committeeForUI := make([]types.ValidatorInfo, len(p.committee))
-				for i, v := range p.committee {
-					committeeForUI[i] = v.ToTypesInfo()
-				}
+			checkpointInfo := p.checkpointInfo(receivedCheckpoint)
 
-				// Convert the stats map to the types package format
-				statsForUI := make(map[string]types.ValidatorStats)
-				allStats := p.statsManager.GetAllStats()
-				for k, v := range allStats {
-					statsForUI[k] = v.ToTypesStats()
-				}
+			p.publish(events.Event{
+				Kind:     events.KindCheckpoint,
+				Epoch:    checkpointInfo.Epoch,
+				Sequence: checkpointInfo.Sequence,
+				Checkpoint: &events.CheckpointSummary{
+					SignerCount:   int(checkpointInfo.SignaturesCount),
+					CommitteeSize: int(checkpointInfo.ValidatorCount),
+					SignedPower:   checkpointInfo.SignedPower,
+					TotalPower:    checkpointInfo.TotalPower,
+					Missing:       missing,
+				},
+			})
 
-				// Calculate voting power metrics
-				totalPower := 0
-				signedPower := 0
-				for _, v := range p.committee {
-					totalPower += v.VotingPower
-					if p.statsManager.IsSigned(v.SuiAddress) {
-						signedPower += v.VotingPower
-					}
+			if p.history != nil {
+				if err := p.history.Record(history.CheckpointRecord{
+					Sequence:    checkpointInfo.Sequence,
+					Epoch:       checkpointInfo.Epoch,
+					Timestamp:   time.UnixMilli(checkpointInfo.Timestamp),
+					Signers:     history.NewBitset(bitmap),
+					Planned:     plannedBits,
+					SignedPower: checkpointInfo.SignedPower,
+					TotalPower:  checkpointInfo.TotalPower,
+				}); err != nil {
+					log.Printf("Failed to record checkpoint %d in history store: %v", receivedCheckpoint.GetSequenceNumber(), err)
 				}
+			}
 
-				uiChan <- types.SnapshotMsg{
-					Epoch:         p.currentEpoch,
-					CheckpointSeq: receivedCheckpoint.GetSequenceNumber(),
-					TotalWithSig:  p.statsManager.GetTotalCheckpointsWithSig(),
-					SignedPower:   signedPower,
-					TotalPower:    totalPower,
-					Committee:     committeeForUI,
-					Stats:         statsForUI,
+			// Build a snapshot for the UI and any registered snapshot hooks
+			if uiChan != nil || len(p.snapshotHooks) > 0 {
+				snapshot := p.buildSnapshot(checkpointInfo)
+				for _, hook := range p.snapshotHooks {
+					hook(snapshot)
 				}
-			} else {
+				if uiChan != nil {
+					uiChan <- snapshot
+				}
+			}
+
+			if uiChan == nil {
 				if p.dataset != nil {
 					if p.reportCount%10 == 0 {
-						p.printReport(receivedCheckpoint.GetSequenceNumber(), os.Stdout)
-						fmt.Println("[dataset mode] Press 'q' then Enter to stop and save dataset.")
+						p.report(checkpointInfo)
+						if p.records == nil {
+							fmt.Println("[dataset mode] Press 'q' then Enter to stop and save dataset.")
+						}
 					}
-				} else {
-					p.printReport(receivedCheckpoint.GetSequenceNumber(), os.Stdout)
+				} else if p.shouldReport() {
+					p.report(checkpointInfo)
 				}
 			}
 
 		case <-ctx.Done():
 			log.Println("Context done, exiting processor loop.")
-			if p.dataset != nil {
-				p.dataset.Close()
-			}
+			p.close()
 			return
 		}
 	}
 }
 
+// publish publishes an event of the processor and, in changes mode, writes it.
+func (p *Processor) publish(ev events.Event) {
+	if ev.Time.IsZero() {
+		ev.Time = time.Now()
+	}
+	p.events.Publish(ev)
+	if p.emitChanges {
+		p.printChange(ev)
+	}
+}
+
+// publishValidatorStatus announces that a validator started or stopped missing checkpoints.
+func (p *Processor) publishValidatorStatus(ref events.ValidatorRef, status string, missStreak uint64, seq uint64) {
+	p.publish(events.Event{
+		Kind:       events.KindValidatorStatus,
+		Epoch:      p.currentEpoch,
+		Sequence:   seq,
+		Validator:  &ref,
+		Status:     status,
+		MissStreak: missStreak,
+	})
+}
+
+// close flushes the optional dataset and history sinks.
+func (p *Processor) close() {
+	if p.dataset != nil {
+		p.dataset.Close()
+	}
+	if p.history != nil {
+		if err := p.history.Close(); err != nil {
+			log.Printf("Failed to close history store: %v", err)
+		}
+	}
+}
+
+// recordCommittee stores the current committee in the history store so that
+// signer bitsets can be resolved to validators later.
+func (p *Processor) recordCommittee() {
+	if p.history == nil {
+		return
+	}
+	members := make([]history.CommitteeMember, len(p.committee))
+	for _, v := range p.committee {
+		if v.BitmapIndex >= 0 && v.BitmapIndex < len(members) {
+			members[v.BitmapIndex] = history.CommitteeMember{Name: v.Name, Address: v.SuiAddress, VotingPower: v.VotingPower}
+		}
+	}
+	if err := p.history.SetCommittee(p.currentEpoch, members); err != nil {
+		log.Printf("Failed to record committee for epoch %d in history store: %v", p.currentEpoch, err)
+	}
+}
+
+// checkpointInfo summarises a processed checkpoint. It must be called after the
+// stats have been updated for the checkpoint.
+func (p *Processor) checkpointInfo(cp *rpcPb.Checkpoint) types.CheckpointInfo {
+	ts := checkpointTime(cp)
+	signedPower, totalPower := p.votingPower()
+	bitmap := cp.GetSignature().GetBitmap()
+	signers := make([]uint32, len(bitmap))
+	copy(signers, bitmap)
+	return types.CheckpointInfo{
+		Sequence:        cp.GetSequenceNumber(),
+		Epoch:           p.currentEpoch,
+		Timestamp:       ts.UnixMilli(),
+		SignaturesCount: uint64(len(bitmap)),
+		ValidatorCount:  uint64(len(p.committee)),
+		SignedPower:     signedPower,
+		TotalPower:      totalPower,
+		Signers:         signers,
+	}
+}
+
+// checkpointTime returns the checkpoint's timestamp, falling back to the
+// processing time if the node did not send one.
+func checkpointTime(cp *rpcPb.Checkpoint) time.Time {
+	if pbTs := cp.GetSummary().GetTimestamp(); pbTs != nil {
+		return pbTs.AsTime()
+	}
+	return time.Now()
+}
+
+// buildSnapshot captures the current committee and stats in the types package format.
+func (p *Processor) buildSnapshot(cp types.CheckpointInfo) types.SnapshotMsg {
+	// Convert the internal validator info to the types package format
+	committee := make([]types.ValidatorInfo, len(p.committee))
+	for i, v := range p.committee {
+		committee[i] = v.ToTypesInfo()
+	}
+
+	stats, totalWithSig := p.statsManager.Snapshot()
+
+	return types.SnapshotMsg{
+		Epoch:         p.currentEpoch,
+		CheckpointSeq: cp.Sequence,
+		TotalWithSig:  totalWithSig,
+		SignedPower:   cp.SignedPower,
+		TotalPower:    cp.TotalPower,
+		Committee:     committee,
+		Stats:         stats,
+		Checkpoint:    cp,
+	}
+}
+
+// votingPower returns the voting power that signed the current checkpoint and the committee total.
+func (p *Processor) votingPower() (signedPower, totalPower int) {
+	for _, v := range p.committee {
+		totalPower += v.VotingPower
+		if p.statsManager.IsSigned(v.SuiAddress) {
+			signedPower += v.VotingPower
+		}
+	}
+	return signedPower, totalPower
+}
+
+// shouldReport applies the emit mode to a processed checkpoint. The first
+// checkpoint is always reported, as the baseline for changes and summaries.
+func (p *Processor) shouldReport() bool {
+	p.sinceReport++
+	emit := p.cfg.Emit
+	if !p.lastReport.IsZero() {
+		switch {
+		case emit.Mode == config.EmitChanges:
+			return false
+		case emit.Mode == config.EmitEvery && emit.Checkpoints > 0:
+			if p.sinceReport < emit.Checkpoints {
+				return false
+			}
+		case emit.Mode == config.EmitEvery:
+			if time.Since(p.lastReport) < emit.Interval {
+				return false
+			}
+		}
+	}
+	p.lastReport = time.Now()
+	p.sinceReport = 0
+	return true
+}
+
+// printChange writes an event in changes mode. Epoch changes are
+// written by the processor itself, and checkpoints are not changes.
+func (p *Processor) printChange(ev events.Event) {
+	switch ev.Kind {
+	case events.KindValidatorStatus, events.KindSourceHealth, events.KindCommitteeReloadFailed, events.KindChainReset:
+	default:
+		return
+	}
+	if p.records == nil {
+		fmt.Printf("%s %s\n", ev.Time.Local().Format("2006-01-02 15:04:05"), ev.Summary())
+		return
+	}
+
+	r := record{{"record", recordEvent}, {"time", ev.Time.UTC()}}
+	if ev.Epoch > 0 {
+		r = append(r, field{"epoch", ev.Epoch})
+	}
+	if ev.Sequence > 0 {
+		r = append(r, field{"sequence", ev.Sequence})
+	}
+	if ev.Validator != nil {
+		r = append(r, field{"name", ev.Validator.Name}, field{"address", ev.Validator.Address})
+	}
+	if ev.Status != "" {
+		r = append(r, field{"status", ev.Status})
+	}
+	if ev.MissStreak > 0 {
+		r = append(r, field{"miss_streak", ev.MissStreak})
+	}
+	r = append(r, field{"kind", string(ev.Kind)})
+	if ev.Message != "" {
+		r = append(r, field{"message", ev.Message})
+	}
+	p.records.write(r)
+}
+
+// report writes the plain-mode output for a processed checkpoint: the text
+// report or a checkpoint record followed by one record per validator.
+func (p *Processor) report(cp types.CheckpointInfo) {
+	if p.records == nil {
+		p.printReport(cp.Sequence, os.Stdout)
+		return
+	}
+
+	totalCheckpointsWithSig := p.statsManager.GetTotalCheckpointsWithSig()
+	cpTime := time.UnixMilli(cp.Timestamp).UTC()
+	p.records.write(record{
+		{"record", recordCheckpoint},
+		{"time", cpTime},
+		{"epoch", cp.Epoch},
+		{"sequence", cp.Sequence},
+		{"signers", cp.SignaturesCount},
+		{"committee_size", cp.ValidatorCount},
+		{"signed_power", cp.SignedPower},
+		{"total_power", cp.TotalPower},
+		{"signed_pct", pct(float64(cp.SignedPower), float64(cp.TotalPower))},
+		{"total_checkpoints", totalCheckpointsWithSig},
+	})
+
+	for _, valInfo := range p.sortedCommittee() {
+		stats, _, ok := p.statsManager.GetStats(valInfo.SuiAddress)
+		if !ok || !p.watch.Shown(valInfo.ToTypesInfo()) {
+			continue
+		}
+		status := statusMissed
+		if stats.SignedCurrent {
+			status = statusSigned
+		} else if stats.InMaintenance {
+			status = statusMaintenance
+		}
+		typesStats := stats.ToTypesStats()
+		r := record{
+			{"record", recordValidator},
+			{"time", cpTime},
+			{"epoch", cp.Epoch},
+			{"sequence", cp.Sequence},
+			{"total_checkpoints", totalCheckpointsWithSig},
+			{"name", valInfo.Name},
+			{"address", valInfo.SuiAddress},
+			{"voting_power", valInfo.VotingPower},
+			{"status", status},
+			{"attested", stats.AttestedCount},
+		}
+		// Without unplanned checkpoints there is no uptime to report
+		if typesStats.HasUptime(totalCheckpointsWithSig) {
+			r = append(r, field{"uptime_pct", math.Round(typesStats.Uptime(totalCheckpointsWithSig)*10000) / 100})
+		}
+		r = append(r, record{
+			{"miss_streak", stats.MissStreak},
+			{"planned_misses", stats.PlannedMisses},
+			{"unplanned_misses", typesStats.UnplannedMisses(totalCheckpointsWithSig)},
+			{"watched", p.watch.Watched(valInfo.ToTypesInfo())},
+		}...)
+		if valInfo.Group != "" {
+			r = append(r, field{"group", valInfo.Group})
+		}
+		if len(valInfo.Tags) > 0 {
+			r = append(r, field{"tags", valInfo.Tags})
+		}
+		p.records.write(r)
+	}
+
+	for _, g := range p.groups() {
+		r := record{
+			{"record", recordGroup},
+			{"time", cpTime},
+			{"epoch", cp.Epoch},
+			{"sequence", cp.Sequence},
+			{"signers", g.Signing},
+			{"total_checkpoints", totalCheckpointsWithSig},
+			{"voting_power", g.VotingPower},
+		}
+		if g.HasUptime {
+			r = append(r, field{"uptime_pct", math.Round(g.Uptime*10000) / 100})
+		}
+		p.records.write(append(r, record{
+			{"group", g.Name},
+			{"members", g.Members},
+			{"missing", append([]string{}, g.Missing...)},
+		}...))
+	}
+}
+
+// groups aggregates the stats of the committee by operator group.
+func (p *Processor) groups() []labels.Group {
+	committee := make([]types.ValidatorInfo, len(p.committee))
+	for i, v := range p.committee {
+		committee[i] = v.ToTypesInfo()
+	}
+	stats, totalWithSig := p.statsManager.Snapshot()
+	return labels.Groups(committee, stats, totalWithSig)
+}
+
+// groupLine returns the plain-mode summary line of an operator group.
+func groupLine(g labels.Group, totalPower int) string {
+	uptime := "N/A"
+	if g.HasUptime {
+		uptime = fmt.Sprintf("%.2f%%", g.Uptime*100)
+	}
+	line := fmt.Sprintf("Group %s: %d/%d signing, %.2f%% of voting power, uptime %s",
+		g.Name, g.Signing, g.Members, pct(float64(g.VotingPower), float64(totalPower)), uptime)
+	if len(g.Missing) > 0 {
+		line += ", missing: " + strings.Join(g.Missing, ", ")
+	}
+	return line
+}
+
+// formatUptime returns the validator's uptime as a percentage, or N/A if every
+// checkpoint was missed during planned maintenance.
+func formatUptime(stats types.ValidatorStats, totalWithSig uint64) string {
+	if !stats.HasUptime(totalWithSig) {
+		return "N/A"
+	}
+	return fmt.Sprintf("%.2f%%", stats.Uptime(totalWithSig)*100)
+}
+
+// sortedCommittee returns the committee sorted by name, the order of reports.
+func (p *Processor) sortedCommittee() []val.ValidatorInfo {
+	sorted := make([]val.ValidatorInfo, len(p.committee))
+	copy(sorted, p.committee)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
+
+// watchSummary returns the plain-mode summary line of the watched validators.
+func (p *Processor) watchSummary(totalCheckpointsWithSig uint64) string {
+	var parts []string
+	signing, watched := 0, 0
+	for _, valInfo := range p.sortedCommittee() {
+		if !p.watch.Watched(valInfo.ToTypesInfo()) {
+			continue
+		}
+		stats, _, ok := p.statsManager.GetStats(valInfo.SuiAddress)
+		if !ok {
+			continue
+		}
+		watched++
+		icon := "❌"
+		if stats.SignedCurrent {
+			icon = "✅"
+			signing++
+		} else if stats.InMaintenance {
+			icon = "🔧"
+		}
+		part := fmt.Sprintf("%s %s %s", icon, valInfo.Name, formatUptime(stats.ToTypesStats(), totalCheckpointsWithSig))
+		if !stats.SignedCurrent && !stats.InMaintenance {
+			part += fmt.Sprintf(" (missed the last %d)", stats.MissStreak)
+		}
+		parts = append(parts, part)
+	}
+	if watched == 0 {
+		return "Watched: no watched validator is in the committee"
+	}
+	return fmt.Sprintf("Watched: %d/%d signing: %s", signing, watched, strings.Join(parts, " | "))
+}
+
 // printReport outputs a formatted report of the current validator status to the provided writer
 func (p *Processor) printReport(checkpointSeqNum uint64, w io.Writer) {
 	totalCheckpointsWithSig := p.statsManager.GetTotalCheckpointsWithSig()
 	fmt.Fprintf(w, "\n--- Checkpoint #%d (Epoch: %d, Total w/Sig: %d) ---\n",
 		checkpointSeqNum, p.currentEpoch, totalCheckpointsWithSig)
 
-	// Calculate voting power metrics
-	totalPower := 0
-	signedPower := 0
-	for _, v := range p.committee {
-		totalPower += v.VotingPower
-		if p.statsManager.IsSigned(v.SuiAddress) {
-			signedPower += v.VotingPower
+	if h := p.node.Health(); !h.PolledAt.IsZero() {
+		fmt.Fprintf(w, "Node: %s, chain %s, height %d, lag %s, lowest available %d\n",
+			h.ServerVersion, h.Chain, h.CheckpointHeight, h.Lag.Truncate(100*time.Millisecond), h.LowestAvailableCheckpoint)
+		for _, warning := range h.Warnings {
+			fmt.Fprintf(w, "⚠ Node: %s\n", warning)
 		}
 	}
 
+	// Calculate voting power metrics
+	signedPower, totalPower := p.votingPower()
+
 	// Print voting power stats if available
 	if totalPower > 0 {
 		pct := float64(signedPower) / float64(totalPower) * 100
 		fmt.Fprintf(w, "Voting power signed: %.2f%% (%d/%d)\n", pct, signedPower, totalPower)
 	}
 
-	displayCommittee := make([]val.ValidatorInfo, len(p.committee))
-	copy(displayCommittee, p.committee)
-	sort.Slice(displayCommittee, func(i, j int) bool {
-		return displayCommittee[i].Name < displayCommittee[j].Name
-	})
+	if p.watch.Len() > 0 {
+		fmt.Fprintln(w, p.watchSummary(totalCheckpointsWithSig))
+	}
+	for _, g := range p.groups() {
+		fmt.Fprintln(w, groupLine(g, totalPower))
+	}
+
+	displayCommittee := p.sortedCommittee()
+
+	// Planned and unplanned downtime are shown once maintenance windows are in use
+	showDowntime := len(p.maintenance.Windows()) > 0
 
 	var linesToPrint []string
 	for _, valInfo := range displayCommittee {
+		if !p.watch.Shown(valInfo.ToTypesInfo()) {
+			continue
+		}
 		stats, _, ok := p.statsManager.GetStats(valInfo.SuiAddress)
 		if !ok {
 			log.Printf("Warning: Validator %s (SuiAddress: %s) in committee but missing from stats for reporting.", valInfo.Name, valInfo.SuiAddress)
@@ -226,14 +731,17 @@ func (p *Processor) printReport(checkpointSeqNum uint64, w io.Writer) {
 		statusIcon := "❌"
 		if stats.SignedCurrent {
 			statusIcon = "✅"
+		} else if stats.InMaintenance {
+			statusIcon = "🔧"
 		}
 
-		uptimePercent := 0.0
-		if totalCheckpointsWithSig > 0 {
-			uptimePercent = (float64(stats.AttestedCount) / float64(totalCheckpointsWithSig)) * 100
+		typesStats := stats.ToTypesStats()
+		line := fmt.Sprintf("%s %-40s - Attested: %7s (%4d/%4d)",
+			statusIcon, valInfo.Name, formatUptime(typesStats, totalCheckpointsWithSig), stats.AttestedCount, totalCheckpointsWithSig)
+		if showDowntime {
+			line += fmt.Sprintf(" Down P/U: %d/%d", stats.PlannedMisses, typesStats.UnplannedMisses(totalCheckpointsWithSig))
 		}
-		linesToPrint = append(linesToPrint, fmt.Sprintf("%s %-40s - Attested: %6.2f%% (%4d/%4d)",
-			statusIcon, valInfo.Name, uptimePercent, stats.AttestedCount, totalCheckpointsWithSig))
+		linesToPrint = append(linesToPrint, line)
 	}
 
 	for j := 0; j < len(linesToPrint); j += 2 {
@@ -0,0 +1,78 @@
+package checkpoint
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	"suitop/internal/config"
+	"suitop/internal/events"
+	rpcPb "suitop/pb/sui/rpc/v2beta"
+)
+
+func TestChangesModeWritesEveryOwnEvent(t *testing.T) {
+	cfg := config.ProcessorConfig{Output: config.OutputLogfmt, Emit: config.Emit{Mode: config.EmitChanges}}
+	p := NewProcessor(nil, NewStatsManager(), cfg, true, nil, nil)
+	var buf bytes.Buffer
+	p.records = newRecordWriter(cfg.Output, &buf)
+	p.emitChanges = true
+	bus := events.NewBus(0)
+	p.SetEventBus(bus)
+	// A subscriber that never reads must not cost the processor any output.
+	// The bus logs a warning for each event it drops.
+	_, cancel := bus.Subscribe(1)
+	defer cancel()
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	const flips = 2000
+	for i := 0; i < flips; i++ {
+		p.publishValidatorStatus(events.ValidatorRef{Name: "alpha", Address: "0xa"}, events.StatusMissing, 0, uint64(i+1))
+	}
+	p.publish(events.Event{Kind: events.KindCheckpoint, Sequence: flips})
+	p.publish(events.Event{Kind: events.KindCommitteeReloadFailed, Epoch: 2, Message: "timeout"})
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != flips+1 {
+		t.Fatalf("wrote %d lines, want %d status changes and the reload failure", len(lines), flips)
+	}
+	if !strings.Contains(lines[0], "sequence=1 name=alpha address=0xa status=missing kind=validator_status") {
+		t.Errorf("first line = %s", lines[0])
+	}
+	if !strings.Contains(lines[flips], "kind=committee_reload_failed message=timeout") {
+		t.Errorf("last line = %s", lines[flips])
+	}
+}
+
+func TestEpochRegressionResetsChain(t *testing.T) {
+	p := NewProcessor(nil, NewStatsManager(), config.ProcessorConfig{}, false, nil, nil)
+	var reasons []string
+	p.OnChainReset(func(reason string) { reasons = append(reasons, reason) })
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	stream := make(chan *rpcPb.Checkpoint, 3)
+	for seq, epoch := range []uint64{4, 3, 4} {
+		stream <- &rpcPb.Checkpoint{
+			SequenceNumber: proto.Uint64(uint64(seq + 1)),
+			Signature:      &rpcPb.ValidatorAggregatedSignature{Epoch: proto.Uint64(epoch)},
+		}
+	}
+	close(stream)
+	p.Run(context.Background(), 5, nil, stream, nil)
+
+	want := []string{"checkpoint 1 is from epoch 4, before current epoch 5"}
+	if !reflect.DeepEqual(reasons, want) {
+		t.Errorf("chain reset reasons = %q, want %q", reasons, want)
+	}
+	if total := p.statsManager.GetTotalCheckpointsWithSig(); total != 0 {
+		t.Errorf("counted %d checkpoints of an earlier epoch", total)
+	}
+}
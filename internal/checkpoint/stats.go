@@ -1,6 +1,8 @@
 package checkpoint
 
 import (
+	"sync"
+
 	"suitop/internal/types"
 	valmodel "suitop/internal/validator"
 )
@@ -9,7 +11,13 @@ import (
 // This is a copy of types.ValidatorStats for internal usage.
 type ValidatorStats struct {
 	AttestedCount uint64
-	SignedCurrent bool // Did they sign the most recently processed checkpoint?
+	SignedCurrent bool   // Did they sign the most recently processed checkpoint?
+	MissStreak    uint64 // Consecutive checkpoints missed up to the most recent one
+	LastSignedSeq uint64 // Sequence number of the last checkpoint they signed (0 if none)
+	PlannedMisses uint64 // Checkpoints missed inside a maintenance window
+	InMaintenance bool   // Did they miss the most recent checkpoint inside a maintenance window?
+
+	LongestMissStreak uint64 // Longest run of consecutive misses since suitop started
 }
 
 // ToTypesStats converts a ValidatorStats to types.ValidatorStats
@@ -17,6 +25,12 @@ func (v ValidatorStats) ToTypesStats() types.ValidatorStats {
 	return types.ValidatorStats{
 		AttestedCount: v.AttestedCount,
 		SignedCurrent: v.SignedCurrent,
+		MissStreak:    v.MissStreak,
+		LastSignedSeq: v.LastSignedSeq,
+		PlannedMisses: v.PlannedMisses,
+		InMaintenance: v.InMaintenance,
+
+		LongestMissStreak: v.LongestMissStreak,
 	}
 }
 
@@ -25,11 +39,20 @@ func FromTypesStats(v types.ValidatorStats) ValidatorStats {
 	return ValidatorStats{
 		AttestedCount: v.AttestedCount,
 		SignedCurrent: v.SignedCurrent,
+		MissStreak:    v.MissStreak,
+		LastSignedSeq: v.LastSignedSeq,
+		PlannedMisses: v.PlannedMisses,
+		InMaintenance: v.InMaintenance,
+
+		LongestMissStreak: v.LongestMissStreak,
 	}
 }
 
 // StatsManager manages the statistics for all validators.
+// It is safe for concurrent use so that exporters can take snapshots
+// while the processor is updating it.
 type StatsManager struct {
+	mu                      sync.RWMutex
 	validatorStats          map[string]ValidatorStats // Keyed by validator SuiAddress
 	totalCheckpointsWithSig uint64
 }
@@ -44,6 +67,8 @@ func NewStatsManager() *StatsManager {
 // InitializeCommitteeStats sets up initial stats for a new committee.
 // It preserves stats for validators already known.
 func (sm *StatsManager) InitializeCommitteeStats(committee []valmodel.ValidatorInfo) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
 	for _, valInfo := range committee {
 		if _, exists := sm.validatorStats[valInfo.SuiAddress]; !exists {
 			sm.validatorStats[valInfo.SuiAddress] = ValidatorStats{AttestedCount: 0, SignedCurrent: false}
@@ -53,48 +78,93 @@ func (sm *StatsManager) InitializeCommitteeStats(committee []valmodel.ValidatorI
 
 // ResetSignedCurrent resets the SignedCurrent flag for all validators in the provided committee.
 func (sm *StatsManager) ResetSignedCurrent(committee []valmodel.ValidatorInfo) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
 	for _, valInfo := range committee {
 		if stats, ok := sm.validatorStats[valInfo.SuiAddress]; ok {
 			stats.SignedCurrent = false
+			stats.InMaintenance = false
 			sm.validatorStats[valInfo.SuiAddress] = stats
 		}
 	}
 }
 
 // UpdateValidatorSigned updates stats for a validator who signed the current checkpoint.
-func (sm *StatsManager) UpdateValidatorSigned(suiAddress string) {
+func (sm *StatsManager) UpdateValidatorSigned(suiAddress string, seq uint64) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
 	if stats, ok := sm.validatorStats[suiAddress]; ok {
 		stats.SignedCurrent = true
 		stats.AttestedCount++
+		stats.MissStreak = 0
+		stats.LastSignedSeq = seq
+		sm.validatorStats[suiAddress] = stats
+	}
+}
+
+// UpdateValidatorMissed updates stats for a validator who did not sign the current checkpoint.
+// Misses inside a maintenance window are counted as planned downtime.
+func (sm *StatsManager) UpdateValidatorMissed(suiAddress string, planned bool) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	if stats, ok := sm.validatorStats[suiAddress]; ok {
+		stats.MissStreak++
+		if stats.MissStreak > stats.LongestMissStreak {
+			stats.LongestMissStreak = stats.MissStreak
+		}
+		if planned {
+			stats.PlannedMisses++
+			stats.InMaintenance = true
+		}
 		sm.validatorStats[suiAddress] = stats
 	}
 }
 
 // IncrementTotalCheckpointsWithSig increments the total count of checkpoints processed that had signatures.
 func (sm *StatsManager) IncrementTotalCheckpointsWithSig() {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
 	sm.totalCheckpointsWithSig++
 }
 
 // GetStats returns the stats for a specific validator and the total processed checkpoints with signatures.
 func (sm *StatsManager) GetStats(suiAddress string) (ValidatorStats, uint64, bool) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
 	stats, ok := sm.validatorStats[suiAddress]
 	return stats, sm.totalCheckpointsWithSig, ok
 }
 
 // GetAllStats returns the entire map of validator stats.
-// Useful for reporting, but be mindful of concurrent access if the map is modified elsewhere.
+// The returned map is shared with the manager; use Snapshot when the
+// result is handed to another goroutine.
 func (sm *StatsManager) GetAllStats() map[string]ValidatorStats {
-	// Consider returning a copy if concurrent modification is a concern
 	return sm.validatorStats
 }
 
+// Snapshot returns a copy of all validator stats in the types package format
+// together with the total number of checkpoints processed with signatures.
+func (sm *StatsManager) Snapshot() (map[string]types.ValidatorStats, uint64) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	out := make(map[string]types.ValidatorStats, len(sm.validatorStats))
+	for k, v := range sm.validatorStats {
+		out[k] = v.ToTypesStats()
+	}
+	return out, sm.totalCheckpointsWithSig
+}
+
 // GetTotalCheckpointsWithSig returns the total number of checkpoints processed with signatures.
 func (sm *StatsManager) GetTotalCheckpointsWithSig() uint64 {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
 	return sm.totalCheckpointsWithSig
 }
 
 // IsSigned checks if a validator has signed the current checkpoint.
 func (sm *StatsManager) IsSigned(suiAddress string) bool {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
 	stats, ok := sm.validatorStats[suiAddress]
 	return ok && stats.SignedCurrent
 }
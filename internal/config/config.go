@@ -1,42 +1,121 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 )
 
 // Config holds all configuration for the application.
+// The yaml tags define the layout of a profile in the config file.
 type Config struct {
-	SuiNode              string
-	JSONRPCURL           string
-	DefaultRPCTimeout    time.Duration
-	GRPC                 GRPCConfig
-	GRPCSubscriberConfig GRPCSubscriberConfig // Renamed for clarity
-	ProcessorConfig      ProcessorConfig      // Placeholder for processor specific configs
-	RPCClientConfig      RPCClientConfig      // For RPC client settings passed to validator.Loader
-	UIConfig             UIConfig             // For UI related settings
-	LogConfig            LogConfig            // For logging configuration
-	DatasetConfig        DatasetConfig        // For dataset generation
+	Network              string               `yaml:"network"` // Selects the default endpoints
+	SuiNode              string               `yaml:"sui_node"`
+	JSONRPCURL           string               `yaml:"json_rpc_url"`
+	DefaultRPCTimeout    time.Duration        `yaml:"rpc_timeout"`
+	GRPC                 GRPCConfig           `yaml:"grpc"`
+	GRPCSubscriberConfig GRPCSubscriberConfig `yaml:"subscriber"`  // Renamed for clarity
+	ProcessorConfig      ProcessorConfig      `yaml:"-"`           // Placeholder for processor specific configs
+	RPCClientConfig      RPCClientConfig      `yaml:"-"`           // For RPC client settings passed to validator.Loader; derived by Finalize
+	UIConfig             UIConfig             `yaml:"ui"`          // For UI related settings
+	LogConfig            LogConfig            `yaml:"log"`         // For logging configuration
+	DatasetConfig        DatasetConfig        `yaml:"dataset"`     // For dataset generation
+	MetricsConfig        MetricsConfig        `yaml:"metrics"`     // For the Prometheus metrics endpoint
+	APIConfig            APIConfig            `yaml:"api"`         // For the read-only HTTP/JSON API
+	AlertConfig          AlertConfig          `yaml:"alerts"`      // For the alert rule engine
+	NotifyConfig         NotifyConfig         `yaml:"notify"`      // For event notification sinks
+	MaintenanceConfig    MaintenanceConfig    `yaml:"maintenance"` // For planned maintenance windows
+	HistoryConfig        HistoryConfig        `yaml:"history"`     // For the per-checkpoint time-series store
+	ChainConfig          ChainConfig          `yaml:"chain"`       // For chain identity checks
+	NodeConfig           NodeConfig           `yaml:"node"`        // For polling the node's health
+	WatchConfig          WatchConfig          `yaml:"watch"`       // For the validators the operator follows
+	LabelsConfig         LabelsConfig         `yaml:"labels"`      // For local validator names, groups and tags
+
+	Networks        map[string]Network `yaml:"-"` // Custom networks from the config file
+	ExpectedChainID string             `yaml:"-"` // Chain identifier of the selected network, if known; set by Finalize
+
+	File    string `yaml:"-"` // Config file the settings were loaded from, if any
+	Profile string `yaml:"-"` // Profile of File that was applied
 }
 
 // GRPCConfig holds gRPC specific settings.
 type GRPCConfig struct {
-	UseTLS             bool
-	InsecureSkipVerify bool
+	UseTLS             bool `yaml:"use_tls"` // When false, connect over plaintext
+	InsecureSkipVerify bool `yaml:"insecure_skip_verify"`
 	// Other gRPC dial options can be added here
 }
 
 // GRPCSubscriberConfig holds settings for the checkpoint subscriber.
 type GRPCSubscriberConfig struct {
-	RetryDelay time.Duration
+	RetryDelay   time.Duration `yaml:"retry_delay"`
+	StallTimeout time.Duration `yaml:"stall_timeout"` // Resubscribe if no checkpoint arrives within this duration
 	// MaxRetries int // Example: could add max retries or backoff strategy config
 }
 
 // ProcessorConfig can hold settings for the checkpoint processor if needed.
 type ProcessorConfig struct {
-	// Example: BatchSize int, etc.
+	Output OutputFormat // Plain-mode output format; derived from UIConfig by Finalize
+	Emit   Emit         // When plain mode writes reports; parsed from UIConfig by Finalize
+}
+
+// OutputFormat selects how plain mode writes its reports.
+type OutputFormat string
+
+const (
+	OutputText   OutputFormat = "text"   // Human-readable report per checkpoint
+	OutputJSON   OutputFormat = "json"   // One JSON object per line (NDJSON)
+	OutputCSV    OutputFormat = "csv"    // CSV with a header line and a fixed set of columns
+	OutputLogfmt OutputFormat = "logfmt" // One key=value line per record
+)
+
+// EmitMode selects when plain mode writes reports.
+type EmitMode string
+
+const (
+	EmitAll     EmitMode = "all"     // A report for every checkpoint
+	EmitChanges EmitMode = "changes" // Validator status flips, epoch changes and source events only
+	EmitEvery   EmitMode = "every"   // A report every Interval or every Checkpoints checkpoints
+)
+
+// Emit is a parsed --emit setting: "all", "changes", "every=<duration>" or
+// "every=<N>" checkpoints.
+type Emit struct {
+	Mode        EmitMode
+	Interval    time.Duration
+	Checkpoints uint64
+}
+
+// ParseEmit parses an --emit setting. An empty setting means "all".
+func ParseEmit(s string) (Emit, error) {
+	switch s {
+	case "", string(EmitAll):
+		return Emit{Mode: EmitAll}, nil
+	case string(EmitChanges):
+		return Emit{Mode: EmitChanges}, nil
+	}
+	v, ok := strings.CutPrefix(s, string(EmitEvery)+"=")
+	if !ok {
+		return Emit{}, fmt.Errorf("invalid emit mode %q: must be all, changes, every=<duration> or every=<checkpoints>", s)
+	}
+	if n, err := strconv.ParseUint(v, 10, 64); err == nil {
+		if n == 0 {
+			return Emit{}, fmt.Errorf("invalid emit mode %q: the checkpoint count must be positive", s)
+		}
+		return Emit{Mode: EmitEvery, Checkpoints: n}, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		return Emit{}, fmt.Errorf("invalid emit mode %q: %q is neither a positive duration nor a checkpoint count", s, v)
+	}
+	return Emit{Mode: EmitEvery, Interval: d}, nil
+}
+
+// OutputFormats lists the valid plain-mode output formats.
+func OutputFormats() []string {
+	return []string{string(OutputText), string(OutputJSON), string(OutputCSV), string(OutputLogfmt)}
 }
 
 // RPCClientConfig holds settings for the JSON-RPC client.
@@ -47,126 +126,326 @@ type RPCClientConfig struct {
 
 // UIConfig contains settings for the user interface
 type UIConfig struct {
-	PlainMode   bool // When true, use command-line output instead of TUI
-	NoAltScreen bool // When true, run inside current terminal buffer (useful for tmux logs)
+	PlainMode   bool         `yaml:"plain"`         // When true, use command-line output instead of TUI
+	NoAltScreen bool         `yaml:"no_alt_screen"` // When true, run inside current terminal buffer (useful for tmux logs)
+	Output      OutputFormat `yaml:"output"`        // Plain-mode output format; anything but text implies plain mode
+	Emit        string       `yaml:"emit"`          // When plain mode writes reports: all, changes, every=<duration> or every=<checkpoints>
 }
 
 // LogConfig holds settings for logging
 type LogConfig struct {
-	ToStderr  bool   // Whether to log to stderr
-	ToFile    bool   // Whether to log to a file
-	FilePath  string // Path to log file
-	WithTime  bool   // Include timestamps in logs
-	WithLevel bool   // Include log levels in messages
+	ToStderr  bool   `yaml:"-"`         // Whether to log to stderr
+	ToFile    bool   `yaml:"to_file"`   // Whether to log to a file
+	FilePath  string `yaml:"file_path"` // Path to log file
+	WithTime  bool   `yaml:"-"`         // Include timestamps in logs
+	WithLevel bool   `yaml:"-"`         // Include log levels in messages
 }
 
 type DatasetConfig struct {
-	Generate bool
-	Folder   string
+	Generate bool   `yaml:"generate"`
+	Folder   string `yaml:"folder"`
 }
 
-// Load populates Config from environment variables or defaults.
-func Load() *Config {
-	suiNode := os.Getenv("SUI_NODE")
-	jsonRPCURL := os.Getenv("SUI_JSON_RPC_URL")
+// HistoryConfig holds settings for the embedded checkpoint history store.
+type HistoryConfig struct {
+	Enabled         bool          `yaml:"enabled"`
+	Folder          string        `yaml:"folder"`           // Defaults to <dataset folder>/history
+	RawRetention    time.Duration `yaml:"raw_retention"`    // How long raw per-checkpoint records are kept
+	MinuteRetention time.Duration `yaml:"minute_retention"` // How long per-minute rollups are kept (per-epoch rollups are kept forever)
+}
 
-	defaultRPCTimeoutStr := os.Getenv("DEFAULT_RPC_TIMEOUT_SECONDS")
-	defaultRPCTimeoutSeconds, err := strconv.Atoi(defaultRPCTimeoutStr)
-	if err != nil || defaultRPCTimeoutSeconds <= 0 {
-		defaultRPCTimeoutSeconds = 15 // Default to 15 seconds
-	}
+// MetricsConfig holds settings for the Prometheus metrics endpoint.
+type MetricsConfig struct {
+	ListenAddr string `yaml:"listen"` // Address to serve /metrics on; empty disables the endpoint
+}
 
-	// Example for GRPC specific config from env (can be expanded)
-	grpcUseTLSStr := os.Getenv("GRPC_USE_TLS")
-	grpcUseTLS := true // Default to true
-	if grpcUseTLSStr == "false" {
-		grpcUseTLS = false
-	}
+// APIConfig holds settings for the read-only HTTP/JSON API.
+type APIConfig struct {
+	ListenAddr        string `yaml:"listen"`              // Address to serve the API on; empty disables the API
+	Token             string `yaml:"token" secret:"true"` // Optional bearer token required on /api/ routes
+	RecentCheckpoints int    `yaml:"recent_checkpoints"`  // Number of recent checkpoints kept for the checkpoints endpoint
+}
 
-	grpcInsecureSkipVerifyStr := os.Getenv("GRPC_INSECURE_SKIP_VERIFY")
-	grpcInsecureSkipVerify := true // Default to true for easier local dev against testnets, review for prod
-	if grpcInsecureSkipVerifyStr == "false" {
-		grpcInsecureSkipVerify = false
-	}
+// AlertConfig holds settings for the alert rule engine.
+type AlertConfig struct {
+	RulesFile string `yaml:"rules_file"` // Path to a YAML rules file; empty uses the built-in rules if Alertmanager is configured
 
-	subscriberRetryDelayStr := os.Getenv("SUBSCRIBER_RETRY_DELAY_MS")
-	subscriberRetryDelayMs, err := strconv.Atoi(subscriberRetryDelayStr)
-	if err != nil || subscriberRetryDelayMs <= 0 {
-		subscriberRetryDelayMs = 1000 // Default to 1 second
-	}
+	AlertmanagerURLs           []string      `yaml:"alertmanager_urls"`            // Alertmanager base URLs to push alerts to; empty disables pushing
+	AlertmanagerResendInterval time.Duration `yaml:"alertmanager_resend_interval"` // How often firing alerts are re-sent to Alertmanager
+	MissStreakThreshold        int           `yaml:"miss_streak"`                  // Consecutive misses before the built-in validator alert fires
+	StallThreshold             time.Duration `yaml:"stall_threshold"`              // Time without checkpoints before the built-in subscription alert fires
+}
 
-	// UI Config settings
-	plainModeStr := os.Getenv("PLAIN_MODE")
-	plainMode := false // Default to TUI mode
-	if plainModeStr == "true" {
-		plainMode = true
-	}
+// NotifyConfig holds settings for event notification sinks.
+type NotifyConfig struct {
+	ConfigFile string `yaml:"config_file"` // Path to a YAML notifications file; empty disables notifications
+}
 
-	noAltScreenStr := os.Getenv("NO_ALT_SCREEN")
-	noAltScreen := false // Default to using alt screen
-	if noAltScreenStr == "true" {
-		noAltScreen = true
-	}
+// MaintenanceConfig holds settings for planned maintenance windows.
+type MaintenanceConfig struct {
+	File string `yaml:"file"` // YAML file holding the maintenance windows; reloaded when it changes
+}
 
-	// Log Config settings
-	logToFileStr := os.Getenv("LOG_TO_FILE")
-	logToFile := false
-	if logToFileStr == "true" {
-		logToFile = true
-	}
+// ChainConfig holds settings for checking which chain the node serves.
+type ChainConfig struct {
+	CheckInterval time.Duration `yaml:"check_interval"` // How often the chain identifier is re-checked to detect resets
+}
 
-	logFilePath := os.Getenv("LOG_FILE_PATH")
-	if logFilePath == "" {
-		// Default log file in user's home directory
-		home, err := os.UserHomeDir()
-		if err == nil {
-			logFilePath = filepath.Join(home, ".suitop", "logs", "suitop.log")
-		} else {
-			// Fallback to current directory if home not found
-			logFilePath = "suitop.log"
-		}
-	}
+// NodeConfig holds settings for the node health poll.
+type NodeConfig struct {
+	PollInterval time.Duration `yaml:"poll_interval"` // How often the node's service info is polled
+	MaxLag       time.Duration `yaml:"max_lag"`       // Node lag behind the wall clock before a warning is shown
+}
 
-	generateDatasetStr := os.Getenv("GENERATE_DATASET")
-	generateDataset := false
-	if generateDatasetStr == "true" {
-		generateDataset = true
-	}
-	datasetFolder := os.Getenv("DATASET_FOLDER")
-	if datasetFolder == "" {
-		datasetFolder = "./data"
+// WatchConfig selects the validators pinned in the TUI and summarised in plain mode.
+type WatchConfig struct {
+	Validators []string `yaml:"validators"` // Names, addresses or protocol pubkeys
+	File       string   `yaml:"file"`       // YAML file with more validators; empty for none
+	Only       bool     `yaml:"only"`       // Show only the watched validators
+}
+
+// LabelsConfig holds settings for local validator labels.
+type LabelsConfig struct {
+	File string `yaml:"file"` // YAML file mapping addresses or pubkeys to names, groups and tags
+}
+
+// Defaults returns the built-in configuration, the lowest precedence layer.
+func Defaults() *Config {
+	logFilePath := "suitop.log" // Fallback to current directory if home not found
+	maintenanceFile := "maintenance.yaml"
+	labelsFile := "labels.yaml"
+	if home, err := os.UserHomeDir(); err == nil {
+		// Default log, maintenance and labels files in user's home directory
+		logFilePath = filepath.Join(home, ".suitop", "logs", "suitop.log")
+		maintenanceFile = filepath.Join(home, ".suitop", "maintenance.yaml")
+		labelsFile = filepath.Join(home, ".suitop", "labels.yaml")
 	}
 
 	return &Config{
-		SuiNode:           suiNode,
-		JSONRPCURL:        jsonRPCURL,
-		DefaultRPCTimeout: time.Duration(defaultRPCTimeoutSeconds) * time.Second,
+		Network:           "mainnet",
+		DefaultRPCTimeout: 15 * time.Second,
 		GRPC: GRPCConfig{
-			UseTLS:             grpcUseTLS,
-			InsecureSkipVerify: grpcInsecureSkipVerify,
+			UseTLS:             true,
+			InsecureSkipVerify: true, // Easier local dev against testnets, review for prod
 		},
 		GRPCSubscriberConfig: GRPCSubscriberConfig{
-			RetryDelay: time.Duration(subscriberRetryDelayMs) * time.Millisecond,
+			RetryDelay:   time.Second,
+			StallTimeout: 30 * time.Second,
 		},
 		ProcessorConfig: ProcessorConfig{},
-		RPCClientConfig: RPCClientConfig{
-			URL:     jsonRPCURL,
-			Timeout: time.Duration(defaultRPCTimeoutSeconds) * time.Second,
-		},
 		UIConfig: UIConfig{
-			PlainMode:   plainMode,
-			NoAltScreen: noAltScreen,
+			Output: OutputText,
+			Emit:   string(EmitAll),
 		},
 		LogConfig: LogConfig{
-			ToStderr:  true,      // Always log to stderr
-			ToFile:    logToFile, // Optionally log to file
+			ToStderr:  true, // Always log to stderr
 			FilePath:  logFilePath,
 			WithTime:  true,
 			WithLevel: true,
 		},
 		DatasetConfig: DatasetConfig{
-			Generate: generateDataset,
-			Folder:   datasetFolder,
+			Folder: "./data",
+		},
+		APIConfig: APIConfig{
+			RecentCheckpoints: 1000,
+		},
+		AlertConfig: AlertConfig{
+			AlertmanagerResendInterval: time.Minute,
+			MissStreakThreshold:        10,
+			StallThreshold:             2 * time.Minute,
+		},
+		MaintenanceConfig: MaintenanceConfig{
+			File: maintenanceFile,
+		},
+		LabelsConfig: LabelsConfig{
+			File: labelsFile,
 		},
+		HistoryConfig: HistoryConfig{
+			RawRetention:    7 * 24 * time.Hour,  // One week
+			MinuteRetention: 90 * 24 * time.Hour, // 90 days
+		},
+		ChainConfig: ChainConfig{
+			CheckInterval: time.Minute,
+		},
+		NodeConfig: NodeConfig{
+			PollInterval: 15 * time.Second,
+			MaxLag:       time.Minute,
+		},
+	}
+}
+
+// Load builds the configuration from the defaults, the selected profile of the
+// config file and environment variables, in increasing order of precedence.
+// path may be empty to use ~/.suitop/config.yaml if it exists; profile may be
+// empty to use SUITOP_PROFILE or the file's default profile. Command-line flags
+// are applied by the caller, which then calls Finalize.
+func Load(path, profile string) (*Config, error) {
+	cfg := Defaults()
+	if err := cfg.applyFile(path, profile); err != nil {
+		return nil, err
+	}
+	cfg.applyEnv()
+	return cfg, nil
+}
+
+// applyEnv overrides the configuration with the environment variables that are set.
+// Invalid values are ignored.
+func (c *Config) applyEnv() {
+	envString("SUI_NODE", &c.SuiNode)
+	envString("SUI_JSON_RPC_URL", &c.JSONRPCURL)
+	if v, ok := envPositiveInt("DEFAULT_RPC_TIMEOUT_SECONDS"); ok {
+		c.DefaultRPCTimeout = time.Duration(v) * time.Second
+	}
+
+	if v, ok := os.LookupEnv("GRPC_USE_TLS"); ok {
+		c.GRPC.UseTLS = v != "false"
+	}
+	if v, ok := os.LookupEnv("GRPC_INSECURE_SKIP_VERIFY"); ok {
+		c.GRPC.InsecureSkipVerify = v != "false"
+	}
+
+	if v, ok := envPositiveInt("SUBSCRIBER_RETRY_DELAY_MS"); ok {
+		c.GRPCSubscriberConfig.RetryDelay = time.Duration(v) * time.Millisecond
+	}
+	if v, ok := envPositiveInt("SUBSCRIBER_STALL_TIMEOUT_SECONDS"); ok {
+		c.GRPCSubscriberConfig.StallTimeout = time.Duration(v) * time.Second
+	}
+
+	envTrue("PLAIN_MODE", &c.UIConfig.PlainMode)
+	envTrue("NO_ALT_SCREEN", &c.UIConfig.NoAltScreen)
+	if v := os.Getenv("OUTPUT_FORMAT"); v != "" {
+		c.UIConfig.Output = OutputFormat(v)
+	}
+	envString("EMIT", &c.UIConfig.Emit)
+
+	envTrue("LOG_TO_FILE", &c.LogConfig.ToFile)
+	envString("LOG_FILE_PATH", &c.LogConfig.FilePath)
+
+	envTrue("GENERATE_DATASET", &c.DatasetConfig.Generate)
+	envString("DATASET_FOLDER", &c.DatasetConfig.Folder)
+
+	envString("METRICS_LISTEN", &c.MetricsConfig.ListenAddr)
+
+	envString("API_LISTEN", &c.APIConfig.ListenAddr)
+	envString("API_TOKEN", &c.APIConfig.Token)
+	if v, ok := envPositiveInt("API_RECENT_CHECKPOINTS"); ok {
+		c.APIConfig.RecentCheckpoints = v
+	}
+
+	envString("ALERT_RULES_FILE", &c.AlertConfig.RulesFile)
+	if v := os.Getenv("ALERTMANAGER_URL"); v != "" {
+		c.AlertConfig.AlertmanagerURLs = SplitList(v)
+	}
+	envDuration("ALERTMANAGER_RESEND_INTERVAL", &c.AlertConfig.AlertmanagerResendInterval)
+	if v, ok := envPositiveInt("ALERT_MISS_STREAK"); ok {
+		c.AlertConfig.MissStreakThreshold = v
+	}
+	envDuration("ALERT_STALL_THRESHOLD", &c.AlertConfig.StallThreshold)
+
+	envString("NOTIFY_CONFIG_FILE", &c.NotifyConfig.ConfigFile)
+	envString("MAINTENANCE_FILE", &c.MaintenanceConfig.File)
+
+	envTrue("HISTORY_ENABLED", &c.HistoryConfig.Enabled)
+	envString("HISTORY_FOLDER", &c.HistoryConfig.Folder)
+	envDuration("HISTORY_RAW_RETENTION", &c.HistoryConfig.RawRetention)
+	envDuration("HISTORY_MINUTE_RETENTION", &c.HistoryConfig.MinuteRetention)
+	envDuration("CHAIN_CHECK_INTERVAL", &c.ChainConfig.CheckInterval)
+	envDuration("NODE_POLL_INTERVAL", &c.NodeConfig.PollInterval)
+	envDuration("NODE_MAX_LAG", &c.NodeConfig.MaxLag)
+	if v := os.Getenv("WATCH"); v != "" {
+		c.WatchConfig.Validators = SplitList(v)
+	}
+	envString("WATCH_FILE", &c.WatchConfig.File)
+	envTrue("WATCH_ONLY", &c.WatchConfig.Only)
+	envString("LABELS_FILE", &c.LabelsConfig.File)
+}
+
+// Finalize fills in settings derived from others once every layer has been
+// applied: the network's default endpoints and chain, the JSON-RPC client
+// settings, the history folder and the plain-mode output settings.
+func (c *Config) Finalize() error {
+	switch c.UIConfig.Output {
+	case OutputText, OutputJSON, OutputCSV, OutputLogfmt:
+	default:
+		return fmt.Errorf("invalid output format %q: must be one of %s", c.UIConfig.Output, strings.Join(OutputFormats(), ", "))
+	}
+	c.ProcessorConfig.Output = c.UIConfig.Output
+	emit, err := ParseEmit(c.UIConfig.Emit)
+	if err != nil {
+		return err
+	}
+	c.ProcessorConfig.Emit = emit
+	if len(c.AlertConfig.AlertmanagerURLs) > 0 && c.AlertConfig.AlertmanagerResendInterval <= 0 {
+		return fmt.Errorf("invalid alertmanager_resend_interval %v: must be positive", c.AlertConfig.AlertmanagerResendInterval)
+	}
+	if c.NodeConfig.PollInterval <= 0 {
+		return fmt.Errorf("invalid node poll_interval %v: must be positive", c.NodeConfig.PollInterval)
+	}
+
+	network, ok := c.Networks[c.Network]
+	if !ok {
+		network, ok = knownNetworks[c.Network]
+	}
+	if c.SuiNode == "" || c.JSONRPCURL == "" {
+		if !ok {
+			return fmt.Errorf("invalid network %q: must be one of %s, a network defined in the config file, or set both the gRPC and JSON-RPC endpoints", c.Network, strings.Join(KnownNetworks(), ", "))
+		}
+		if err := network.Validate(); err != nil {
+			return fmt.Errorf("network %q: %w", c.Network, err)
+		}
+		// The network's TLS mode goes with its endpoint; an explicitly
+		// configured endpoint uses the grpc settings as they are.
+		if c.SuiNode == "" {
+			c.SuiNode = network.GRPC
+			c.GRPC.applyTLS(network.TLS)
+		}
+		if c.JSONRPCURL == "" {
+			c.JSONRPCURL = network.jsonRPCURL(c.GRPC.UseTLS)
+		}
+	}
+	// Explicit endpoints are still expected to serve the named network's chain.
+	c.ExpectedChainID = network.ChainID
+	c.RPCClientConfig = RPCClientConfig{
+		URL:     c.JSONRPCURL,
+		Timeout: c.DefaultRPCTimeout,
+	}
+	if c.HistoryConfig.Folder == "" {
+		c.HistoryConfig.Folder = filepath.Join(c.DatasetConfig.Folder, "history")
+	}
+	return nil
+}
+
+// SplitList splits a comma-separated list, dropping empty entries.
+func SplitList(s string) []string {
+	var out []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func envString(name string, dst *string) {
+	if v := os.Getenv(name); v != "" {
+		*dst = v
+	}
+}
+
+// envTrue sets a setting that defaults to false; only "true" enables it.
+func envTrue(name string, dst *bool) {
+	if v, ok := os.LookupEnv(name); ok {
+		*dst = v == "true"
+	}
+}
+
+func envPositiveInt(name string) (int, bool) {
+	v, err := strconv.Atoi(os.Getenv(name))
+	return v, err == nil && v > 0
+}
+
+func envDuration(name string, dst *time.Duration) {
+	if v, err := time.ParseDuration(os.Getenv(name)); err == nil && v > 0 {
+		*dst = v
 	}
 }
@@ -0,0 +1,331 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeConfigFile writes a config file to a temporary folder and returns its path
+func writeConfigFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+const layeredConfig = `
+default_profile: ops
+networks:
+  private:
+    grpc: node.example.com:443
+    chain_id: 0a0b0c0d
+profiles:
+  ops:
+    network: testnet
+    rpc_timeout: 30s
+    ui: {emit: changes}
+    alerts: {miss_streak: 5}
+  private:
+    network: private
+`
+
+func TestLoadLayers(t *testing.T) {
+	path := writeConfigFile(t, layeredConfig)
+	tests := []struct {
+		name    string
+		profile string
+		env     map[string]string
+		check   func(t *testing.T, c *Config)
+	}{
+		{
+			name: "default profile over defaults",
+			check: func(t *testing.T, c *Config) {
+				if c.Profile != "ops" || c.Network != "testnet" {
+					t.Errorf("profile %q network %q, want ops on testnet", c.Profile, c.Network)
+				}
+				if c.DefaultRPCTimeout != 30*time.Second || c.UIConfig.Emit != "changes" || c.AlertConfig.MissStreakThreshold != 5 {
+					t.Errorf("profile settings not applied: %+v", c)
+				}
+				// Settings the profile does not mention keep their defaults.
+				if c.AlertConfig.StallThreshold != 2*time.Minute || !c.GRPC.UseTLS {
+					t.Errorf("defaults lost: stall %v, tls %v", c.AlertConfig.StallThreshold, c.GRPC.UseTLS)
+				}
+			},
+		},
+		{
+			name: "environment over profile",
+			env: map[string]string{
+				"DEFAULT_RPC_TIMEOUT_SECONDS": "5",
+				"EMIT":                        "every=10",
+				"ALERTMANAGER_URL":            "http://a, ,http://b",
+			},
+			check: func(t *testing.T, c *Config) {
+				if c.DefaultRPCTimeout != 5*time.Second || c.UIConfig.Emit != "every=10" {
+					t.Errorf("environment not applied: timeout %v, emit %q", c.DefaultRPCTimeout, c.UIConfig.Emit)
+				}
+				if want := []string{"http://a", "http://b"}; !reflect.DeepEqual(c.AlertConfig.AlertmanagerURLs, want) {
+					t.Errorf("alertmanager URLs %v, want %v", c.AlertConfig.AlertmanagerURLs, want)
+				}
+			},
+		},
+		{
+			name: "invalid environment values are ignored",
+			env:  map[string]string{"DEFAULT_RPC_TIMEOUT_SECONDS": "-1", "NODE_POLL_INTERVAL": "soon"},
+			check: func(t *testing.T, c *Config) {
+				if c.DefaultRPCTimeout != 30*time.Second || c.NodeConfig.PollInterval != 15*time.Second {
+					t.Errorf("timeout %v, poll interval %v; want the profile and default values", c.DefaultRPCTimeout, c.NodeConfig.PollInterval)
+				}
+			},
+		},
+		{
+			name: "profile from the environment",
+			env:  map[string]string{ProfileEnv: "private"},
+			check: func(t *testing.T, c *Config) {
+				if c.Profile != "private" || c.Network != "private" {
+					t.Errorf("profile %q network %q, want private", c.Profile, c.Network)
+				}
+			},
+		},
+		{
+			name:    "explicit profile over the environment",
+			profile: "ops",
+			env:     map[string]string{ProfileEnv: "private"},
+			check: func(t *testing.T, c *Config) {
+				if c.Profile != "ops" {
+					t.Errorf("profile %q, want ops", c.Profile)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			c, err := Load(path, tt.profile)
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			if c.File != path {
+				t.Errorf("File = %q, want %q", c.File, path)
+			}
+			tt.check(t, c)
+		})
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		profile string
+		want    string
+	}{
+		{"unknown profile", layeredConfig, "staging", `no profile "staging"`},
+		{"several profiles without default", "profiles:\n  a: {}\n  b: {}\n", "", "several profiles"},
+		{"unknown profile key", "profiles:\n  a:\n    netwrk: testnet\n", "", "field netwrk not found"},
+		{"unknown nested key", "profiles:\n  a:\n    alerts: {miss_streek: 3}\n", "", "field miss_streek not found"},
+		{"unknown top-level key", "profile:\n  a: {}\n", "", "field profile not found"},
+		{"undefined default profile", "default_profile: b\nprofiles:\n  a: {}\n", "", `default_profile "b" is not defined`},
+		{"invalid network", "networks:\n  x: {grpc: nohost}\n", "", `network "x"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(writeConfigFile(t, tt.content), tt.profile)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Load() error = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFinalize(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(c *Config)
+		wantErr string
+		check   func(t *testing.T, c *Config)
+	}{
+		{
+			name: "network endpoints",
+			modify: func(c *Config) {
+				c.Network = "testnet"
+			},
+			check: func(t *testing.T, c *Config) {
+				if c.SuiNode != "fullnode.testnet.sui.io:443" || c.RPCClientConfig.URL != "https://fullnode.testnet.sui.io" {
+					t.Errorf("endpoints %q %q", c.SuiNode, c.RPCClientConfig.URL)
+				}
+				if c.ExpectedChainID != "4c78adac" {
+					t.Errorf("ExpectedChainID = %q", c.ExpectedChainID)
+				}
+			},
+		},
+		{
+			name: "localnet is plaintext",
+			modify: func(c *Config) {
+				c.Network = "localnet"
+			},
+			check: func(t *testing.T, c *Config) {
+				if c.GRPC.UseTLS || c.SuiNode != "127.0.0.1:9000" {
+					t.Errorf("localnet: tls %v, endpoint %q", c.GRPC.UseTLS, c.SuiNode)
+				}
+			},
+		},
+		{
+			name: "custom network",
+			modify: func(c *Config) {
+				c.Network = "private"
+				c.Networks = map[string]Network{"private": {GRPC: "node.example.com:443", TLS: TLSVerify}}
+			},
+			check: func(t *testing.T, c *Config) {
+				if c.JSONRPCURL != "https://node.example.com" || c.GRPC.InsecureSkipVerify {
+					t.Errorf("custom network: json-rpc %q, skip verify %v", c.JSONRPCURL, c.GRPC.InsecureSkipVerify)
+				}
+			},
+		},
+		{
+			name: "explicit endpoints",
+			modify: func(c *Config) {
+				c.Network = "unknown"
+				c.SuiNode = "127.0.0.1:1"
+				c.JSONRPCURL = "http://127.0.0.1:2"
+			},
+			check: func(t *testing.T, c *Config) {
+				if c.SuiNode != "127.0.0.1:1" || c.ExpectedChainID != "" {
+					t.Errorf("explicit endpoints: %q, chain %q", c.SuiNode, c.ExpectedChainID)
+				}
+			},
+		},
+		{
+			name: "derived settings",
+			modify: func(c *Config) {
+				c.UIConfig.Output = OutputCSV
+				c.UIConfig.Emit = "every=30s"
+				c.DatasetConfig.Folder = "/data"
+			},
+			check: func(t *testing.T, c *Config) {
+				if c.ProcessorConfig.Output != OutputCSV || c.ProcessorConfig.Emit != (Emit{Mode: EmitEvery, Interval: 30 * time.Second}) {
+					t.Errorf("processor config %+v", c.ProcessorConfig)
+				}
+				if c.HistoryConfig.Folder != filepath.Join("/data", "history") {
+					t.Errorf("history folder %q", c.HistoryConfig.Folder)
+				}
+			},
+		},
+		{
+			name:    "unknown network",
+			modify:  func(c *Config) { c.Network = "nowhere" },
+			wantErr: `invalid network "nowhere"`,
+		},
+		{
+			name:    "invalid output",
+			modify:  func(c *Config) { c.UIConfig.Output = "xml" },
+			wantErr: `invalid output format "xml"`,
+		},
+		{
+			name:    "invalid emit",
+			modify:  func(c *Config) { c.UIConfig.Emit = "sometimes" },
+			wantErr: `invalid emit mode "sometimes"`,
+		},
+		{
+			name: "alertmanager without resend interval",
+			modify: func(c *Config) {
+				c.AlertConfig.AlertmanagerURLs = []string{"http://am"}
+				c.AlertConfig.AlertmanagerResendInterval = 0
+			},
+			wantErr: "alertmanager_resend_interval",
+		},
+		{
+			name:    "node poll interval",
+			modify:  func(c *Config) { c.NodeConfig.PollInterval = 0 },
+			wantErr: "poll_interval",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := Defaults()
+			tt.modify(c)
+			err := c.Finalize()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Finalize() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Finalize: %v", err)
+			}
+			tt.check(t, c)
+		})
+	}
+}
+
+func TestParseEmit(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Emit
+		wantErr bool
+	}{
+		{"", Emit{Mode: EmitAll}, false},
+		{"all", Emit{Mode: EmitAll}, false},
+		{"changes", Emit{Mode: EmitChanges}, false},
+		{"every=100", Emit{Mode: EmitEvery, Checkpoints: 100}, false},
+		{"every=1m", Emit{Mode: EmitEvery, Interval: time.Minute}, false},
+		{"every=0", Emit{}, true},
+		{"every=-1s", Emit{}, true},
+		{"every=", Emit{}, true},
+		{"every", Emit{}, true},
+		{"sometimes", Emit{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseEmit(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseEmit(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseEmit(%q) = %+v, want %+v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitList(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"", nil},
+		{" , ,", nil},
+		{"a", []string{"a"}},
+		{" a ,b,, c ", []string{"a", "b", "c"}},
+	}
+	for _, tt := range tests {
+		if got := SplitList(tt.in); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("SplitList(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestEffectiveYAMLRedactsSecrets(t *testing.T) {
+	c := Defaults()
+	c.APIConfig.Token = "s3cret"
+	out, err := c.EffectiveYAML()
+	if err != nil {
+		t.Fatalf("EffectiveYAML: %v", err)
+	}
+	if strings.Contains(string(out), "s3cret") {
+		t.Errorf("EffectiveYAML() leaks the API token:\n%s", out)
+	}
+	// The output is itself a valid profile.
+	var back Config
+	if err := decodeStrict(out, &back); err != nil {
+		t.Errorf("EffectiveYAML() output does not decode as a profile: %v\n%s", err, out)
+	}
+}
@@ -0,0 +1,200 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ProfileEnv selects the config file profile when --profile is not given.
+const ProfileEnv = "SUITOP_PROFILE"
+
+// file is the layout of the config file. Each profile has the layout of Config.
+type file struct {
+	DefaultProfile string               `yaml:"default_profile"`
+	Networks       map[string]Network   `yaml:"networks"`
+	Profiles       map[string]yaml.Node `yaml:"profiles"`
+}
+
+// DefaultPath returns ~/.suitop/config.yaml, which is loaded if it exists and
+// no other file is given.
+func DefaultPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".suitop", "config.yaml")
+}
+
+// applyFile overrides the configuration with the selected profile of the config file.
+func (c *Config) applyFile(path, profile string) error {
+	path, f, profile, raw, err := loadProfile(path, profile)
+	if err != nil || path == "" {
+		return err
+	}
+	c.File = path
+	c.Networks = f.Networks
+	if profile == "" {
+		return nil
+	}
+	// Decode the profile on top of the defaults so that only the settings it
+	// mentions are overridden.
+	if err := decodeStrict(raw, c); err != nil {
+		return fmt.Errorf("error in profile %q of config file %s: %w", profile, path, err)
+	}
+	c.Profile = profile
+	return nil
+}
+
+// ReadProfile returns the config file path, the selected profile and its YAML.
+// path may be empty to use DefaultPath if it exists; the returned path is empty
+// if there is no config file. profile may be empty to use SUITOP_PROFILE, the
+// file's default_profile or its only profile; the returned profile is empty if
+// the file has none.
+func ReadProfile(path, profile string) (string, string, []byte, error) {
+	path, _, profile, raw, err := loadProfile(path, profile)
+	return path, profile, raw, err
+}
+
+// loadProfile is ReadProfile, also returning the parsed config file.
+func loadProfile(path, profile string) (string, file, string, []byte, error) {
+	if profile == "" {
+		profile = os.Getenv(ProfileEnv)
+	}
+	if path == "" {
+		path = DefaultPath()
+		if _, err := os.Stat(path); path == "" || errors.Is(err, os.ErrNotExist) {
+			if profile != "" {
+				return "", file{}, "", nil, fmt.Errorf("profile %q requested but there is no config file at %s", profile, path)
+			}
+			return "", file{}, "", nil, nil
+		}
+	}
+
+	f, err := readFile(path)
+	if err != nil {
+		return "", file{}, "", nil, err
+	}
+	if profile == "" {
+		profile = f.DefaultProfile
+	}
+	if profile == "" {
+		switch len(f.Profiles) {
+		case 0:
+			return path, f, "", nil, nil
+		case 1:
+			for name := range f.Profiles {
+				profile = name
+			}
+		default:
+			return "", file{}, "", nil, fmt.Errorf("config file %s has several profiles (%s) and no default_profile; choose one with --profile", path, strings.Join(profileNames(f), ", "))
+		}
+	}
+	node, ok := f.Profiles[profile]
+	if !ok {
+		return "", file{}, "", nil, fmt.Errorf("config file %s has no profile %q (available: %s)", path, profile, strings.Join(profileNames(f), ", "))
+	}
+	raw, err := yaml.Marshal(&node)
+	if err != nil {
+		return "", file{}, "", nil, err
+	}
+	return path, f, profile, raw, nil
+}
+
+// Profiles returns the names of the profiles in the config file at path.
+func Profiles(path string) ([]string, error) {
+	f, err := readFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return profileNames(f), nil
+}
+
+func readFile(path string) (file, error) {
+	var f file
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return f, fmt.Errorf("error reading config file: %w", err)
+	}
+	if err := decodeStrict(data, &f); err != nil {
+		return f, fmt.Errorf("error parsing config file %s: %w", path, err)
+	}
+	for name, n := range f.Networks {
+		if err := n.Validate(); err != nil {
+			return f, fmt.Errorf("config file %s: network %q: %w", path, name, err)
+		}
+	}
+	if f.DefaultProfile != "" {
+		if _, ok := f.Profiles[f.DefaultProfile]; !ok {
+			return f, fmt.Errorf("config file %s: default_profile %q is not defined", path, f.DefaultProfile)
+		}
+	}
+	return f, nil
+}
+
+func decodeStrict(data []byte, v interface{}) error {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(v); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	return nil
+}
+
+func profileNames(f file) []string {
+	names := make([]string, 0, len(f.Profiles))
+	for name := range f.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// EffectiveYAML renders the configuration as a config file profile, with
+// durations in Go syntax and secrets redacted.
+func (c *Config) EffectiveYAML() ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(toNode(reflect.ValueOf(*c))); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// toNode converts a config value to a YAML node in field order.
+func toNode(v reflect.Value) *yaml.Node {
+	switch {
+	case v.Type() == durationType:
+		return &yaml.Node{Kind: yaml.ScalarNode, Value: time.Duration(v.Int()).String()}
+	case v.Kind() == reflect.Struct:
+		n := &yaml.Node{Kind: yaml.MappingNode}
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+			if name == "" || name == "-" {
+				continue
+			}
+			value := toNode(v.Field(i))
+			if field.Tag.Get("secret") == "true" && !v.Field(i).IsZero() {
+				value = &yaml.Node{Kind: yaml.ScalarNode, Value: "<redacted>"}
+			}
+			n.Content = append(n.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: name}, value)
+		}
+		return n
+	}
+	n := &yaml.Node{}
+	n.Encode(v.Interface())
+	return n
+}
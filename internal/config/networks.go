@@ -0,0 +1,99 @@
+package config
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net"
+	"sort"
+)
+
+// TLSMode selects how the gRPC connection to a network is secured.
+type TLSMode string
+
+const (
+	TLSVerify    TLSMode = "tls"       // TLS with certificate verification
+	TLSInsecure  TLSMode = "insecure"  // TLS without certificate verification
+	TLSPlaintext TLSMode = "plaintext" // No TLS, e.g. for a localnet
+)
+
+// Network describes the endpoints of a Sui network. Custom networks are
+// defined in the `networks` section of the config file.
+type Network struct {
+	GRPC    string  `yaml:"grpc"`               // host:port of the gRPC endpoint
+	JSONRPC string  `yaml:"json_rpc,omitempty"` // Defaults to the gRPC host and port over HTTP(S)
+	TLS     TLSMode `yaml:"tls,omitempty"`      // Empty keeps the grpc settings of the profile
+
+	// ChainID is the chain identifier reported by sui_getChainIdentifier: the
+	// first 4 bytes of the genesis checkpoint digest in hex. Empty accepts any
+	// chain, as for networks that are wiped and restarted.
+	ChainID string `yaml:"chain_id,omitempty"`
+}
+
+var knownNetworks = map[string]Network{
+	"mainnet":  {GRPC: "fullnode.mainnet.sui.io:443", JSONRPC: "https://fullnode.mainnet.sui.io", ChainID: "35834a8a"},
+	"testnet":  {GRPC: "fullnode.testnet.sui.io:443", JSONRPC: "https://fullnode.testnet.sui.io", ChainID: "4c78adac"},
+	"devnet":   {GRPC: "fullnode.devnet.sui.io:443", JSONRPC: "https://fullnode.devnet.sui.io"},
+	"localnet": {GRPC: "127.0.0.1:9000", JSONRPC: "http://127.0.0.1:9000", TLS: TLSPlaintext},
+}
+
+// KnownNetworks returns the names of the networks with built-in endpoints.
+func KnownNetworks() []string {
+	names := make([]string, 0, len(knownNetworks))
+	for name := range knownNetworks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Validate checks the network definition.
+func (n Network) Validate() error {
+	if n.GRPC == "" {
+		return fmt.Errorf("grpc endpoint is required")
+	}
+	if _, _, err := net.SplitHostPort(n.GRPC); err != nil {
+		return fmt.Errorf("grpc endpoint must be host:port: %w", err)
+	}
+	if n.ChainID != "" {
+		if b, err := hex.DecodeString(n.ChainID); err != nil || len(b) != 4 {
+			return fmt.Errorf("chain_id must be 8 hex digits, as returned by sui_getChainIdentifier")
+		}
+	}
+	switch n.TLS {
+	case "", TLSVerify, TLSInsecure, TLSPlaintext:
+	default:
+		return fmt.Errorf("tls must be %q, %q or %q", TLSVerify, TLSInsecure, TLSPlaintext)
+	}
+	return nil
+}
+
+// jsonRPCURL returns the JSON-RPC endpoint, which full nodes serve on the
+// same host and port as gRPC unless configured otherwise.
+func (n Network) jsonRPCURL(useTLS bool) string {
+	if n.JSONRPC != "" {
+		return n.JSONRPC
+	}
+	host, port, err := net.SplitHostPort(n.GRPC)
+	if err != nil {
+		return ""
+	}
+	if useTLS {
+		if port == "443" {
+			return "https://" + host
+		}
+		return "https://" + n.GRPC
+	}
+	return "http://" + n.GRPC
+}
+
+// applyTLS sets the gRPC transport settings for the network's TLS mode.
+func (g *GRPCConfig) applyTLS(mode TLSMode) {
+	switch mode {
+	case TLSVerify:
+		g.UseTLS, g.InsecureSkipVerify = true, false
+	case TLSInsecure:
+		g.UseTLS, g.InsecureSkipVerify = true, true
+	case TLSPlaintext:
+		g.UseTLS = false
+	}
+}
@@ -0,0 +1,145 @@
+package events
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+const defaultReplaySize = 4096
+
+// Bus fans out events to subscribers and keeps a replay buffer so that
+// clients can resume after a disconnect. A nil *Bus discards all events.
+type Bus struct {
+	mu          sync.Mutex
+	nextID      uint64
+	replay      []Event
+	replaySize  int
+	subscribers map[chan Event]func(Event) bool // Filter of each subscriber; nil receives every event
+}
+
+// NewBus creates a bus that retains the last replaySize events for replay.
+func NewBus(replaySize int) *Bus {
+	if replaySize <= 0 {
+		replaySize = defaultReplaySize
+	}
+	return &Bus{
+		nextID:      1,
+		replaySize:  replaySize,
+		subscribers: make(map[chan Event]func(Event) bool),
+	}
+}
+
+// Publish assigns the event an ID and delivers it to all subscribers.
+// Subscribers that are not keeping up miss the event rather than blocking the publisher.
+func (b *Bus) Publish(e Event) {
+	if b == nil {
+		return
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	e.ID = b.nextID
+	b.nextID++
+	b.replay = append(b.replay, e)
+	if len(b.replay) > b.replaySize {
+		b.replay = b.replay[len(b.replay)-b.replaySize:]
+	}
+	for ch, keep := range b.subscribers {
+		if keep != nil && !keep(e) {
+			continue
+		}
+		select {
+		case ch <- e:
+		default:
+			log.Printf("Warning: event subscriber is falling behind, dropped %s event %d", e.Kind, e.ID)
+		}
+	}
+}
+
+// Subscribe returns a channel receiving every event published from now on and
+// a function that must be called to unsubscribe.
+func (b *Bus) Subscribe(buffer int) (<-chan Event, func()) {
+	return b.subscribe(buffer, nil)
+}
+
+// SubscribeKinds is Subscribe for the events of the given kinds only, so that
+// a subscriber interested in rare events is not flooded by frequent ones.
+func (b *Bus) SubscribeKinds(buffer int, kinds ...Kind) (<-chan Event, func()) {
+	return b.subscribe(buffer, func(e Event) bool {
+		for _, k := range kinds {
+			if e.Kind == k {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+func (b *Bus) subscribe(buffer int, keep func(Event) bool) (<-chan Event, func()) {
+	ch := make(chan Event, buffer)
+	if b == nil {
+		return ch, func() {}
+	}
+	b.mu.Lock()
+	b.subscribers[ch] = keep
+	b.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers, ch)
+			b.mu.Unlock()
+		})
+	}
+}
+
+// LastID returns the ID of the newest buffered event matching match, or 0 if
+// none does.
+func (b *Bus) LastID(match func(Event) bool) uint64 {
+	if b == nil {
+		return 0
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for i := len(b.replay) - 1; i >= 0; i-- {
+		if match(b.replay[i]) {
+			return b.replay[i].ID
+		}
+	}
+	return 0
+}
+
+// SubscribeWithReplay atomically returns the buffered events matching the
+// replay predicate and a live subscription, so no event is lost or duplicated
+// between the two.
+func (b *Bus) SubscribeWithReplay(buffer int, replay func(Event) bool) ([]Event, <-chan Event, func()) {
+	if b == nil {
+		ch, cancel := b.Subscribe(buffer)
+		return nil, ch, cancel
+	}
+	ch := make(chan Event, buffer)
+
+	b.mu.Lock()
+	var past []Event
+	for _, e := range b.replay {
+		if replay(e) {
+			past = append(past, e)
+		}
+	}
+	b.subscribers[ch] = nil
+	b.mu.Unlock()
+
+	var once sync.Once
+	return past, ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers, ch)
+			b.mu.Unlock()
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package events
+
+import (
+	"testing"
+)
+
+func TestBusSubscribe(t *testing.T) {
+	b := NewBus(2)
+	all, cancelAll := b.Subscribe(10)
+	defer cancelAll()
+	rare, cancelRare := b.SubscribeKinds(10, KindChainReset, KindSourceHealth)
+	defer cancelRare()
+
+	b.Publish(Event{Kind: KindCheckpoint, Sequence: 1})
+	b.Publish(Event{Kind: KindSourceHealth, Status: SourceStalled})
+	b.Publish(Event{Kind: KindCheckpoint, Sequence: 2})
+	b.Publish(Event{Kind: KindChainReset})
+
+	if got := drain(all); len(got) != 4 || got[0].ID != 1 || got[3].ID != 4 {
+		t.Errorf("Subscribe received %+v, want events 1-4", got)
+	}
+	got := drain(rare)
+	if len(got) != 2 || got[0].Kind != KindSourceHealth || got[1].Kind != KindChainReset {
+		t.Errorf("SubscribeKinds received %+v, want the source health and chain reset events", got)
+	}
+	if got[0].Time.IsZero() {
+		t.Error("Publish did not set the event time")
+	}
+
+	cancelRare()
+	cancelRare() // Unsubscribing twice is harmless
+	b.Publish(Event{Kind: KindChainReset})
+	if got := drain(rare); len(got) != 0 {
+		t.Errorf("received %d events after unsubscribing", len(got))
+	}
+}
+
+func TestBusReplay(t *testing.T) {
+	b := NewBus(2)
+	for i := 0; i < 3; i++ {
+		b.Publish(Event{Kind: KindCheckpoint})
+	}
+	b.Publish(Event{Kind: KindEpochChange})
+	past, live, cancel := b.SubscribeWithReplay(10, func(e Event) bool { return e.Kind == KindCheckpoint })
+	defer cancel()
+	// The replay buffer keeps the last 2 events, of which one is a checkpoint
+	if len(past) != 1 || past[0].ID != 3 {
+		t.Errorf("replayed %+v, want checkpoint event 3", past)
+	}
+	b.Publish(Event{Kind: KindEpochChange})
+	if got := drain(live); len(got) != 1 || got[0].ID != 5 {
+		t.Errorf("live subscription received %+v, want event 5", got)
+	}
+}
+
+func TestBusSlowSubscriber(t *testing.T) {
+	b := NewBus(0)
+	slow, cancel := b.Subscribe(1)
+	defer cancel()
+	// Publishing never blocks on a full subscriber
+	for i := 0; i < 10; i++ {
+		b.Publish(Event{Kind: KindCheckpoint})
+	}
+	if got := drain(slow); len(got) != 1 || got[0].ID != 1 {
+		t.Errorf("slow subscriber received %+v, want only event 1", got)
+	}
+}
+
+func TestNilBus(t *testing.T) {
+	var b *Bus
+	b.Publish(Event{Kind: KindCheckpoint})
+	ch, cancel := b.SubscribeKinds(1, KindChainReset)
+	cancel()
+	if len(ch) != 0 {
+		t.Error("nil bus delivered an event")
+	}
+}
+
+func drain(ch <-chan Event) []Event {
+	var out []Event
+	for {
+		select {
+		case e := <-ch:
+			out = append(out, e)
+		default:
+			return out
+		}
+	}
+}
+
+func TestBusLastID(t *testing.T) {
+	b := NewBus(3)
+	for _, seq := range []uint64{1, 2, 0, 3} {
+		b.Publish(Event{Kind: KindCheckpoint, Sequence: seq})
+	}
+	tests := []struct {
+		name  string
+		match func(Event) bool
+		want  uint64
+	}{
+		{"newest match", func(e Event) bool { return e.Sequence != 0 && e.Sequence <= 2 }, 2},
+		{"newest event", func(Event) bool { return true }, 4},
+		{"out of the buffer", func(e Event) bool { return e.Sequence == 1 }, 0},
+		{"no match", func(Event) bool { return false }, 0},
+	}
+	for _, tt := range tests {
+		if got := b.LastID(tt.match); got != tt.want {
+			t.Errorf("%s: LastID() = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+	var nilBus *Bus
+	if got := nilBus.LastID(func(Event) bool { return true }); got != 0 {
+		t.Errorf("nil Bus LastID() = %d, want 0", got)
+	}
+}
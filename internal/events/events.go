@@ -0,0 +1,125 @@
+package events
+
+import (
+	"fmt"
+	"time"
+)
+
+// Kind identifies the type of an Event.
+type Kind string
+
+const (
+	// KindCheckpoint is published for every processed checkpoint with its signer summary.
+	KindCheckpoint Kind = "checkpoint"
+	// KindValidatorStatus is published when a validator starts or stops missing checkpoints.
+	KindValidatorStatus Kind = "validator_status"
+	// KindEpochChange is published when the processor moves to a new epoch.
+	KindEpochChange Kind = "epoch_change"
+	// KindSourceHealth is published when the checkpoint subscription changes state.
+	KindSourceHealth Kind = "source_health"
+	// KindCommitteeReloadFailed is published when the committee for a new epoch cannot be loaded.
+	KindCommitteeReloadFailed Kind = "committee_reload_failed"
+	// KindChainReset is published when the node starts serving a different chain, e.g. after a devnet wipe.
+	KindChainReset Kind = "chain_reset"
+)
+
+// Validator status values carried by KindValidatorStatus events.
+const (
+	StatusSigning = "signing"
+	StatusMissing = "missing"
+)
+
+// Source health values carried by KindSourceHealth events.
+const (
+	SourceConnected    = "connected"
+	SourceDisconnected = "disconnected"
+	SourceStalled      = "stalled"
+)
+
+// ValidatorRef identifies a validator in an event.
+type ValidatorRef struct {
+	Name        string `json:"name"`
+	Address     string `json:"address"`
+	VotingPower int    `json:"voting_power,omitempty"`
+	Maintenance bool   `json:"maintenance,omitempty"` // Missed inside a maintenance window
+}
+
+// CheckpointSummary describes the signers of a processed checkpoint.
+type CheckpointSummary struct {
+	SignerCount   int            `json:"signer_count"`
+	CommitteeSize int            `json:"committee_size"`
+	SignedPower   int            `json:"signed_power"`
+	TotalPower    int            `json:"total_power"`
+	Missing       []ValidatorRef `json:"missing"`
+}
+
+// Event is a single occurrence published on the Bus.
+// Only the fields relevant to the event's Kind are set.
+type Event struct {
+	ID       uint64    `json:"id"` // Assigned by the Bus, strictly increasing
+	Kind     Kind      `json:"kind"`
+	Time     time.Time `json:"time"`
+	Epoch    uint64    `json:"epoch"`
+	Sequence uint64    `json:"sequence,omitempty"` // Checkpoint sequence the event relates to
+
+	Checkpoint *CheckpointSummary `json:"checkpoint,omitempty"`
+
+	Validator  *ValidatorRef `json:"validator,omitempty"`
+	Status     string        `json:"status,omitempty"`      // Validator status or source health
+	MissStreak uint64        `json:"miss_streak,omitempty"` // Misses before the validator resumed signing
+
+	PreviousEpoch uint64 `json:"previous_epoch,omitempty"`
+	Message       string `json:"message,omitempty"`
+}
+
+// Affects reports whether the event concerns the validator with the given address.
+// Checkpoint events concern a validator when it is listed as missing.
+func (e Event) Affects(address string) bool {
+	if e.Validator != nil && e.Validator.Address == address {
+		return true
+	}
+	if e.Checkpoint != nil {
+		for _, v := range e.Checkpoint.Missing {
+			if v.Address == address {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Summary renders the event as a single human-readable line.
+func (e Event) Summary() string {
+	var msg string
+	switch e.Kind {
+	case KindEpochChange:
+		msg = fmt.Sprintf("Epoch changed from %d to %d at checkpoint %d", e.PreviousEpoch, e.Epoch, e.Sequence)
+	case KindCommitteeReloadFailed:
+		msg = fmt.Sprintf("Failed to load committee for epoch %d: %s", e.Epoch, e.Message)
+	case KindChainReset:
+		msg = fmt.Sprintf("Chain reset detected: %s", e.Message)
+	case KindSourceHealth:
+		msg = fmt.Sprintf("Checkpoint subscription %s", e.Status)
+		if e.Message != "" {
+			msg += ": " + e.Message
+		}
+	case KindValidatorStatus:
+		name := "unknown validator"
+		if e.Validator != nil {
+			name = e.Validator.Name
+		}
+		if e.Status == StatusMissing {
+			msg = fmt.Sprintf("%s started missing checkpoints at %d", name, e.Sequence)
+		} else {
+			msg = fmt.Sprintf("%s resumed signing at checkpoint %d after %d misses", name, e.Sequence, e.MissStreak)
+		}
+	case KindCheckpoint:
+		msg = fmt.Sprintf("Checkpoint %d", e.Sequence)
+		if e.Checkpoint != nil {
+			msg += fmt.Sprintf(" signed by %d/%d validators, %d missing", e.Checkpoint.SignerCount, e.Checkpoint.CommitteeSize, len(e.Checkpoint.Missing))
+		}
+	default:
+		msg = string(e.Kind)
+	}
+	return msg
+}
@@ -1,20 +1,16 @@
 package grpc
 
-// This file is a placeholder for potential gRPC client/server interceptors,
-// such as for logging, metrics, or tracing.
-
-// Example of what might go here in the future:
-/*
 import (
 	"context"
-	"log"
 	"time"
 
 	"google.golang.org/grpc"
+
+	"suitop/internal/metrics"
 )
 
-// LoggingClientInterceptor logs the RPC method, duration, and error for client calls.
-func LoggingClientInterceptor() grpc.UnaryClientInterceptor {
+// MetricsUnaryClientInterceptor records the latency and outcome of unary gRPC calls.
+func MetricsUnaryClientInterceptor() grpc.UnaryClientInterceptor {
 	return func(
 		ctx context.Context,
 		method string,
@@ -26,13 +22,7 @@ func LoggingClientInterceptor() grpc.UnaryClientInterceptor {
 	) error {
 		start := time.Now()
 		err := invoker(ctx, method, req, reply, cc, opts...)
-		duration := time.Since(start)
-		if err != nil {
-			log.Printf("gRPC call: %s, Duration: %s, Error: %v", method, duration, err)
-		} else {
-			log.Printf("gRPC call: %s, Duration: %s, Success", method, duration)
-		}
+		metrics.ObserveRPC("grpc", method, time.Since(start).Seconds(), err)
 		return err
 	}
 }
-*/
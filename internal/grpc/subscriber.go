@@ -2,8 +2,10 @@ package grpc
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"log"
+	"sync/atomic"
 	"time"
 
 	"google.golang.org/grpc/codes"
@@ -11,6 +13,8 @@ import (
 	"google.golang.org/protobuf/types/known/fieldmaskpb"
 
 	"suitop/internal/config"
+	"suitop/internal/events"
+	"suitop/internal/metrics"
 
 	subPb "suitop/pb/sui/rpc/v2alpha"
 	rpcPb "suitop/pb/sui/rpc/v2beta"
@@ -18,11 +22,13 @@ import (
 
 // SubscribeToCheckpoints subscribes to the checkpoint stream and sends data to a channel.
 // It attempts to automatically resubscribe if the stream is terminated.
+// Changes in subscription health are published to bus, which may be nil.
 func SubscribeToCheckpoints(
 	ctx context.Context,
 	subClient subPb.SubscriptionServiceClient,
 	checkpointChan chan<- *rpcPb.Checkpoint, // Changed from rpcPb.Checkpoint to subPb.Checkpoint
 	cfg config.GRPCSubscriberConfig,
+	bus *events.Bus,
 ) {
 	defer close(checkpointChan) // Close channel when subscription goroutine exits
 
@@ -30,6 +36,11 @@ func SubscribeToCheckpoints(
 	if retryDelay <= 0 {
 		retryDelay = 1 * time.Second // Default retry delay if not configured properly
 	}
+	stallTimeout := cfg.StallTimeout
+	if stallTimeout <= 0 {
+		stallTimeout = 30 * time.Second // Default stall timeout if not configured properly
+	}
+	subscribedBefore := false
 
 	for { // Outer loop for attempting to subscribe and resubscribe
 		select {
@@ -41,16 +52,21 @@ func SubscribeToCheckpoints(
 		}
 
 		log.Println("Attempting to subscribe to checkpoints...")
-		stream, err := subClient.SubscribeCheckpoints(ctx, &subPb.SubscribeCheckpointsRequest{
+		// Each subscription gets its own context so that a stalled stream can be
+		// torn down without cancelling the whole subscriber.
+		streamCtx, streamCancel := context.WithCancel(ctx)
+		stream, err := subClient.SubscribeCheckpoints(streamCtx, &subPb.SubscribeCheckpointsRequest{
 			ReadMask: &fieldmaskpb.FieldMask{
 				// We only require the aggregated signature (which includes
-				// the epoch information) and the sequence number of the
-				// checkpoint. Requesting fewer fields reduces payload size.
-				Paths: []string{"signature", "sequence_number"},
+				// the epoch information), the sequence number and the
+				// timestamp of the checkpoint. Requesting fewer fields
+				// reduces payload size.
+				Paths: []string{"signature", "sequence_number", "summary.timestamp"},
 			},
 		})
 
 		if err != nil {
+			streamCancel()
 			log.Printf("Failed to subscribe to checkpoints: %v", err)
 			if ctx.Err() != nil {
 				log.Println("Context cancelled during subscription attempt. Exiting.")
@@ -68,16 +84,44 @@ func SubscribeToCheckpoints(
 		}
 
 		log.Println("Successfully subscribed. Waiting for checkpoints...")
+		if subscribedBefore {
+			metrics.SubscriberReconnects.Inc()
+		}
+		subscribedBefore = true
+		metrics.SubscriberConnected.Set(1)
+		bus.Publish(events.Event{Kind: events.KindSourceHealth, Status: events.SourceConnected})
+
+		// The watchdog cancels the stream if no checkpoint arrives within
+		// stallTimeout. It only runs while waiting on the node: a processor that
+		// is slow to take checkpoints must not be mistaken for a stalled stream.
+		var stalled atomic.Bool
+		watchdog := time.AfterFunc(stallTimeout, func() {
+			stalled.Store(true)
+			streamCancel()
+		})
 
 	recvLoop:
 		for {
 			resp, err := stream.Recv() // resp is *subPb.SubscribeCheckpointsResponse
 			if err != nil {
 				if ctx.Err() != nil {
+					watchdog.Stop()
+					streamCancel()
 					log.Printf("Context cancelled during Recv(): %v. Exiting subscription.", ctx.Err())
 					return
 				}
 
+				if stalled.Load() {
+					metrics.SubscriberStalls.Inc()
+					bus.Publish(events.Event{
+						Kind:    events.KindSourceHealth,
+						Status:  events.SourceStalled,
+						Message: fmt.Sprintf("no checkpoint received for %v", stallTimeout),
+					})
+					log.Printf("No checkpoint received for %v, stream stalled. Attempting to resubscribe...", stallTimeout)
+					break recvLoop
+				}
+
 				if err == io.EOF {
 					log.Println("Checkpoint stream ended (EOF). Attempting to resubscribe...")
 					break recvLoop
@@ -102,16 +146,23 @@ func SubscribeToCheckpoints(
 			}
 
 			// Successfully received a response. resp.GetCheckpoint() is of type *subPb.CheckpointData
+			watchdog.Stop()
 			if resp.GetCheckpoint() != nil {
 				select {
 				case checkpointChan <- resp.GetCheckpoint():
 					// Successfully sent to channel
 				case <-ctx.Done():
+					streamCancel()
 					log.Printf("Context done while trying to send checkpoint to channel: %v. Exiting.", ctx.Err())
 					return
 				}
 			}
+			watchdog.Reset(stallTimeout)
 		} // End of recvLoop
+		watchdog.Stop()
+		streamCancel()
+		metrics.SubscriberConnected.Set(0)
+		bus.Publish(events.Event{Kind: events.KindSourceHealth, Status: events.SourceDisconnected})
 
 		log.Printf("Disconnected from stream. Waiting %v before attempting to resubscribe...", retryDelay)
 		select {
@@ -0,0 +1,184 @@
+package history
+
+import (
+	"time"
+)
+
+// Latest returns up to n of the most recently recorded checkpoints, oldest first.
+func (s *Store) Latest(n int) []CheckpointRecord {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if n <= 0 {
+		return nil
+	}
+	if n <= len(s.tail) {
+		return append([]CheckpointRecord(nil), s.tail[len(s.tail)-n:]...)
+	}
+	if len(s.tail) == 0 {
+		return nil
+	}
+	last := s.tail[len(s.tail)-1].Sequence
+	var from uint64
+	if last >= uint64(n) {
+		from = last - uint64(n) + 1
+	}
+	records, err := s.rangeLocked(from, last)
+	if err != nil {
+		return append([]CheckpointRecord(nil), s.tail...)
+	}
+	if len(records) > n {
+		records = records[len(records)-n:]
+	}
+	return records
+}
+
+// First returns the sequence number of the oldest raw record Range can
+// return, or false if the store is empty.
+func (s *Store) First() (uint64, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	first, ok := uint64(0), false
+	if len(s.segments) > 0 {
+		first, ok = s.segments[0].firstSeq, true
+	}
+	if len(s.tail) > 0 && (!ok || s.tail[0].Sequence < first) {
+		first, ok = s.tail[0].Sequence, true
+	}
+	return first, ok
+}
+
+// Range returns the raw records with sequence numbers in [from, to], oldest first.
+// Records that have aged out of the raw retention window are not returned.
+func (s *Store) Range(from, to uint64) ([]CheckpointRecord, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.rangeLocked(from, to)
+}
+
+func (s *Store) rangeLocked(from, to uint64) ([]CheckpointRecord, error) {
+	if len(s.tail) > 0 && s.tail[0].Sequence <= from {
+		var out []CheckpointRecord
+		for _, r := range s.tail {
+			if r.Sequence >= from && r.Sequence <= to {
+				out = append(out, r)
+			}
+		}
+		return out, nil
+	}
+
+	var out []CheckpointRecord
+	for _, seg := range s.segments {
+		if !seg.open && (seg.lastSeq < from || seg.firstSeq > to) {
+			continue
+		}
+		if err := scanSegment(seg.path, func(r CheckpointRecord) {
+			if r.Sequence >= from && r.Sequence <= to {
+				out = append(out, r)
+			}
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// Between returns the raw records whose timestamps fall in [from, to), oldest first.
+func (s *Store) Between(from, to time.Time) ([]CheckpointRecord, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var out []CheckpointRecord
+	for _, seg := range s.segments {
+		if !seg.hour.Add(time.Hour).After(from) || !seg.hour.Before(to) {
+			continue
+		}
+		if err := scanSegment(seg.path, func(r CheckpointRecord) {
+			if !r.Timestamp.Before(from) && r.Timestamp.Before(to) {
+				out = append(out, r)
+			}
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// Get returns the raw record for a single checkpoint.
+func (s *Store) Get(seq uint64) (CheckpointRecord, bool, error) {
+	records, err := s.Range(seq, seq)
+	if err != nil || len(records) == 0 {
+		return CheckpointRecord{}, false, err
+	}
+	return records[0], true, nil
+}
+
+// SignedBy reports whether the validator with the given address signed a checkpoint.
+// The second return value is false when the checkpoint or the validator's committee
+// membership for that epoch is unknown.
+func (s *Store) SignedBy(seq uint64, address string) (bool, bool, error) {
+	r, ok, err := s.Get(seq)
+	if err != nil || !ok {
+		return false, false, err
+	}
+	idx := s.BitmapIndex(r.Epoch, address)
+	if idx < 0 {
+		return false, false, nil
+	}
+	return r.Signers.Has(idx), true, nil
+}
+
+// Minutes returns the per-minute aggregates in [from, to), including the
+// minute currently being filled.
+func (s *Store) Minutes(from, to time.Time) []MinuteAggregate {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var out []MinuteAggregate
+	for _, m := range s.minutes {
+		if !m.Minute.Before(from) && m.Minute.Before(to) {
+			out = append(out, m)
+		}
+	}
+	if s.currentMinute != nil && !s.currentMinute.Minute.Before(from) && s.currentMinute.Minute.Before(to) {
+		out = append(out, *s.currentMinute)
+	}
+	return out
+}
+
+// Epochs returns every epoch aggregate, including the epoch currently being filled.
+func (s *Store) Epochs() []EpochAggregate {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := append([]EpochAggregate(nil), s.epochs...)
+	if s.currentEpoch != nil {
+		out = append(out, *s.currentEpoch)
+	}
+	return out
+}
+
+// Epoch returns the aggregate for a single epoch.
+func (s *Store) Epoch(epoch uint64) (EpochAggregate, bool) {
+	for _, e := range s.Epochs() {
+		if e.Epoch == epoch {
+			return e, true
+		}
+	}
+	return EpochAggregate{}, false
+}
+
+// Committee returns a copy of the recorded committee for an epoch in
+// bitmap-index order.
+func (s *Store) Committee(epoch uint64) []CommitteeMember {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]CommitteeMember(nil), s.committees[epoch]...)
+}
+
+// BitmapIndex resolves a validator address to its bitmap index within an epoch's
+// recorded committee, or -1 if unknown.
+func (s *Store) BitmapIndex(epoch uint64, address string) int {
+	for i, m := range s.Committee(epoch) {
+		if m.Address == address {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,133 @@
+package history
+
+import (
+	"math/bits"
+	"time"
+)
+
+// Bitset is a signer set indexed by committee bitmap index.
+// Bit i lives in byte i/8 at position i%8 (least significant bit first),
+// which matches the layout used by the dataset files.
+type Bitset []byte
+
+// NewBitset builds a Bitset from the list of signer indices carried by a
+// checkpoint's aggregated signature.
+func NewBitset(indices []uint32) Bitset {
+	var b Bitset
+	for _, idx := range indices {
+		b.Set(int(idx))
+	}
+	return b
+}
+
+// Set marks the given index as signed, growing the set if needed.
+func (b *Bitset) Set(index int) {
+	if index < 0 {
+		return
+	}
+	byteIndex := index / 8
+	for byteIndex >= len(*b) {
+		*b = append(*b, 0)
+	}
+	(*b)[byteIndex] |= 1 << (index % 8)
+}
+
+// Has reports whether the given index is set.
+func (b Bitset) Has(index int) bool {
+	if index < 0 || index/8 >= len(b) {
+		return false
+	}
+	return b[index/8]&(1<<(index%8)) != 0
+}
+
+// Count returns the number of set bits.
+func (b Bitset) Count() int {
+	n := 0
+	for _, v := range b {
+		n += bits.OnesCount8(v)
+	}
+	return n
+}
+
+// CheckpointRecord is the raw per-checkpoint entry kept by the store.
+type CheckpointRecord struct {
+	Sequence    uint64    `json:"seq"`
+	Epoch       uint64    `json:"epoch"`
+	Timestamp   time.Time `json:"ts"`
+	Signers     Bitset    `json:"signers"`
+	Planned     Bitset    `json:"planned,omitempty"` // Validators that missed inside a maintenance window
+	SignedPower int       `json:"signed_power"`
+	TotalPower  int       `json:"total_power"`
+}
+
+// Aggregate is a rolled-up view over a run of checkpoints from a single epoch.
+// SignedCounts is indexed by committee bitmap index.
+type Aggregate struct {
+	Epoch          uint64   `json:"epoch"`
+	FirstSeq       uint64   `json:"first_seq"`
+	LastSeq        uint64   `json:"last_seq"`
+	Checkpoints    uint64   `json:"checkpoints"`
+	SignedPowerSum uint64   `json:"signed_power_sum"`
+	MinSignedPower int      `json:"min_signed_power"`
+	TotalPower     int      `json:"total_power"`
+	SignedCounts   []uint64 `json:"signed_counts"`
+}
+
+// MinuteAggregate is an Aggregate bucketed by wall-clock minute.
+type MinuteAggregate struct {
+	Minute time.Time `json:"minute"`
+	Aggregate
+}
+
+// EpochAggregate is an Aggregate covering every recorded checkpoint of an epoch.
+type EpochAggregate struct {
+	Aggregate
+}
+
+// CommitteeMember identifies the validator behind a bitmap index for an epoch.
+type CommitteeMember struct {
+	Name        string `json:"name"`
+	Address     string `json:"address"`
+	VotingPower int    `json:"voting_power"`
+}
+
+// add folds a raw record into the aggregate.
+func (a *Aggregate) add(r CheckpointRecord) {
+	if a.Checkpoints == 0 {
+		a.Epoch = r.Epoch
+		a.FirstSeq = r.Sequence
+		a.MinSignedPower = r.SignedPower
+	}
+	a.LastSeq = r.Sequence
+	a.Checkpoints++
+	a.SignedPowerSum += uint64(r.SignedPower)
+	if r.SignedPower < a.MinSignedPower {
+		a.MinSignedPower = r.SignedPower
+	}
+	a.TotalPower = r.TotalPower
+	for i := 0; i < len(r.Signers)*8; i++ {
+		if !r.Signers.Has(i) {
+			continue
+		}
+		for i >= len(a.SignedCounts) {
+			a.SignedCounts = append(a.SignedCounts, 0)
+		}
+		a.SignedCounts[i]++
+	}
+}
+
+// AvgSignedPower returns the mean signed voting power across the aggregate.
+func (a Aggregate) AvgSignedPower() float64 {
+	if a.Checkpoints == 0 {
+		return 0
+	}
+	return float64(a.SignedPowerSum) / float64(a.Checkpoints)
+}
+
+// Uptime returns the fraction of aggregated checkpoints signed by the given bitmap index.
+func (a Aggregate) Uptime(bitmapIndex int) float64 {
+	if a.Checkpoints == 0 || bitmapIndex < 0 || bitmapIndex >= len(a.SignedCounts) {
+		return 0
+	}
+	return float64(a.SignedCounts[bitmapIndex]) / float64(a.Checkpoints)
+}
@@ -0,0 +1,454 @@
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"suitop/internal/config"
+)
+
+const (
+	rawDirName        = "raw"
+	minutesFileName   = "minutes.ndjson"
+	epochsFileName    = "epochs.ndjson"
+	committeesFile    = "committees.json"
+	segmentTimeLayout = "20060102T15"
+	compactInterval   = 10 * time.Minute
+	defaultTailSize   = 4096
+)
+
+// segment describes one hourly file of raw checkpoint records.
+type segment struct {
+	path     string
+	hour     time.Time
+	firstSeq uint64
+	lastSeq  uint64
+	open     bool
+}
+
+// Store is an embedded, file-backed time-series store of per-checkpoint signer data.
+// Raw records are kept in hourly segments for the configured retention window and
+// are rolled up into per-minute and per-epoch aggregates as they arrive.
+type Store struct {
+	mu  sync.RWMutex
+	cfg config.HistoryConfig
+
+	rawDir   string
+	segments []*segment
+	current  *os.File
+
+	tail []CheckpointRecord
+
+	minutes       []MinuteAggregate // finalized minutes, oldest first
+	currentMinute *MinuteAggregate
+	epochs        []EpochAggregate // finalized epochs, oldest first
+	currentEpoch  *EpochAggregate
+	committees    map[uint64][]CommitteeMember
+
+	lastCompaction time.Time
+}
+
+// Open opens (or creates) a store rooted at cfg.Folder and rebuilds any
+// in-progress aggregates from the raw segments on disk.
+func Open(cfg config.HistoryConfig) (*Store, error) {
+	if cfg.Folder == "" {
+		cfg.Folder = "./data/history"
+	}
+	s := &Store{
+		cfg:        cfg,
+		rawDir:     filepath.Join(cfg.Folder, rawDirName),
+		committees: make(map[uint64][]CommitteeMember),
+	}
+	if err := os.MkdirAll(s.rawDir, 0755); err != nil {
+		return nil, fmt.Errorf("error creating history folder: %w", err)
+	}
+	if err := s.loadSegments(); err != nil {
+		return nil, err
+	}
+	if err := readLines(filepath.Join(cfg.Folder, minutesFileName), func(line []byte) error {
+		var m MinuteAggregate
+		if err := json.Unmarshal(line, &m); err != nil {
+			return err
+		}
+		s.minutes = append(s.minutes, m)
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("error loading minute aggregates: %w", err)
+	}
+	if err := readLines(filepath.Join(cfg.Folder, epochsFileName), func(line []byte) error {
+		var e EpochAggregate
+		if err := json.Unmarshal(line, &e); err != nil {
+			return err
+		}
+		s.epochs = append(s.epochs, e)
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("error loading epoch aggregates: %w", err)
+	}
+	if data, err := os.ReadFile(filepath.Join(cfg.Folder, committeesFile)); err == nil {
+		if err := json.Unmarshal(data, &s.committees); err != nil {
+			return nil, fmt.Errorf("error loading committees: %w", err)
+		}
+	}
+	if err := s.replay(); err != nil {
+		return nil, err
+	}
+	s.compactLocked(time.Now())
+	log.Printf("History store opened at %s with %d raw segments, %d minute and %d epoch aggregates.",
+		cfg.Folder, len(s.segments), len(s.minutes), len(s.epochs))
+	return s, nil
+}
+
+// loadSegments indexes the raw segment files. Segments left open by a previous
+// run are scanned and sealed so that every indexed segment has a known range.
+func (s *Store) loadSegments() error {
+	entries, err := os.ReadDir(s.rawDir)
+	if err != nil {
+		return fmt.Errorf("error reading raw history folder: %w", err)
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".ndjson") {
+			continue
+		}
+		seg, err := parseSegmentName(filepath.Join(s.rawDir, name))
+		if err != nil {
+			log.Printf("Warning: skipping unrecognised history segment %s: %v", name, err)
+			continue
+		}
+		if seg.open {
+			if err := scanSegment(seg.path, func(r CheckpointRecord) {
+				if seg.firstSeq == 0 || r.Sequence < seg.firstSeq {
+					seg.firstSeq = r.Sequence
+				}
+				if r.Sequence > seg.lastSeq {
+					seg.lastSeq = r.Sequence
+				}
+			}); err != nil {
+				return fmt.Errorf("error scanning history segment %s: %w", name, err)
+			}
+			if seg.lastSeq == 0 {
+				os.Remove(seg.path)
+				continue
+			}
+			if err := s.seal(seg); err != nil {
+				return err
+			}
+		}
+		s.segments = append(s.segments, seg)
+	}
+	sort.Slice(s.segments, func(i, j int) bool {
+		return s.segments[i].firstSeq < s.segments[j].firstSeq
+	})
+	return nil
+}
+
+// replay folds raw records newer than the last finalized aggregates back into
+// the in-progress minute and epoch aggregates.
+func (s *Store) replay() error {
+	var lastMinuteSeq, lastEpochSeq uint64
+	if n := len(s.minutes); n > 0 {
+		lastMinuteSeq = s.minutes[n-1].LastSeq
+	}
+	if n := len(s.epochs); n > 0 {
+		lastEpochSeq = s.epochs[n-1].LastSeq
+	}
+	from := lastMinuteSeq
+	if lastEpochSeq < from {
+		from = lastEpochSeq
+	}
+	records, err := s.rangeLocked(from+1, ^uint64(0))
+	if err != nil {
+		return fmt.Errorf("error replaying history: %w", err)
+	}
+	for _, r := range records {
+		if r.Sequence > lastMinuteSeq {
+			s.rollMinute(r)
+		}
+		if r.Sequence > lastEpochSeq {
+			s.rollEpoch(r)
+		}
+		s.pushTail(r)
+	}
+	return nil
+}
+
+// SetCommittee records the committee for an epoch so that signer bitsets can be
+// resolved to validators later on.
+func (s *Store) SetCommittee(epoch uint64, members []CommitteeMember) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.committees[epoch] = append([]CommitteeMember(nil), members...)
+	data, err := json.Marshal(s.committees)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(s.cfg.Folder, committeesFile), data, 0644)
+}
+
+// Record appends a checkpoint to the store and updates the rollups.
+func (s *Store) Record(r CheckpointRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.Timestamp.IsZero() {
+		r.Timestamp = time.Now()
+	}
+	r.Timestamp = r.Timestamp.UTC()
+
+	if err := s.appendRaw(r); err != nil {
+		return err
+	}
+	s.pushTail(r)
+	s.rollMinute(r)
+	s.rollEpoch(r)
+
+	if time.Since(s.lastCompaction) >= compactInterval {
+		s.compactLocked(time.Now())
+	}
+	return nil
+}
+
+func (s *Store) appendRaw(r CheckpointRecord) error {
+	hour := r.Timestamp.Truncate(time.Hour)
+	var seg *segment
+	if n := len(s.segments); n > 0 && s.segments[n-1].open {
+		seg = s.segments[n-1]
+	}
+	if seg != nil && !seg.hour.Equal(hour) {
+		if err := s.closeCurrent(); err != nil {
+			return err
+		}
+		seg = nil
+	}
+	if seg == nil {
+		path := filepath.Join(s.rawDir, hour.Format(segmentTimeLayout)+".ndjson")
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+		if err != nil {
+			return fmt.Errorf("error opening history segment: %w", err)
+		}
+		s.current = f
+		seg = &segment{path: path, hour: hour, firstSeq: r.Sequence, open: true}
+		s.segments = append(s.segments, seg)
+	}
+
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	if _, err := s.current.Write(data); err != nil {
+		return fmt.Errorf("error writing history record: %w", err)
+	}
+	seg.lastSeq = r.Sequence
+	return nil
+}
+
+func (s *Store) pushTail(r CheckpointRecord) {
+	s.tail = append(s.tail, r)
+	if len(s.tail) > defaultTailSize {
+		s.tail = s.tail[len(s.tail)-defaultTailSize:]
+	}
+}
+
+func (s *Store) rollMinute(r CheckpointRecord) {
+	minute := r.Timestamp.Truncate(time.Minute)
+	if s.currentMinute != nil && (!s.currentMinute.Minute.Equal(minute) || s.currentMinute.Epoch != r.Epoch) {
+		s.minutes = append(s.minutes, *s.currentMinute)
+		if err := appendLine(filepath.Join(s.cfg.Folder, minutesFileName), s.currentMinute); err != nil {
+			log.Printf("Warning: failed to persist minute aggregate: %v", err)
+		}
+		s.currentMinute = nil
+	}
+	if s.currentMinute == nil {
+		s.currentMinute = &MinuteAggregate{Minute: minute}
+	}
+	s.currentMinute.add(r)
+}
+
+func (s *Store) rollEpoch(r CheckpointRecord) {
+	if s.currentEpoch != nil && s.currentEpoch.Epoch != r.Epoch {
+		s.epochs = append(s.epochs, *s.currentEpoch)
+		if err := appendLine(filepath.Join(s.cfg.Folder, epochsFileName), s.currentEpoch); err != nil {
+			log.Printf("Warning: failed to persist epoch aggregate: %v", err)
+		}
+		s.currentEpoch = nil
+	}
+	if s.currentEpoch == nil {
+		s.currentEpoch = &EpochAggregate{}
+	}
+	s.currentEpoch.add(r)
+}
+
+// Compact applies the retention windows: raw segments older than RawRetention
+// are deleted and minute aggregates older than MinuteRetention are dropped.
+// Epoch aggregates are kept indefinitely.
+func (s *Store) Compact() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.compactLocked(time.Now())
+}
+
+func (s *Store) compactLocked(now time.Time) {
+	s.lastCompaction = now
+
+	if s.cfg.RawRetention > 0 {
+		cutoff := now.Add(-s.cfg.RawRetention)
+		kept := s.segments[:0]
+		for _, seg := range s.segments {
+			if !seg.open && seg.hour.Add(time.Hour).Before(cutoff) {
+				if err := os.Remove(seg.path); err != nil && !os.IsNotExist(err) {
+					log.Printf("Warning: failed to remove expired history segment %s: %v", seg.path, err)
+					kept = append(kept, seg)
+				}
+				continue
+			}
+			kept = append(kept, seg)
+		}
+		s.segments = kept
+	}
+
+	if s.cfg.MinuteRetention > 0 {
+		cutoff := now.Add(-s.cfg.MinuteRetention)
+		drop := 0
+		for drop < len(s.minutes) && s.minutes[drop].Minute.Before(cutoff) {
+			drop++
+		}
+		if drop > 0 {
+			s.minutes = append([]MinuteAggregate(nil), s.minutes[drop:]...)
+			if err := s.rewriteMinutes(); err != nil {
+				log.Printf("Warning: failed to rewrite minute aggregates: %v", err)
+			}
+		}
+	}
+}
+
+func (s *Store) rewriteMinutes() error {
+	path := filepath.Join(s.cfg.Folder, minutesFileName)
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(f)
+	for _, m := range s.minutes {
+		if err := enc.Encode(m); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Close flushes in-progress aggregates' raw data and seals the open segment.
+// In-progress minute and epoch aggregates are rebuilt from raw data on the next Open.
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.closeCurrent()
+}
+
+func (s *Store) closeCurrent() error {
+	if s.current == nil {
+		return nil
+	}
+	if err := s.current.Close(); err != nil {
+		return err
+	}
+	s.current = nil
+	if n := len(s.segments); n > 0 && s.segments[n-1].open {
+		return s.seal(s.segments[n-1])
+	}
+	return nil
+}
+
+// seal renames an open segment so that its sequence range is encoded in its name.
+func (s *Store) seal(seg *segment) error {
+	name := fmt.Sprintf("%s_%d-%d.ndjson", seg.hour.Format(segmentTimeLayout), seg.firstSeq, seg.lastSeq)
+	path := filepath.Join(filepath.Dir(seg.path), name)
+	if err := os.Rename(seg.path, path); err != nil {
+		return fmt.Errorf("error sealing history segment %s: %w", seg.path, err)
+	}
+	seg.path = path
+	seg.open = false
+	return nil
+}
+
+func parseSegmentName(path string) (*segment, error) {
+	base := strings.TrimSuffix(filepath.Base(path), ".ndjson")
+	hourPart, rangePart, sealed := strings.Cut(base, "_")
+	hour, err := time.Parse(segmentTimeLayout, hourPart)
+	if err != nil {
+		return nil, err
+	}
+	seg := &segment{path: path, hour: hour.UTC(), open: !sealed}
+	if sealed {
+		first, last, ok := strings.Cut(rangePart, "-")
+		if !ok {
+			return nil, fmt.Errorf("malformed sequence range %q", rangePart)
+		}
+		if seg.firstSeq, err = strconv.ParseUint(first, 10, 64); err != nil {
+			return nil, err
+		}
+		if seg.lastSeq, err = strconv.ParseUint(last, 10, 64); err != nil {
+			return nil, err
+		}
+	}
+	return seg, nil
+}
+
+func scanSegment(path string, fn func(CheckpointRecord)) error {
+	return readLines(path, func(line []byte) error {
+		var r CheckpointRecord
+		if err := json.Unmarshal(line, &r); err != nil {
+			// A torn final line after a crash is expected; skip it.
+			return nil
+		}
+		fn(r)
+		return nil
+	})
+}
+
+func readLines(path string, fn func([]byte) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		if err := fn(line); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+func appendLine(path string, v interface{}) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return json.NewEncoder(f).Encode(v)
+}
@@ -0,0 +1,290 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"suitop/internal/config"
+)
+
+// openTestStore opens a store in a temporary folder, closed at the end of the test
+func openTestStore(t *testing.T) *Store {
+	t.Helper()
+	s, err := Open(config.HistoryConfig{Enabled: true, Folder: t.TempDir()})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+// record adds checkpoints from..to of an epoch, one second apart, each signed
+// by the given indices
+func record(t *testing.T, s *Store, epoch, from, to uint64, start time.Time, signers ...uint32) {
+	t.Helper()
+	for seq := from; seq <= to; seq++ {
+		r := CheckpointRecord{
+			Sequence:    seq,
+			Epoch:       epoch,
+			Timestamp:   start.Add(time.Duration(seq-from) * time.Second),
+			Signers:     NewBitset(signers),
+			SignedPower: 100 * len(signers),
+			TotalPower:  400,
+		}
+		if err := s.Record(r); err != nil {
+			t.Fatalf("Record(%d): %v", seq, err)
+		}
+	}
+}
+
+func TestBitset(t *testing.T) {
+	tests := []struct {
+		name    string
+		indices []uint32
+		has     []int
+		hasNot  []int
+		count   int
+	}{
+		{"empty", nil, nil, []int{-1, 0, 7, 8}, 0},
+		{"first byte", []uint32{0, 3, 7}, []int{0, 3, 7}, []int{1, 8}, 3},
+		{"several bytes", []uint32{1, 9, 17, 100}, []int{1, 9, 17, 100}, []int{0, 8, 99, 101, 1000}, 4},
+		{"duplicates", []uint32{5, 5}, []int{5}, []int{4, 6}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBitset(tt.indices)
+			for _, i := range tt.has {
+				if !b.Has(i) {
+					t.Errorf("Has(%d) = false, want true", i)
+				}
+			}
+			for _, i := range tt.hasNot {
+				if b.Has(i) {
+					t.Errorf("Has(%d) = true, want false", i)
+				}
+			}
+			if got := b.Count(); got != tt.count {
+				t.Errorf("Count() = %d, want %d", got, tt.count)
+			}
+		})
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	var a Aggregate
+	if got := a.AvgSignedPower(); got != 0 {
+		t.Errorf("AvgSignedPower() of an empty aggregate = %v, want 0", got)
+	}
+	a.add(CheckpointRecord{Sequence: 10, Epoch: 3, Signers: NewBitset([]uint32{0, 1}), SignedPower: 300, TotalPower: 400})
+	a.add(CheckpointRecord{Sequence: 11, Epoch: 3, Signers: NewBitset([]uint32{0}), SignedPower: 100, TotalPower: 400})
+
+	if a.Epoch != 3 || a.FirstSeq != 10 || a.LastSeq != 11 || a.Checkpoints != 2 {
+		t.Errorf("aggregate = %+v, want epoch 3 over checkpoints 10-11", a)
+	}
+	if got := a.AvgSignedPower(); got != 200 {
+		t.Errorf("AvgSignedPower() = %v, want 200", got)
+	}
+	if a.MinSignedPower != 100 {
+		t.Errorf("MinSignedPower = %d, want 100", a.MinSignedPower)
+	}
+	for _, tt := range []struct {
+		index int
+		want  float64
+	}{{0, 1}, {1, 0.5}, {2, 0}, {-1, 0}} {
+		if got := a.Uptime(tt.index); got != tt.want {
+			t.Errorf("Uptime(%d) = %v, want %v", tt.index, got, tt.want)
+		}
+	}
+}
+
+func TestStoreQueries(t *testing.T) {
+	s := openTestStore(t)
+	if _, ok := s.First(); ok {
+		t.Error("First() of an empty store found a record")
+	}
+	start := time.Now().Add(-time.Hour).Truncate(time.Minute)
+	record(t, s, 1, 100, 109, start, 0, 1)
+	record(t, s, 2, 110, 119, start.Add(10*time.Second), 1)
+
+	tests := []struct {
+		name     string
+		from, to uint64
+		want     []uint64
+	}{
+		{"inside", 102, 104, []uint64{102, 103, 104}},
+		{"across epochs", 108, 111, []uint64{108, 109, 110, 111}},
+		{"clipped", 118, 200, []uint64{118, 119}},
+		{"before", 0, 99, nil},
+		{"empty", 105, 104, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records, err := s.Range(tt.from, tt.to)
+			if err != nil {
+				t.Fatalf("Range: %v", err)
+			}
+			var got []uint64
+			for _, r := range records {
+				got = append(got, r.Sequence)
+			}
+			if !equalSeqs(got, tt.want) {
+				t.Errorf("Range(%d, %d) = %v, want %v", tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+
+	if first, ok := s.First(); !ok || first != 100 {
+		t.Errorf("First() = %d, %v; want 100", first, ok)
+	}
+
+	latest := s.Latest(3)
+	if len(latest) != 3 || latest[0].Sequence != 117 || latest[2].Sequence != 119 {
+		t.Errorf("Latest(3) = %v, want checkpoints 117-119", latest)
+	}
+	if got := s.Latest(0); got != nil {
+		t.Errorf("Latest(0) = %v, want nil", got)
+	}
+
+	if r, ok, err := s.Get(105); err != nil || !ok || r.Epoch != 1 || r.Signers.Count() != 2 {
+		t.Errorf("Get(105) = %+v, %v, %v; want epoch 1 with 2 signers", r, ok, err)
+	}
+	if _, ok, err := s.Get(500); err != nil || ok {
+		t.Errorf("Get(500) = %v, %v; want not found", ok, err)
+	}
+
+	epochs := s.Epochs()
+	if len(epochs) != 2 || epochs[0].Epoch != 1 || epochs[1].Epoch != 2 {
+		t.Fatalf("Epochs() = %+v, want epochs 1 and 2", epochs)
+	}
+	if e, ok := s.Epoch(1); !ok || e.Checkpoints != 10 || e.Uptime(0) != 1 {
+		t.Errorf("Epoch(1) = %+v, %v; want 10 checkpoints all signed by index 0", e, ok)
+	}
+	if e, ok := s.Epoch(2); !ok || e.Uptime(0) != 0 || e.Uptime(1) != 1 {
+		t.Errorf("Epoch(2) = %+v, %v; want index 1 only", e, ok)
+	}
+	if _, ok := s.Epoch(9); ok {
+		t.Error("Epoch(9) found, want missing")
+	}
+
+	minutes := s.Minutes(start, start.Add(time.Hour))
+	total := uint64(0)
+	for _, m := range minutes {
+		total += m.Checkpoints
+	}
+	if total != 20 {
+		t.Errorf("Minutes() cover %d checkpoints, want 20", total)
+	}
+}
+
+func TestStoreCommittee(t *testing.T) {
+	s := openTestStore(t)
+	members := []CommitteeMember{
+		{Name: "alpha", Address: "0xa", VotingPower: 300},
+		{Name: "beta", Address: "0xb", VotingPower: 100},
+	}
+	if err := s.SetCommittee(1, members); err != nil {
+		t.Fatalf("SetCommittee: %v", err)
+	}
+	members[0].Name = "changed by caller"
+	record(t, s, 1, 1, 3, time.Now().Add(-time.Minute), 1)
+
+	tests := []struct {
+		seq     uint64
+		address string
+		signed  bool
+		known   bool
+	}{
+		{2, "0xa", false, true},
+		{2, "0xb", true, true},
+		{2, "0xc", false, false},
+		{9, "0xb", false, false},
+	}
+	for _, tt := range tests {
+		signed, known, err := s.SignedBy(tt.seq, tt.address)
+		if err != nil || signed != tt.signed || known != tt.known {
+			t.Errorf("SignedBy(%d, %s) = %v, %v, %v; want %v, %v", tt.seq, tt.address, signed, known, err, tt.signed, tt.known)
+		}
+	}
+
+	committee := s.Committee(1)
+	if len(committee) != 2 || committee[0].Name != "alpha" {
+		t.Fatalf("Committee(1) = %+v, want alpha and beta", committee)
+	}
+	committee[0].Name = "changed by reader"
+	if got := s.Committee(1)[0].Name; got != "alpha" {
+		t.Errorf("Committee(1) shares its slice with callers: name %q", got)
+	}
+	if got := s.BitmapIndex(1, "0xb"); got != 1 {
+		t.Errorf("BitmapIndex(1, 0xb) = %d, want 1", got)
+	}
+	if got := s.BitmapIndex(2, "0xb"); got != -1 {
+		t.Errorf("BitmapIndex(2, 0xb) = %d, want -1", got)
+	}
+}
+
+func TestStoreReopen(t *testing.T) {
+	folder := t.TempDir()
+	s, err := Open(config.HistoryConfig{Enabled: true, Folder: folder})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if err := s.SetCommittee(4, []CommitteeMember{{Name: "alpha", Address: "0xa"}}); err != nil {
+		t.Fatalf("SetCommittee: %v", err)
+	}
+	record(t, s, 4, 50, 59, time.Now().Add(-time.Minute), 0)
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	s, err = Open(config.HistoryConfig{Enabled: true, Folder: folder})
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer s.Close()
+	if latest := s.Latest(1); len(latest) != 1 || latest[0].Sequence != 59 {
+		t.Errorf("Latest(1) after reopening = %v, want checkpoint 59", latest)
+	}
+	if e, ok := s.Epoch(4); !ok || e.Checkpoints != 10 {
+		t.Errorf("Epoch(4) after reopening = %+v, %v; want 10 checkpoints", e, ok)
+	}
+	if got := s.BitmapIndex(4, "0xa"); got != 0 {
+		t.Errorf("BitmapIndex(4, 0xa) after reopening = %d, want 0", got)
+	}
+}
+
+func TestStoreRetention(t *testing.T) {
+	s, err := Open(config.HistoryConfig{Enabled: true, Folder: t.TempDir(), RawRetention: time.Hour})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer s.Close()
+	old := time.Now().Add(-3 * time.Hour)
+	record(t, s, 1, 1, 5, old, 0)
+	record(t, s, 1, 6, 10, time.Now().Add(-time.Minute), 0) // Seals the old segment
+
+	s.Compact()
+	records, err := s.Between(old.Add(-time.Hour), time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("Between: %v", err)
+	}
+	for _, r := range records {
+		if r.Sequence <= 5 {
+			t.Errorf("checkpoint %d outlived the raw retention", r.Sequence)
+		}
+	}
+	if len(records) != 5 {
+		t.Errorf("Between() returned %d records, want 5", len(records))
+	}
+}
+
+func equalSeqs(a, b []uint64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
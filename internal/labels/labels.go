@@ -0,0 +1,167 @@
+// Package labels maps validators to local display names, operator groups and
+// tags. On-chain names are chosen by each operator and are often inconsistent;
+// a labels file gives them the names an operator knows them by and groups the
+// validators run by the same entity.
+package labels
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"suitop/internal/types"
+)
+
+// Label is the local metadata of one validator. Every field is optional.
+type Label struct {
+	Name  string   `yaml:"name"`  // Display name, replacing the on-chain name
+	Group string   `yaml:"group"` // Operator group
+	Tags  []string `yaml:"tags"`  // Free-form tags
+}
+
+// file is the on-disk format of a labels file: labels keyed by Sui address or
+// protocol pubkey.
+type file struct {
+	Validators map[string]Label `yaml:"validators"`
+}
+
+// Set is the labels of a labels file. A nil *Set has no labels.
+type Set struct {
+	byAddress map[string]Label // Keyed by lower-case address
+	byPubkey  map[string]Label
+}
+
+// ReadFile loads the labels of path, keyed as in the file. A missing file has
+// no labels.
+func ReadFile(path string) (map[string]Label, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading labels file: %w", err)
+	}
+	// Unknown keys are rejected so that a misspelt field is not silently ignored
+	var f file
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&f); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("error parsing labels file %s: %w", path, err)
+	}
+	for key, l := range f.Validators {
+		if strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("empty validator key in labels file %s", path)
+		}
+		if l.Name == "" && l.Group == "" && len(l.Tags) == 0 {
+			return nil, fmt.Errorf("validator %s in labels file %s has no name, group or tags", key, path)
+		}
+	}
+	return f.Validators, nil
+}
+
+// Open loads the labels of path. It returns nil if path is empty or the file
+// has no labels.
+func Open(path string) (*Set, error) {
+	if path == "" {
+		return nil, nil
+	}
+	labels, err := ReadFile(path)
+	if err != nil || len(labels) == 0 {
+		return nil, err
+	}
+	s := &Set{byAddress: make(map[string]Label), byPubkey: make(map[string]Label)}
+	for key, l := range labels {
+		l.Name = strings.TrimSpace(l.Name)
+		l.Group = strings.TrimSpace(l.Group)
+		if strings.HasPrefix(key, "0x") {
+			s.byAddress[strings.ToLower(key)] = l
+		} else {
+			s.byPubkey[key] = l
+		}
+	}
+	return s, nil
+}
+
+// Len returns the number of labelled validators.
+func (s *Set) Len() int {
+	if s == nil {
+		return 0
+	}
+	return len(s.byAddress) + len(s.byPubkey)
+}
+
+// Lookup returns the label of the validator with the given address or protocol
+// pubkey. A label keyed by address takes precedence.
+func (s *Set) Lookup(address, pubkey string) (Label, bool) {
+	if s == nil {
+		return Label{}, false
+	}
+	if l, ok := s.byAddress[strings.ToLower(address)]; ok {
+		return l, true
+	}
+	l, ok := s.byPubkey[pubkey]
+	return l, ok
+}
+
+// Group is the aggregate status of the committee members of one operator group.
+type Group struct {
+	Name        string
+	Members     int
+	Signing     int      // Members that signed the latest checkpoint
+	VotingPower int      // Combined voting power of the members
+	Uptime      float64  // Uptime of the members weighted by voting power
+	HasUptime   bool     // False when no member has an uptime, e.g. all were in maintenance
+	Missing     []string // Names of the members missing the latest checkpoint outside maintenance, sorted
+}
+
+// Groups aggregates the stats of the committee by group, sorted by group name.
+// Validators without a group are left out.
+func Groups(committee []types.ValidatorInfo, stats map[string]types.ValidatorStats, totalWithSig uint64) []Group {
+	byName := make(map[string]*Group)
+	weighted := make(map[string]float64)
+	measured := make(map[string]int) // Voting power of the members with an uptime
+	for _, v := range committee {
+		if v.Group == "" {
+			continue
+		}
+		g, ok := byName[v.Group]
+		if !ok {
+			g = &Group{Name: v.Group}
+			byName[v.Group] = g
+		}
+		g.Members++
+		g.VotingPower += v.VotingPower
+		s, ok := stats[v.SuiAddress]
+		if !ok {
+			continue
+		}
+		if s.HasUptime(totalWithSig) {
+			weighted[v.Group] += float64(v.VotingPower) * s.Uptime(totalWithSig)
+			measured[v.Group] += v.VotingPower
+		}
+		switch {
+		case s.SignedCurrent:
+			g.Signing++
+		case !s.InMaintenance:
+			g.Missing = append(g.Missing, v.Name)
+		}
+	}
+
+	groups := make([]Group, 0, len(byName))
+	for name, g := range byName {
+		if measured[name] > 0 {
+			g.Uptime = weighted[name] / float64(measured[name])
+			g.HasUptime = true
+		}
+		sort.Strings(g.Missing)
+		groups = append(groups, *g)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Name < groups[j].Name })
+	return groups
+}
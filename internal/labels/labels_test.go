@@ -0,0 +1,129 @@
+package labels
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"suitop/internal/types"
+)
+
+func writeLabelsFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "labels.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestReadFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    int
+		wantErr string
+	}{
+		{"labels", "validators:\n  0xAB: {name: Alpha, group: Ops}\n  pk1: {tags: [eu]}\n", 2, ""},
+		{"empty file", "", 0, ""},
+		{"unknown top-level key", "validator:\n  0xab: {name: Alpha}\n", 0, "field validator not found"},
+		{"unknown label key", "validators:\n  0xab: {nmae: Alpha}\n", 0, "field nmae not found"},
+		{"empty label", "validators:\n  0xab: {}\n", 0, "has no name, group or tags"},
+		{"empty key", "validators:\n  ' ': {name: Alpha}\n", 0, "empty validator key"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadFile(writeLabelsFile(t, tt.content))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ReadFile() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReadFile: %v", err)
+			}
+			if len(got) != tt.want {
+				t.Errorf("ReadFile() returned %d labels, want %d", len(got), tt.want)
+			}
+		})
+	}
+
+	if got, err := ReadFile(filepath.Join(t.TempDir(), "missing.yaml")); err != nil || got != nil {
+		t.Errorf("ReadFile(missing) = %v, %v; want no labels", got, err)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	s, err := Open(writeLabelsFile(t, `
+validators:
+  0xAB: {name: " Alpha ", group: Ops}
+  pkA: {name: Alpha by pubkey}
+  pkB: {group: Peers, tags: [eu]}
+`))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if s.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", s.Len())
+	}
+	tests := []struct {
+		name    string
+		address string
+		pubkey  string
+		want    Label
+		found   bool
+	}{
+		{"address in any case", "0xab", "", Label{Name: "Alpha", Group: "Ops"}, true},
+		{"address before pubkey", "0xAb", "pkA", Label{Name: "Alpha", Group: "Ops"}, true},
+		{"pubkey", "0xcd", "pkB", Label{Group: "Peers", Tags: []string{"eu"}}, true},
+		{"unlabelled", "0xcd", "pkC", Label{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := s.Lookup(tt.address, tt.pubkey)
+			if ok != tt.found || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Lookup(%s, %s) = %+v, %v; want %+v, %v", tt.address, tt.pubkey, got, ok, tt.want, tt.found)
+			}
+		})
+	}
+
+	var none *Set
+	if _, ok := none.Lookup("0xab", "pkA"); ok || none.Len() != 0 {
+		t.Error("a nil set must have no labels")
+	}
+	if s, err := Open(""); s != nil || err != nil {
+		t.Errorf("Open(\"\") = %v, %v; want nil", s, err)
+	}
+}
+
+func TestGroups(t *testing.T) {
+	committee := []types.ValidatorInfo{
+		{Name: "a1", SuiAddress: "0xa1", VotingPower: 300, Group: "A"},
+		{Name: "a2", SuiAddress: "0xa2", VotingPower: 100, Group: "A"},
+		{Name: "a3", SuiAddress: "0xa3", VotingPower: 100, Group: "A"},
+		{Name: "b1", SuiAddress: "0xb1", VotingPower: 200, Group: "B"},
+		{Name: "b2", SuiAddress: "0xb2", VotingPower: 200, Group: "B"},
+		{Name: "solo", SuiAddress: "0xs", VotingPower: 500},
+	}
+	stats := map[string]types.ValidatorStats{
+		"0xa1": {AttestedCount: 10, SignedCurrent: true},
+		"0xa2": {AttestedCount: 5},
+		"0xa3": {PlannedMisses: 10, InMaintenance: true}, // No uptime
+		"0xb1": {PlannedMisses: 10, InMaintenance: true},
+		"0xb2": {PlannedMisses: 10, InMaintenance: true},
+		"0xs":  {AttestedCount: 10, SignedCurrent: true},
+	}
+	groups := Groups(committee, stats, 10)
+	want := []Group{
+		// Weighted over the members with an uptime: (300*1 + 100*0.5) / 400
+		{Name: "A", Members: 3, Signing: 1, VotingPower: 500, Uptime: 0.875, HasUptime: true, Missing: []string{"a2"}},
+		// Every member was in maintenance the whole time
+		{Name: "B", Members: 2, Signing: 0, VotingPower: 400, Uptime: 0, HasUptime: false},
+	}
+	if !reflect.DeepEqual(groups, want) {
+		t.Errorf("Groups() =\n%+v\nwant\n%+v", groups, want)
+	}
+}
@@ -0,0 +1,268 @@
+package maintenance
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const reloadInterval = 5 * time.Second
+
+// Window is a planned maintenance period for one validator. It is bounded by
+// wall-clock time, by checkpoint sequence numbers, or both; unset bounds are open.
+type Window struct {
+	ID        string `yaml:"id"`
+	Validator string `yaml:"validator"` // Name or address
+	Reason    string `yaml:"reason,omitempty"`
+
+	Start time.Time `yaml:"start,omitempty"`
+	End   time.Time `yaml:"end,omitempty"`
+
+	FromCheckpoint uint64 `yaml:"from_checkpoint,omitempty"`
+	ToCheckpoint   uint64 `yaml:"to_checkpoint,omitempty"`
+}
+
+// Covers reports whether the window applies to the validator at the given
+// checkpoint sequence and timestamp. names are the validator's display name
+// and on-chain name, either of which the window may use.
+func (w Window) Covers(address string, names []string, seq uint64, ts time.Time) bool {
+	if !w.matches(address, names) {
+		return false
+	}
+	if !w.Start.IsZero() && ts.Before(w.Start) {
+		return false
+	}
+	if !w.End.IsZero() && !ts.Before(w.End) {
+		return false
+	}
+	if w.FromCheckpoint > 0 && seq < w.FromCheckpoint {
+		return false
+	}
+	if w.ToCheckpoint > 0 && seq > w.ToCheckpoint {
+		return false
+	}
+	return true
+}
+
+func (w Window) matches(address string, names []string) bool {
+	if strings.EqualFold(w.Validator, address) {
+		return true
+	}
+	for _, name := range names {
+		if w.Validator == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Expired reports whether the window can no longer cover any checkpoint at or
+// after now. Windows bounded only by checkpoints never expire on their own.
+func (w Window) Expired(now time.Time) bool {
+	return !w.End.IsZero() && !now.Before(w.End)
+}
+
+// Validate checks that the window names a validator and has at least one bound.
+func (w Window) Validate() error {
+	if strings.TrimSpace(w.Validator) == "" {
+		return errors.New("validator is required")
+	}
+	if w.Start.IsZero() && w.End.IsZero() && w.FromCheckpoint == 0 && w.ToCheckpoint == 0 {
+		return errors.New("a time range or a checkpoint range is required")
+	}
+	if !w.Start.IsZero() && !w.End.IsZero() && !w.End.After(w.Start) {
+		return errors.New("end must be after start")
+	}
+	if w.FromCheckpoint > 0 && w.ToCheckpoint > 0 && w.ToCheckpoint < w.FromCheckpoint {
+		return errors.New("to_checkpoint must not be before from_checkpoint")
+	}
+	return nil
+}
+
+// String describes the window on one line.
+func (w Window) String() string {
+	var bounds []string
+	if !w.Start.IsZero() || !w.End.IsZero() {
+		bounds = append(bounds, fmt.Sprintf("%s → %s", formatTime(w.Start), formatTime(w.End)))
+	}
+	if w.FromCheckpoint > 0 || w.ToCheckpoint > 0 {
+		bounds = append(bounds, fmt.Sprintf("checkpoints %s → %s", formatSeq(w.FromCheckpoint), formatSeq(w.ToCheckpoint)))
+	}
+	s := fmt.Sprintf("%s  %s  %s", w.ID, w.Validator, strings.Join(bounds, ", "))
+	if w.Reason != "" {
+		s += "  (" + w.Reason + ")"
+	}
+	return s
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "…"
+	}
+	return t.Local().Format("2006-01-02 15:04 MST")
+}
+
+func formatSeq(seq uint64) string {
+	if seq == 0 {
+		return "…"
+	}
+	return fmt.Sprint(seq)
+}
+
+// file is the on-disk format of a maintenance file.
+type file struct {
+	Windows []Window `yaml:"windows"`
+}
+
+// ReadFile loads the windows from path. A missing file has no windows.
+func ReadFile(path string) ([]Window, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading maintenance file: %w", err)
+	}
+	// Unknown keys are rejected so that a typo does not silently widen or drop a window
+	var f file
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&f); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("error parsing maintenance file %s: %w", path, err)
+	}
+	for i, w := range f.Windows {
+		if err := w.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid maintenance window %d (%s) in %s: %w", i, w.ID, path, err)
+		}
+	}
+	return f.Windows, nil
+}
+
+// WriteFile atomically replaces the windows in path.
+func WriteFile(path string, windows []Window) error {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(file{Windows: windows}); err != nil {
+		return err
+	}
+	data := buf.Bytes()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("error creating maintenance folder: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("error writing maintenance file: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// NewID returns a short random window identifier.
+func NewID() string {
+	b := make([]byte, 4)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// Schedule holds the windows of a maintenance file and picks up changes made
+// to it while suitop is running, e.g. by `suitop maintenance add`.
+// A nil *Schedule has no windows.
+type Schedule struct {
+	path string
+
+	mu      sync.RWMutex
+	windows []Window
+	modTime time.Time
+}
+
+// Open loads the schedule from path.
+func Open(path string) (*Schedule, error) {
+	s := &Schedule{path: path}
+	if err := s.reload(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Active returns the window covering the validator at the given checkpoint, if any.
+func (s *Schedule) Active(address string, names []string, seq uint64, ts time.Time) (Window, bool) {
+	if s == nil {
+		return Window{}, false
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, w := range s.windows {
+		if w.Covers(address, names, seq, ts) {
+			return w, true
+		}
+	}
+	return Window{}, false
+}
+
+// Windows returns a copy of the scheduled windows.
+func (s *Schedule) Windows() []Window {
+	if s == nil {
+		return nil
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]Window(nil), s.windows...)
+}
+
+// Run reloads the file whenever it changes until ctx is done.
+func (s *Schedule) Run(ctx context.Context) {
+	ticker := time.NewTicker(reloadInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.reload(); err != nil {
+				log.Printf("Failed to reload maintenance windows, keeping the previous ones: %v", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *Schedule) reload() error {
+	var modTime time.Time
+	if info, err := os.Stat(s.path); err == nil {
+		modTime = info.ModTime()
+	}
+	s.mu.RLock()
+	unchanged := modTime.Equal(s.modTime) && s.windows != nil
+	s.mu.RUnlock()
+	if unchanged {
+		return nil
+	}
+
+	windows, err := ReadFile(s.path)
+	if err != nil {
+		return err
+	}
+	if windows == nil {
+		windows = []Window{}
+	}
+	s.mu.Lock()
+	changed := s.windows != nil
+	s.windows = windows
+	s.modTime = modTime
+	s.mu.Unlock()
+	if changed {
+		log.Printf("Reloaded %d maintenance windows from %s", len(windows), s.path)
+	}
+	return nil
+}
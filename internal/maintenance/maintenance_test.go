@@ -0,0 +1,78 @@
+package maintenance
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWindowCovers(t *testing.T) {
+	start := time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	tests := []struct {
+		name    string
+		window  Window
+		address string
+		names   []string
+		seq     uint64
+		ts      time.Time
+		want    bool
+	}{
+		{"address in any case", Window{Validator: "0xAB", FromCheckpoint: 1}, "0xab", []string{"Alpha"}, 5, start, true},
+		{"display name", Window{Validator: "Alpha (fra-1)", FromCheckpoint: 1}, "0xab", []string{"Alpha (fra-1)", "alpha-validator"}, 5, start, true},
+		{"on-chain name of a renamed validator", Window{Validator: "alpha-validator", FromCheckpoint: 1}, "0xab", []string{"Alpha (fra-1)", "alpha-validator"}, 5, start, true},
+		{"other validator", Window{Validator: "beta", FromCheckpoint: 1}, "0xab", []string{"Alpha (fra-1)", "alpha-validator"}, 5, start, false},
+		{"names are case sensitive", Window{Validator: "alpha", FromCheckpoint: 1}, "0xab", []string{"Alpha"}, 5, start, false},
+		{"inside time range", Window{Validator: "0xab", Start: start, End: end}, "0xab", nil, 5, start.Add(time.Minute), true},
+		{"before start", Window{Validator: "0xab", Start: start, End: end}, "0xab", nil, 5, start.Add(-time.Second), false},
+		{"end is exclusive", Window{Validator: "0xab", Start: start, End: end}, "0xab", nil, 5, end, false},
+		{"inside checkpoint range", Window{Validator: "0xab", FromCheckpoint: 10, ToCheckpoint: 20}, "0xab", nil, 20, start, true},
+		{"after checkpoint range", Window{Validator: "0xab", FromCheckpoint: 10, ToCheckpoint: 20}, "0xab", nil, 21, start, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.window.Covers(tt.address, tt.names, tt.seq, tt.ts); got != tt.want {
+				t.Errorf("Covers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNilScheduleHasNoWindows(t *testing.T) {
+	var s *Schedule
+	if _, ok := s.Active("0xab", []string{"Alpha"}, 1, time.Now()); ok {
+		t.Error("nil schedule reported an active window")
+	}
+	if s.Windows() != nil {
+		t.Error("nil schedule has windows")
+	}
+}
+
+func TestReadFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    int
+		wantErr bool
+	}{
+		{"window", "windows:\n  - id: a\n    validator: Alpha\n    from_checkpoint: 10\n", 1, false},
+		{"empty", "", 0, false},
+		{"unknown key", "windows:\n  - id: a\n    validator: Alpha\n    from_checkpiont: 10\n", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "maintenance.yaml")
+			if err := os.WriteFile(path, []byte(tt.data), 0644); err != nil {
+				t.Fatal(err)
+			}
+			got, err := ReadFile(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadFile() error = %v, want error %v", err, tt.wantErr)
+			}
+			if len(got) != tt.want {
+				t.Errorf("ReadFile() = %+v, want %d windows", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"suitop/internal/types"
+)
+
+// rateWindow is the sliding window used to compute the checkpoint processing rate.
+const rateWindow = time.Minute
+
+var (
+	validatorLabels = []string{"validator", "address"}
+
+	uptimeDesc = prometheus.NewDesc(namespace+"_validator_uptime_ratio",
+		"Fraction of processed checkpoints signed by the validator, excluding planned maintenance.", validatorLabels, nil)
+	plannedMissesDesc = prometheus.NewDesc(namespace+"_validator_planned_misses_total",
+		"Checkpoints missed by the validator inside a maintenance window.", validatorLabels, nil)
+	unplannedMissesDesc = prometheus.NewDesc(namespace+"_validator_unplanned_misses_total",
+		"Checkpoints missed by the validator outside maintenance windows.", validatorLabels, nil)
+	signedCurrentDesc = prometheus.NewDesc(namespace+"_validator_signed_current",
+		"Whether the validator signed the most recently processed checkpoint.", validatorLabels, nil)
+	missStreakDesc = prometheus.NewDesc(namespace+"_validator_miss_streak",
+		"Consecutive checkpoints missed by the validator.", validatorLabels, nil)
+	lastSignedDesc = prometheus.NewDesc(namespace+"_validator_last_signed_sequence",
+		"Sequence number of the last checkpoint signed by the validator.", validatorLabels, nil)
+	votingPowerDesc = prometheus.NewDesc(namespace+"_validator_voting_power",
+		"Committee voting power of the validator.", validatorLabels, nil)
+
+	committeePowerDesc = prometheus.NewDesc(namespace+"_committee_voting_power",
+		"Total voting power of the current committee.", nil, nil)
+	committeeSizeDesc = prometheus.NewDesc(namespace+"_committee_size",
+		"Number of validators in the current committee.", nil, nil)
+	signedPowerDesc = prometheus.NewDesc(namespace+"_checkpoint_signed_voting_power",
+		"Voting power that signed the most recently processed checkpoint.", nil, nil)
+	signerCountDesc = prometheus.NewDesc(namespace+"_checkpoint_signer_count",
+		"Number of validators that signed the most recently processed checkpoint.", nil, nil)
+	epochDesc = prometheus.NewDesc(namespace+"_epoch",
+		"Current epoch.", nil, nil)
+	sequenceDesc = prometheus.NewDesc(namespace+"_checkpoint_sequence",
+		"Sequence number of the most recently processed checkpoint.", nil, nil)
+	processedDesc = prometheus.NewDesc(namespace+"_checkpoints_processed_total",
+		"Number of checkpoints with signatures processed.", nil, nil)
+	rateDesc = prometheus.NewDesc(namespace+"_checkpoint_processing_rate",
+		"Checkpoints processed per second over the last minute.", nil, nil)
+)
+
+// SnapshotCollector exposes the latest processor snapshot as Prometheus metrics.
+type SnapshotCollector struct {
+	mu       sync.RWMutex
+	snapshot *types.SnapshotMsg
+	seen     []time.Time
+}
+
+// NewSnapshotCollector creates an empty collector. Feed it with Observe.
+func NewSnapshotCollector() *SnapshotCollector {
+	return &SnapshotCollector{}
+}
+
+// Observe stores the latest snapshot. It is meant to be registered as a
+// processor snapshot hook.
+func (c *SnapshotCollector) Observe(msg types.SnapshotMsg) {
+	now := time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.snapshot = &msg
+	c.seen = append(c.seen, now)
+	cutoff := now.Add(-rateWindow)
+	drop := 0
+	for drop < len(c.seen) && c.seen[drop].Before(cutoff) {
+		drop++
+	}
+	c.seen = c.seen[drop:]
+}
+
+// Describe implements prometheus.Collector.
+func (c *SnapshotCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, d := range []*prometheus.Desc{
+		uptimeDesc, plannedMissesDesc, unplannedMissesDesc, signedCurrentDesc, missStreakDesc, lastSignedDesc, votingPowerDesc,
+		committeePowerDesc, committeeSizeDesc, signedPowerDesc, signerCountDesc,
+		epochDesc, sequenceDesc, processedDesc, rateDesc,
+	} {
+		ch <- d
+	}
+}
+
+// Collect implements prometheus.Collector.
+func (c *SnapshotCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.snapshot == nil {
+		return
+	}
+	s := c.snapshot
+
+	signers := 0
+	for _, v := range s.Committee {
+		stats, ok := s.Stats[v.SuiAddress]
+		if !ok {
+			continue
+		}
+		signed := 0.0
+		if stats.SignedCurrent {
+			signed = 1
+			signers++
+		}
+		if stats.HasUptime(s.TotalWithSig) { // Unknown while every checkpoint was missed during maintenance
+			ch <- prometheus.MustNewConstMetric(uptimeDesc, prometheus.GaugeValue, stats.Uptime(s.TotalWithSig), v.Name, v.SuiAddress)
+		}
+		ch <- prometheus.MustNewConstMetric(plannedMissesDesc, prometheus.CounterValue, float64(stats.PlannedMisses), v.Name, v.SuiAddress)
+		ch <- prometheus.MustNewConstMetric(unplannedMissesDesc, prometheus.CounterValue, float64(stats.UnplannedMisses(s.TotalWithSig)), v.Name, v.SuiAddress)
+		ch <- prometheus.MustNewConstMetric(signedCurrentDesc, prometheus.GaugeValue, signed, v.Name, v.SuiAddress)
+		ch <- prometheus.MustNewConstMetric(missStreakDesc, prometheus.GaugeValue, float64(stats.MissStreak), v.Name, v.SuiAddress)
+		ch <- prometheus.MustNewConstMetric(lastSignedDesc, prometheus.GaugeValue, float64(stats.LastSignedSeq), v.Name, v.SuiAddress)
+		ch <- prometheus.MustNewConstMetric(votingPowerDesc, prometheus.GaugeValue, float64(v.VotingPower), v.Name, v.SuiAddress)
+	}
+
+	rate := 0.0
+	if n := len(c.seen); n > 1 {
+		if elapsed := c.seen[n-1].Sub(c.seen[0]).Seconds(); elapsed > 0 {
+			rate = float64(n-1) / elapsed
+		}
+	}
+
+	ch <- prometheus.MustNewConstMetric(committeePowerDesc, prometheus.GaugeValue, float64(s.TotalPower))
+	ch <- prometheus.MustNewConstMetric(committeeSizeDesc, prometheus.GaugeValue, float64(len(s.Committee)))
+	ch <- prometheus.MustNewConstMetric(signedPowerDesc, prometheus.GaugeValue, float64(s.SignedPower))
+	ch <- prometheus.MustNewConstMetric(signerCountDesc, prometheus.GaugeValue, float64(signers))
+	ch <- prometheus.MustNewConstMetric(epochDesc, prometheus.GaugeValue, float64(s.Epoch))
+	ch <- prometheus.MustNewConstMetric(sequenceDesc, prometheus.GaugeValue, float64(s.CheckpointSeq))
+	ch <- prometheus.MustNewConstMetric(processedDesc, prometheus.CounterValue, float64(s.TotalWithSig))
+	ch <- prometheus.MustNewConstMetric(rateDesc, prometheus.GaugeValue, rate)
+}
@@ -0,0 +1,120 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"suitop/internal/types"
+)
+
+// testSnapshot is checkpoint 42 of epoch 7, after 10 checkpoints: alice signed
+// all, bob is on a miss streak and carol missed every one in maintenance
+func testSnapshot() types.SnapshotMsg {
+	return types.SnapshotMsg{
+		Epoch:         7,
+		CheckpointSeq: 42,
+		TotalWithSig:  10,
+		SignedPower:   5000,
+		TotalPower:    10000,
+		Committee: []types.ValidatorInfo{
+			{Name: "alice", SuiAddress: "0xa", BitmapIndex: 0, VotingPower: 5000},
+			{Name: "bob", SuiAddress: "0xb", BitmapIndex: 1, VotingPower: 3000},
+			{Name: "carol", SuiAddress: "0xc", BitmapIndex: 2, VotingPower: 2000},
+		},
+		Stats: map[string]types.ValidatorStats{
+			"0xa": {AttestedCount: 10, SignedCurrent: true, LastSignedSeq: 42},
+			"0xb": {AttestedCount: 6, MissStreak: 4, LastSignedSeq: 38},
+			"0xc": {PlannedMisses: 10, MissStreak: 10, InMaintenance: true},
+		},
+	}
+}
+
+func TestSnapshotCollector(t *testing.T) {
+	tests := []struct {
+		name    string
+		metrics []string
+		want    string
+	}{
+		{
+			name:    "uptime",
+			metrics: []string{"suitop_validator_uptime_ratio", "suitop_validator_unplanned_misses_total"},
+			want: `
+# HELP suitop_validator_uptime_ratio Fraction of processed checkpoints signed by the validator, excluding planned maintenance.
+# TYPE suitop_validator_uptime_ratio gauge
+suitop_validator_uptime_ratio{address="0xa",validator="alice"} 1
+suitop_validator_uptime_ratio{address="0xb",validator="bob"} 0.6
+# HELP suitop_validator_unplanned_misses_total Checkpoints missed by the validator outside maintenance windows.
+# TYPE suitop_validator_unplanned_misses_total counter
+suitop_validator_unplanned_misses_total{address="0xa",validator="alice"} 0
+suitop_validator_unplanned_misses_total{address="0xb",validator="bob"} 4
+suitop_validator_unplanned_misses_total{address="0xc",validator="carol"} 0
+`,
+		},
+		{
+			name:    "streak",
+			metrics: []string{"suitop_validator_miss_streak", "suitop_validator_signed_current"},
+			want: `
+# HELP suitop_validator_miss_streak Consecutive checkpoints missed by the validator.
+# TYPE suitop_validator_miss_streak gauge
+suitop_validator_miss_streak{address="0xa",validator="alice"} 0
+suitop_validator_miss_streak{address="0xb",validator="bob"} 4
+suitop_validator_miss_streak{address="0xc",validator="carol"} 10
+# HELP suitop_validator_signed_current Whether the validator signed the most recently processed checkpoint.
+# TYPE suitop_validator_signed_current gauge
+suitop_validator_signed_current{address="0xa",validator="alice"} 1
+suitop_validator_signed_current{address="0xb",validator="bob"} 0
+suitop_validator_signed_current{address="0xc",validator="carol"} 0
+`,
+		},
+		{
+			name:    "quorum",
+			metrics: []string{"suitop_committee_voting_power", "suitop_checkpoint_signed_voting_power", "suitop_checkpoint_signer_count", "suitop_committee_size"},
+			want: `
+# HELP suitop_committee_voting_power Total voting power of the current committee.
+# TYPE suitop_committee_voting_power gauge
+suitop_committee_voting_power 10000
+# HELP suitop_checkpoint_signed_voting_power Voting power that signed the most recently processed checkpoint.
+# TYPE suitop_checkpoint_signed_voting_power gauge
+suitop_checkpoint_signed_voting_power 5000
+# HELP suitop_checkpoint_signer_count Number of validators that signed the most recently processed checkpoint.
+# TYPE suitop_checkpoint_signer_count gauge
+suitop_checkpoint_signer_count 1
+# HELP suitop_committee_size Number of validators in the current committee.
+# TYPE suitop_committee_size gauge
+suitop_committee_size 3
+`,
+		},
+		{
+			name:    "position",
+			metrics: []string{"suitop_epoch", "suitop_checkpoint_sequence", "suitop_checkpoints_processed_total"},
+			want: `
+# HELP suitop_epoch Current epoch.
+# TYPE suitop_epoch gauge
+suitop_epoch 7
+# HELP suitop_checkpoint_sequence Sequence number of the most recently processed checkpoint.
+# TYPE suitop_checkpoint_sequence gauge
+suitop_checkpoint_sequence 42
+# HELP suitop_checkpoints_processed_total Number of checkpoints with signatures processed.
+# TYPE suitop_checkpoints_processed_total counter
+suitop_checkpoints_processed_total 10
+`,
+		},
+	}
+	c := NewSnapshotCollector()
+	c.Observe(testSnapshot())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := testutil.CollectAndCompare(c, strings.NewReader(tt.want), tt.metrics...); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestSnapshotCollectorEmpty(t *testing.T) {
+	if n := testutil.CollectAndCount(NewSnapshotCollector()); n != 0 {
+		t.Errorf("collector without a snapshot exposes %d metrics, want 0", n)
+	}
+}
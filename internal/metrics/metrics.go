@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const namespace = "suitop"
+
+// Operational metrics updated directly by the subscriber and the RPC clients.
+// Per-validator and per-checkpoint metrics are derived from processor snapshots
+// by SnapshotCollector instead.
+var (
+	SubscriberReconnects = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "subscriber",
+		Name:      "reconnects_total",
+		Help:      "Number of times the checkpoint subscription was re-established.",
+	})
+
+	SubscriberStalls = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "subscriber",
+		Name:      "stalls_total",
+		Help:      "Number of times the checkpoint stream stalled and was restarted.",
+	})
+
+	SubscriberConnected = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "subscriber",
+		Name:      "connected",
+		Help:      "Whether the checkpoint subscription is currently established (1) or not (0).",
+	})
+
+	RPCLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "rpc",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of JSON-RPC and gRPC requests to the Sui node.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"transport", "method", "outcome"})
+)
+
+// ObserveRPC records the latency of a single RPC request.
+func ObserveRPC(transport, method string, seconds float64, err error) {
+	outcome := "success"
+	if err != nil {
+		outcome = "error"
+	}
+	RPCLatency.WithLabelValues(transport, method, outcome).Observe(seconds)
+}
+
+// NewRegistry creates a registry holding every suitop metric plus the Go
+// runtime and process collectors. All metrics carry a constant network label.
+func NewRegistry(network string, snapshots *SnapshotCollector) *prometheus.Registry {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+	)
+	wrapped := prometheus.WrapRegistererWith(prometheus.Labels{"network": network}, reg)
+	wrapped.MustRegister(
+		SubscriberReconnects,
+		SubscriberStalls,
+		SubscriberConnected,
+		RPCLatency,
+		snapshots,
+	)
+	return reg
+}
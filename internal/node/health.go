@@ -0,0 +1,134 @@
+// Package node polls the health of the full node that suitop reads from.
+package node
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"suitop/internal/config"
+	"suitop/internal/types"
+	rpcPb "suitop/pb/sui/rpc/v2beta"
+)
+
+// Monitor polls the node's service info and derives warnings from it.
+// A nil *Monitor reports no health.
+type Monitor struct {
+	ledger rpcPb.LedgerServiceClient
+	cfg    config.NodeConfig
+
+	mu     sync.RWMutex
+	health types.NodeHealth
+	hooks  []func(types.NodeHealth)
+}
+
+// NewMonitor creates a monitor for the node behind ledger. Start it with Run.
+func NewMonitor(ledger rpcPb.LedgerServiceClient, cfg config.NodeConfig) *Monitor {
+	return &Monitor{ledger: ledger, cfg: cfg}
+}
+
+// OnUpdate registers a function called with the result of every poll.
+// Hooks must be registered before Run is started.
+func (m *Monitor) OnUpdate(fn func(types.NodeHealth)) {
+	m.hooks = append(m.hooks, fn)
+}
+
+// Health returns the result of the last poll.
+func (m *Monitor) Health() types.NodeHealth {
+	if m == nil {
+		return types.NodeHealth{}
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.health
+}
+
+// Run polls the node every poll interval until ctx is done.
+func (m *Monitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		m.poll(ctx)
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (m *Monitor) poll(ctx context.Context) {
+	pollCtx, cancel := context.WithTimeout(ctx, m.cfg.PollInterval)
+	info, err := m.ledger.GetServiceInfo(pollCtx, &rpcPb.GetServiceInfoRequest{})
+	cancel()
+	if err != nil && ctx.Err() != nil {
+		return
+	}
+
+	m.mu.Lock()
+	h := m.health
+	previous := h.Warnings
+	h.PolledAt = time.Now()
+	h.Error = ""
+	if err != nil {
+		h.Error = err.Error()
+	} else {
+		h.Chain = info.GetChain()
+		h.ServerVersion = info.GetServerVersion()
+		h.Epoch = info.GetEpoch()
+		h.CheckpointHeight = info.GetCheckpointHeight()
+		h.LowestAvailableCheckpoint = info.GetLowestAvailableCheckpoint()
+		if ts := info.GetTimestamp(); ts != nil {
+			h.NodeTime = time.Unix(ts.GetSeconds(), int64(ts.GetNanos()))
+		}
+	}
+	if !h.NodeTime.IsZero() {
+		h.Lag = h.PolledAt.Sub(h.NodeTime)
+	}
+	h.Warnings = warnings(h, m.cfg.MaxLag)
+	m.health = h
+	m.mu.Unlock()
+
+	logNewWarnings(previous, h.Warnings)
+	for _, fn := range m.hooks {
+		fn(h)
+	}
+}
+
+// warnings lists the problems with the node's health.
+func warnings(h types.NodeHealth, maxLag time.Duration) []string {
+	var out []string
+	if h.Error != "" {
+		out = append(out, "service info unavailable: "+h.Error)
+	}
+	if maxLag > 0 && h.Lag > maxLag {
+		out = append(out, fmt.Sprintf("node is %s behind the wall clock", h.Lag.Truncate(time.Second)))
+	}
+	return out
+}
+
+// logNewWarnings logs warnings when they first appear so that they are not
+// repeated on every poll.
+func logNewWarnings(previous, current []string) {
+	seen := make(map[string]bool, len(previous))
+	for _, w := range previous {
+		seen[warningKey(w)] = true
+	}
+	for _, w := range current {
+		if !seen[warningKey(w)] {
+			log.Printf("Warning: node health: %s", w)
+		}
+	}
+}
+
+// warningKey identifies a warning by the text before its first number, which
+// changes from poll to poll.
+func warningKey(w string) string {
+	if i := strings.IndexAny(w, "0123456789"); i >= 0 {
+		return w[:i]
+	}
+	return w
+}
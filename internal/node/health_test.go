@@ -0,0 +1,46 @@
+package node
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"suitop/internal/types"
+)
+
+func TestWarnings(t *testing.T) {
+	tests := []struct {
+		name   string
+		health types.NodeHealth
+		maxLag time.Duration
+		want   []string
+	}{
+		{"healthy", types.NodeHealth{Lag: time.Second, LowestAvailableCheckpoint: 10}, time.Minute, nil},
+		{"poll failed", types.NodeHealth{Error: "unavailable"}, time.Minute, []string{"service info unavailable: unavailable"}},
+		{"lagging", types.NodeHealth{Lag: 90 * time.Second}, time.Minute, []string{"node is 1m30s behind the wall clock"}},
+		{"lag check disabled", types.NodeHealth{Lag: time.Hour}, 0, nil},
+		{
+			"lagging and failed", types.NodeHealth{Error: "timeout", Lag: 2 * time.Minute}, time.Minute,
+			[]string{"service info unavailable: timeout", "node is 2m0s behind the wall clock"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := warnings(tt.health, tt.maxLag)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("warnings() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWarningKey(t *testing.T) {
+	a := warningKey("node is 1m30s behind the wall clock")
+	b := warningKey("node is 2m0s behind the wall clock")
+	if a != b {
+		t.Errorf("warningKey differs between polls: %q, %q", a, b)
+	}
+	if got := warningKey("service info unavailable: timeout"); got != "service info unavailable: timeout" {
+		t.Errorf("warningKey() = %q", got)
+	}
+}
@@ -0,0 +1,174 @@
+package notify
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"suitop/internal/events"
+)
+
+// SinkType selects how a sink delivers notifications.
+type SinkType string
+
+const (
+	SinkWebhook SinkType = "webhook" // POST a templated JSON body
+	SinkSlack   SinkType = "slack"   // POST a Slack incoming-webhook message
+	SinkDiscord SinkType = "discord" // POST a Discord webhook message
+	SinkSMTP    SinkType = "smtp"    // Send an email
+	SinkExec    SinkType = "exec"    // Run a command with the event JSON on stdin
+)
+
+// DefaultEvents are the event kinds delivered to a sink that does not list any.
+// Checkpoint events are excluded because they arrive several times per second,
+// and validator status events because without a validators list they would
+// report every validator of the committee.
+var DefaultEvents = []events.Kind{
+	events.KindEpochChange,
+	events.KindCommitteeReloadFailed,
+	events.KindChainReset,
+	events.KindSourceHealth,
+}
+
+// Config is the contents of a notifications file.
+type Config struct {
+	// Validators limits validator_status events to these names or addresses
+	// for every sink that does not set its own list. Empty means all validators.
+	Validators []string     `yaml:"validators,omitempty"`
+	Sinks      []SinkConfig `yaml:"sinks"`
+}
+
+// SinkConfig configures a single notification sink.
+type SinkConfig struct {
+	Name       string        `yaml:"name"`
+	Type       SinkType      `yaml:"type"`
+	Events     []events.Kind `yaml:"events,omitempty"`
+	Validators []string      `yaml:"validators,omitempty"`
+
+	Webhook WebhookConfig `yaml:"webhook,omitempty"` // For webhook, slack and discord sinks
+	SMTP    SMTPConfig    `yaml:"smtp,omitempty"`
+	Exec    ExecConfig    `yaml:"exec,omitempty"`
+}
+
+// WebhookConfig holds settings for HTTP sinks.
+type WebhookConfig struct {
+	URL     string            `yaml:"url"`
+	Headers map[string]string `yaml:"headers,omitempty"`
+	// Template is a text/template producing the JSON body; only used by webhook sinks.
+	Template string `yaml:"template,omitempty"`
+	// Secret signs the body with HMAC-SHA256 in the X-Suitop-Signature header.
+	Secret     string        `yaml:"secret,omitempty"`
+	MaxRetries int           `yaml:"max_retries,omitempty"`
+	Timeout    time.Duration `yaml:"timeout,omitempty"`
+}
+
+// SMTPConfig holds settings for email sinks.
+type SMTPConfig struct {
+	Host     string   `yaml:"host"`
+	Port     int      `yaml:"port,omitempty"`
+	Username string   `yaml:"username,omitempty"`
+	Password string   `yaml:"password,omitempty"`
+	From     string   `yaml:"from"`
+	To       []string `yaml:"to"`
+	// Timeout bounds the whole exchange with the server, from dial to QUIT.
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+}
+
+// ExecConfig holds settings for exec sinks.
+type ExecConfig struct {
+	Command string        `yaml:"command"`
+	Args    []string      `yaml:"args,omitempty"`
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+}
+
+// LoadConfig reads and validates a YAML notifications file.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading notifications file: %w", err)
+	}
+	// Unknown keys are rejected so that a typo does not silently drop a setting
+	var cfg Config
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("error parsing notifications file %s: %w", path, err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid notifications file %s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// Validate checks the sinks and fills in defaults.
+func (c *Config) Validate() error {
+	known := map[events.Kind]bool{events.KindCheckpoint: true, events.KindValidatorStatus: true}
+	for _, k := range DefaultEvents {
+		known[k] = true
+	}
+
+	seen := make(map[string]bool)
+	for i := range c.Sinks {
+		s := &c.Sinks[i]
+		if s.Name == "" {
+			return fmt.Errorf("sink %d has no name", i)
+		}
+		if seen[s.Name] {
+			return fmt.Errorf("duplicate sink name %q", s.Name)
+		}
+		seen[s.Name] = true
+
+		if len(s.Events) == 0 {
+			s.Events = DefaultEvents
+		}
+		for _, k := range s.Events {
+			if !known[k] {
+				return fmt.Errorf("sink %q: unknown event type %q", s.Name, k)
+			}
+		}
+		if len(s.Validators) == 0 {
+			s.Validators = c.Validators
+		}
+
+		switch s.Type {
+		case SinkWebhook, SinkSlack, SinkDiscord:
+			if s.Webhook.URL == "" {
+				return fmt.Errorf("sink %q: webhook.url is required", s.Name)
+			}
+			if s.Webhook.Template != "" && s.Type != SinkWebhook {
+				return fmt.Errorf("sink %q: webhook.template is only supported by webhook sinks", s.Name)
+			}
+			if s.Webhook.MaxRetries < 0 {
+				return fmt.Errorf("sink %q: webhook.max_retries must not be negative", s.Name)
+			}
+			if s.Webhook.Timeout <= 0 {
+				s.Webhook.Timeout = 10 * time.Second
+			}
+		case SinkSMTP:
+			if s.SMTP.Host == "" || s.SMTP.From == "" || len(s.SMTP.To) == 0 {
+				return fmt.Errorf("sink %q: smtp.host, smtp.from and smtp.to are required", s.Name)
+			}
+			if s.SMTP.Port == 0 {
+				s.SMTP.Port = 587
+			}
+			if s.SMTP.Timeout <= 0 {
+				s.SMTP.Timeout = 30 * time.Second
+			}
+		case SinkExec:
+			if s.Exec.Command == "" {
+				return fmt.Errorf("sink %q: exec.command is required", s.Name)
+			}
+			if s.Exec.Timeout <= 0 {
+				s.Exec.Timeout = 30 * time.Second
+			}
+		default:
+			return fmt.Errorf("sink %q: unknown type %q", s.Name, s.Type)
+		}
+	}
+	return nil
+}
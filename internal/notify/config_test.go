@@ -0,0 +1,124 @@
+package notify
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"suitop/internal/events"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{"webhook", Config{Sinks: []SinkConfig{{Name: "a", Type: SinkWebhook, Webhook: WebhookConfig{URL: "http://x"}}}}, false},
+		{"no name", Config{Sinks: []SinkConfig{{Type: SinkWebhook, Webhook: WebhookConfig{URL: "http://x"}}}}, true},
+		{"duplicate name", Config{Sinks: []SinkConfig{
+			{Name: "a", Type: SinkExec, Exec: ExecConfig{Command: "true"}},
+			{Name: "a", Type: SinkExec, Exec: ExecConfig{Command: "true"}},
+		}}, true},
+		{"unknown type", Config{Sinks: []SinkConfig{{Name: "a", Type: "pager"}}}, true},
+		{"unknown event", Config{Sinks: []SinkConfig{{Name: "a", Type: SinkExec, Exec: ExecConfig{Command: "true"}, Events: []events.Kind{"nope"}}}}, true},
+		{"validator status listed", Config{Sinks: []SinkConfig{{Name: "a", Type: SinkExec, Exec: ExecConfig{Command: "true"}, Events: []events.Kind{events.KindValidatorStatus}}}}, false},
+		{"webhook without url", Config{Sinks: []SinkConfig{{Name: "a", Type: SinkSlack}}}, true},
+		{"template on slack", Config{Sinks: []SinkConfig{{Name: "a", Type: SinkSlack, Webhook: WebhookConfig{URL: "http://x", Template: "{}"}}}}, true},
+		{"negative retries", Config{Sinks: []SinkConfig{{Name: "a", Type: SinkWebhook, Webhook: WebhookConfig{URL: "http://x", MaxRetries: -1}}}}, true},
+		{"smtp without recipients", Config{Sinks: []SinkConfig{{Name: "a", Type: SinkSMTP, SMTP: SMTPConfig{Host: "h", From: "f"}}}}, true},
+		{"exec without command", Config{Sinks: []SinkConfig{{Name: "a", Type: SinkExec}}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateDefaults(t *testing.T) {
+	cfg := Config{
+		Validators: []string{"0xa"},
+		Sinks: []SinkConfig{
+			{Name: "mail", Type: SinkSMTP, SMTP: SMTPConfig{Host: "h", From: "f", To: []string{"t"}}},
+			{Name: "hook", Type: SinkWebhook, Webhook: WebhookConfig{URL: "http://x"}, Validators: []string{"0xb"}},
+		},
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	mail, hook := cfg.Sinks[0], cfg.Sinks[1]
+	if mail.SMTP.Port != 587 {
+		t.Errorf("SMTP port = %d, want 587", mail.SMTP.Port)
+	}
+	if mail.SMTP.Timeout != 30*time.Second {
+		t.Errorf("SMTP timeout = %s, want 30s", mail.SMTP.Timeout)
+	}
+	if hook.Webhook.Timeout == 0 {
+		t.Error("webhook timeout not defaulted")
+	}
+	for _, k := range mail.Events {
+		if k == events.KindValidatorStatus || k == events.KindCheckpoint {
+			t.Errorf("default events include %s", k)
+		}
+	}
+	if len(mail.Validators) != 1 || mail.Validators[0] != "0xa" {
+		t.Errorf("sink validators = %v, want the global list", mail.Validators)
+	}
+	if len(hook.Validators) != 1 || hook.Validators[0] != "0xb" {
+		t.Errorf("sink validators = %v, want its own list", hook.Validators)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notify.yaml")
+	data := "sinks:\n  - name: slack\n    type: slack\n    webhook: {url: https://hooks.example.com}\n"
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if len(cfg.Sinks) != 1 || cfg.Sinks[0].Type != SinkSlack {
+		t.Errorf("sinks = %+v, want one slack sink", cfg.Sinks)
+	}
+
+	// A misspelt key is an error rather than a silently ignored setting
+	data = "sinks:\n  - name: slack\n    type: slack\n    webhook: {url: https://hooks.example.com, timout: 5s}\n"
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadConfig(path); err == nil {
+		t.Error("LoadConfig accepted an unknown key")
+	}
+}
+
+func TestRouteAccepts(t *testing.T) {
+	r := route{
+		kinds:      map[events.Kind]bool{events.KindValidatorStatus: true, events.KindEpochChange: true},
+		validators: []string{"0xABC", "Beta"},
+	}
+	tests := []struct {
+		name string
+		ev   events.Event
+		want bool
+	}{
+		{"other kind", events.Event{Kind: events.KindSourceHealth}, false},
+		{"epoch change", events.Event{Kind: events.KindEpochChange}, true},
+		{"by address", events.Event{Kind: events.KindValidatorStatus, Validator: &events.ValidatorRef{Address: "0xabc"}}, true},
+		{"by name", events.Event{Kind: events.KindValidatorStatus, Validator: &events.ValidatorRef{Name: "Beta", Address: "0xb"}}, true},
+		{"not listed", events.Event{Kind: events.KindValidatorStatus, Validator: &events.ValidatorRef{Name: "Gamma", Address: "0xc"}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := r.accepts(tt.ev); got != tt.want {
+				t.Errorf("accepts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
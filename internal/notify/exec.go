@@ -0,0 +1,48 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// execSink runs a command for each notification with the notification JSON on stdin.
+type execSink struct {
+	cfg ExecConfig
+}
+
+func newExecSink(cfg ExecConfig) *execSink {
+	return &execSink{cfg: cfg}
+}
+
+// Send runs the command and waits for it to exit or time out. The event kind
+// and text are also exported as SUITOP_EVENT_KIND and SUITOP_EVENT_TEXT.
+func (s *execSink) Send(ctx context.Context, n Notification) error {
+	input, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.Timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, s.cfg.Command, s.cfg.Args...)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Env = append(os.Environ(),
+		"SUITOP_EVENT_KIND="+string(n.Kind),
+		"SUITOP_EVENT_TEXT="+n.Text,
+		"SUITOP_NETWORK="+n.Network,
+	)
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("command %s failed: %w: %s", s.cfg.Command, err, strings.TrimSpace(output.String()))
+	}
+	return nil
+}
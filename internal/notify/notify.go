@@ -0,0 +1,155 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"suitop/internal/events"
+)
+
+const sinkQueueSize = 100
+
+// Sink delivers a single event to an external system.
+type Sink interface {
+	Send(ctx context.Context, n Notification) error
+}
+
+// Notification is an event together with the context sinks render it with.
+type Notification struct {
+	events.Event
+	Network string `json:"network"`
+	Text    string `json:"text"` // Human-readable one-line description
+}
+
+// route is a configured sink with its event and validator filters.
+type route struct {
+	name       string
+	sink       Sink
+	kinds      map[events.Kind]bool
+	validators []string
+}
+
+// Dispatcher routes events from the bus to the configured sinks.
+type Dispatcher struct {
+	network string
+	routes  []route
+}
+
+// NewDispatcher builds the sinks described by cfg.
+func NewDispatcher(cfg Config, network string) (*Dispatcher, error) {
+	d := &Dispatcher{network: network}
+	for _, sc := range cfg.Sinks {
+		sink, err := newSink(sc)
+		if err != nil {
+			return nil, fmt.Errorf("sink %q: %w", sc.Name, err)
+		}
+		r := route{name: sc.Name, sink: sink, kinds: make(map[events.Kind]bool), validators: sc.Validators}
+		for _, k := range sc.Events {
+			r.kinds[k] = true
+		}
+		d.routes = append(d.routes, r)
+	}
+	return d, nil
+}
+
+func newSink(sc SinkConfig) (Sink, error) {
+	switch sc.Type {
+	case SinkWebhook, SinkSlack, SinkDiscord:
+		return newWebhookSink(sc.Type, sc.Webhook)
+	case SinkSMTP:
+		return newSMTPSink(sc.SMTP), nil
+	case SinkExec:
+		return newExecSink(sc.Exec), nil
+	}
+	return nil, fmt.Errorf("unknown type %q", sc.Type)
+}
+
+// Run delivers events from the bus until ctx is done. Each sink is fed from
+// its own queue so a slow or failing sink does not delay the others.
+func (d *Dispatcher) Run(ctx context.Context, bus *events.Bus) {
+	sub, cancel := bus.Subscribe(1024)
+	defer cancel()
+
+	queues := make([]chan Notification, len(d.routes))
+	for i, r := range d.routes {
+		queues[i] = make(chan Notification, sinkQueueSize)
+		go d.deliver(ctx, r, queues[i])
+	}
+
+	for {
+		select {
+		case ev := <-sub:
+			n := d.notification(ev)
+			for i, r := range d.routes {
+				if !r.accepts(ev) {
+					continue
+				}
+				select {
+				case queues[i] <- n:
+				default:
+					log.Printf("Warning: notification sink %s is falling behind, dropped %s event %d", r.name, ev.Kind, ev.ID)
+				}
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, r route, queue <-chan Notification) {
+	for {
+		select {
+		case n := <-queue:
+			if err := r.sink.Send(ctx, n); err != nil {
+				log.Printf("Failed to deliver %s event %d to sink %s: %v", n.Kind, n.ID, r.name, err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Test sends a synthetic event to every sink and returns the delivery errors by sink name.
+func (d *Dispatcher) Test(ctx context.Context) map[string]error {
+	n := d.notification(events.Event{
+		Kind:    events.KindSourceHealth,
+		Time:    time.Now(),
+		Status:  events.SourceConnected,
+		Message: "suitop test notification",
+	})
+	n.Text = fmt.Sprintf("[%s] suitop test notification", d.network)
+
+	errs := make(map[string]error)
+	for _, r := range d.routes {
+		errs[r.name] = r.sink.Send(ctx, n)
+	}
+	return errs
+}
+
+func (d *Dispatcher) notification(ev events.Event) Notification {
+	return Notification{Event: ev, Network: d.network, Text: Text(d.network, ev)}
+}
+
+// accepts reports whether the route wants the event.
+func (r route) accepts(ev events.Event) bool {
+	if !r.kinds[ev.Kind] {
+		return false
+	}
+	if ev.Kind != events.KindValidatorStatus || len(r.validators) == 0 || ev.Validator == nil {
+		return true
+	}
+	for _, v := range r.validators {
+		if strings.EqualFold(v, ev.Validator.Address) || v == ev.Validator.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// Text renders an event as a single human-readable line.
+func Text(network string, ev events.Event) string {
+	return fmt.Sprintf("[%s] %s", network, ev.Summary())
+}
@@ -0,0 +1,102 @@
+package notify
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"suitop/internal/events"
+)
+
+// chanSink hands notifications to a channel, blocking until they are taken
+type chanSink chan Notification
+
+func (s chanSink) Send(ctx context.Context, n Notification) error {
+	select {
+	case s <- n:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func TestDispatcherRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const published = sinkQueueSize + 20
+	all, slow := make(chanSink), make(chanSink)
+	d := &Dispatcher{network: "testnet", routes: []route{
+		{name: "all", sink: all, kinds: map[events.Kind]bool{events.KindSourceHealth: true, events.KindValidatorStatus: true}},
+		{name: "slow", sink: slow, kinds: map[events.Kind]bool{events.KindValidatorStatus: true}},
+	}}
+	bus := events.NewBus(0)
+	go d.Run(ctx, bus)
+
+	// Wait for the dispatcher to subscribe
+	for subscribed := false; !subscribed; {
+		bus.Publish(events.Event{Kind: events.KindSourceHealth, Status: events.SourceConnected})
+		select {
+		case n := <-all:
+			if n.Network != "testnet" || n.Text != "[testnet] Checkpoint subscription connected" {
+				t.Errorf("notification = %+v, want the network and text filled in", n)
+			}
+			subscribed = true
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	for drained := false; !drained; {
+		select {
+		case <-all:
+		case <-time.After(50 * time.Millisecond):
+			drained = true
+		}
+	}
+
+	// The slow sink takes nothing, so past its queue events are dropped
+	// instead of holding up the other sink. Each event is published once the
+	// previous one reached the fast sink.
+	for i := 0; i < published; i++ {
+		bus.Publish(events.Event{Kind: events.KindValidatorStatus, Sequence: uint64(i), Validator: &events.ValidatorRef{Name: "Alpha"}})
+		select {
+		case n := <-all:
+			if n.Sequence != uint64(i) {
+				t.Fatalf("event %d delivered as %d", i, n.Sequence)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("fast sink got %d of %d events", i, published)
+		}
+	}
+
+	delivered := 0
+	for done := false; !done; {
+		select {
+		case <-slow:
+			delivered++
+		case <-time.After(50 * time.Millisecond):
+			done = true
+		}
+	}
+	// One notification in flight plus a full queue
+	if delivered != sinkQueueSize+1 {
+		t.Errorf("slow sink got %d events, want %d", delivered, sinkQueueSize+1)
+	}
+}
+
+func TestDispatcherTest(t *testing.T) {
+	ok, failing := make(chanSink, 1), make(chanSink)
+	d := &Dispatcher{network: "devnet", routes: []route{{name: "ok", sink: ok}, {name: "failing", sink: failing}}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	errs := d.Test(ctx)
+	if errs["ok"] != nil {
+		t.Errorf("sink ok failed: %v", errs["ok"])
+	}
+	if errs["failing"] == nil {
+		t.Error("sink failing succeeded")
+	}
+	if n := <-ok; n.Text != "[devnet] suitop test notification" {
+		t.Errorf("test notification text = %q", n.Text)
+	}
+}
@@ -0,0 +1,131 @@
+package notify
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// smtpSink emails notifications. STARTTLS is used when the server offers it.
+type smtpSink struct {
+	cfg SMTPConfig
+}
+
+func newSMTPSink(cfg SMTPConfig) *smtpSink {
+	return &smtpSink{cfg: cfg}
+}
+
+// Send emails the notification text and the event JSON as the message body.
+// The text carries on-chain validator names, so it stays out of the headers:
+// the subject only names the network and the event kind.
+func (s *smtpSink) Send(ctx context.Context, n Notification) error {
+	details, err := json.MarshalIndent(n.Event, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", s.cfg.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(s.cfg.To, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", Subject(n))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	fmt.Fprintf(&msg, "%s\r\n\r\n%s\r\n", crlf(n.Text), crlf(string(details)))
+
+	var auth smtp.Auth
+	if s.cfg.Username != "" {
+		auth = smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)
+	}
+	addr := net.JoinHostPort(s.cfg.Host, strconv.Itoa(s.cfg.Port))
+	if err := s.send(ctx, addr, auth, []byte(msg.String())); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("error sending email via %s: %w", addr, err)
+	}
+	return nil
+}
+
+// send delivers msg the way smtp.SendMail does, but over a connection with a
+// deadline so that a server that accepts the connection and then stalls
+// cannot hold the sink forever. The connection is also closed when ctx is done.
+func (s *smtpSink) send(ctx context.Context, addr string, auth smtp.Auth, msg []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.Timeout)
+	defer cancel()
+
+	dialer := net.Dialer{Timeout: s.cfg.Timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	deadline, _ := ctx.Deadline()
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return err
+	}
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	c, err := smtp.NewClient(conn, s.cfg.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: s.cfg.Host}); err != nil {
+			return err
+		}
+	}
+	if auth != nil {
+		if ok, _ := c.Extension("AUTH"); !ok {
+			return errors.New("smtp: server doesn't support AUTH")
+		}
+		if err := c.Auth(auth); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(s.cfg.From); err != nil {
+		return err
+	}
+	for _, to := range s.cfg.To {
+		if err := c.Rcpt(to); err != nil {
+			return err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// Subject returns the encoded subject of a notification email, "[network]
+// suitop <event kind>", with line breaks removed.
+func Subject(n Notification) string {
+	subject := fmt.Sprintf("[%s] suitop %s", n.Network, n.Kind)
+	subject = strings.NewReplacer("\r", "", "\n", "").Replace(subject)
+	return mime.QEncoding.Encode("utf-8", subject)
+}
+
+// crlf converts the line breaks of a message body to CRLF.
+func crlf(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = strings.ReplaceAll(s, "\r", "\n")
+	return strings.ReplaceAll(s, "\n", "\r\n")
+}
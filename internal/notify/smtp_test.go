@@ -0,0 +1,145 @@
+package notify
+
+import (
+	"context"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"suitop/internal/events"
+)
+
+// fakeSMTP accepts one unauthenticated message on a local port and sends its
+// data on the returned channel
+func fakeSMTP(t *testing.T) (string, int, <-chan string) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	messages := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		tp := textproto.NewConn(conn)
+		tp.PrintfLine("220 localhost ready")
+		for {
+			line, err := tp.ReadLine()
+			if err != nil {
+				return
+			}
+			switch cmd := strings.ToUpper(strings.Fields(line + " ")[0]); cmd {
+			case "EHLO", "HELO":
+				tp.PrintfLine("250 localhost")
+			case "DATA":
+				tp.PrintfLine("354 go ahead")
+				data, err := tp.ReadDotLines()
+				if err != nil {
+					return
+				}
+				messages <- strings.Join(data, "\n")
+				tp.PrintfLine("250 queued")
+			case "QUIT":
+				tp.PrintfLine("221 bye")
+				return
+			default:
+				tp.PrintfLine("250 ok")
+			}
+		}
+	}()
+	host, port, _ := net.SplitHostPort(ln.Addr().String())
+	p, _ := strconv.Atoi(port)
+	return host, p, messages
+}
+
+func TestSMTPSend(t *testing.T) {
+	host, port, messages := fakeSMTP(t)
+	sink := newSMTPSink(SMTPConfig{Host: host, Port: port, From: "suitop@example.com", To: []string{"oncall@example.com"}, Timeout: 5 * time.Second})
+
+	n := testNotification()
+	n.Validator.Name = "Evil\r\nBcc: victim@example.com"
+	n.Text = Text(n.Network, n.Event)
+	if err := sink.Send(context.Background(), n); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	msg := <-messages
+	header, body, ok := strings.Cut(msg, "\n\n")
+	if !ok {
+		t.Fatalf("message has no body: %q", msg)
+	}
+	if strings.Contains(header, "Bcc") || strings.Contains(header, "Evil") {
+		t.Errorf("validator name leaked into the headers:\n%s", header)
+	}
+	if !strings.Contains(header, "Subject: [testnet] suitop validator_status") {
+		t.Errorf("headers lack the subject:\n%s", header)
+	}
+	if !strings.Contains(header, "To: oncall@example.com") {
+		t.Errorf("headers lack the recipient:\n%s", header)
+	}
+	if !strings.Contains(body, "started missing checkpoints at 1000") || !strings.Contains(body, `"kind": "validator_status"`) {
+		t.Errorf("body lacks the text or the event JSON:\n%s", body)
+	}
+}
+
+func TestSMTPTimeout(t *testing.T) {
+	// The server accepts the connection but never greets
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer ln.Close()
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.Read(make([]byte, 1))
+	}()
+	host, port, _ := net.SplitHostPort(ln.Addr().String())
+	p, _ := strconv.Atoi(port)
+	sink := newSMTPSink(SMTPConfig{Host: host, Port: p, From: "suitop@example.com", To: []string{"oncall@example.com"}, Timeout: 100 * time.Millisecond})
+
+	start := time.Now()
+	err = sink.Send(context.Background(), testNotification())
+	if err == nil {
+		t.Fatal("Send() succeeded against a stalled server")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Send() returned after %s, want about the 100ms timeout", elapsed)
+	}
+}
+
+func TestSubject(t *testing.T) {
+	tests := []struct {
+		name    string
+		network string
+		kind    events.Kind
+		want    string
+	}{
+		{"plain", "mainnet", events.KindEpochChange, "[mainnet] suitop epoch_change"},
+		{"line breaks", "main\r\nBcc: x", events.KindChainReset, "[mainBcc: x] suitop chain_reset"},
+		{"non-ASCII", "réseau", events.KindSourceHealth, "=?utf-8?q?[r=C3=A9seau]_suitop_source=5Fhealth?="},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := Notification{Event: events.Event{Kind: tt.kind}, Network: tt.network}
+			if got := Subject(n); got != tt.want {
+				t.Errorf("Subject() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCRLF(t *testing.T) {
+	if got, want := crlf("a\nb\r\nc\rd"), "a\r\nb\r\nc\r\nd"; got != want {
+		t.Errorf("crlf() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,145 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"text/template"
+	"time"
+)
+
+const (
+	// SignatureHeader carries "sha256=<hex HMAC of timestamp + "." + body>".
+	SignatureHeader = "X-Suitop-Signature"
+	// TimestampHeader carries the unix timestamp included in the signature.
+	TimestampHeader = "X-Suitop-Timestamp"
+)
+
+// retryBaseDelay is the wait before the first retry, doubled on each attempt
+var retryBaseDelay = time.Second
+
+// defaultWebhookTemplate posts the whole notification.
+const defaultWebhookTemplate = `{{json .}}`
+
+// webhookSink posts notifications over HTTP.
+type webhookSink struct {
+	kind   SinkType
+	cfg    WebhookConfig
+	tmpl   *template.Template
+	client *http.Client
+}
+
+func newWebhookSink(kind SinkType, cfg WebhookConfig) (*webhookSink, error) {
+	s := &webhookSink{kind: kind, cfg: cfg, client: &http.Client{Timeout: cfg.Timeout}}
+	if kind == SinkWebhook {
+		text := cfg.Template
+		if text == "" {
+			text = defaultWebhookTemplate
+		}
+		tmpl, err := template.New("webhook").Funcs(template.FuncMap{"json": toJSON}).Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing webhook template: %w", err)
+		}
+		s.tmpl = tmpl
+	}
+	return s, nil
+}
+
+// Send posts the notification, retrying with exponential backoff on network
+// errors, 429 and 5xx responses.
+func (s *webhookSink) Send(ctx context.Context, n Notification) error {
+	body, err := s.body(n)
+	if err != nil {
+		return err
+	}
+
+	delay := retryBaseDelay
+	for attempt := 0; ; attempt++ {
+		retry, err := s.post(ctx, body)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt >= s.cfg.MaxRetries {
+			return err
+		}
+		select {
+		case <-time.After(delay):
+			delay *= 2
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// body renders the request body for the sink's message format.
+func (s *webhookSink) body(n Notification) ([]byte, error) {
+	switch s.kind {
+	case SinkSlack:
+		return json.Marshal(map[string]string{"text": n.Text})
+	case SinkDiscord:
+		return json.Marshal(map[string]string{"content": n.Text})
+	}
+	var buf bytes.Buffer
+	if err := s.tmpl.Execute(&buf, n); err != nil {
+		return nil, fmt.Errorf("error rendering webhook template: %w", err)
+	}
+	if !json.Valid(buf.Bytes()) {
+		return nil, fmt.Errorf("webhook template did not produce valid JSON: %s", buf.String())
+	}
+	return buf.Bytes(), nil
+}
+
+// post sends one request and reports whether a failure is worth retrying.
+func (s *webhookSink) post(ctx context.Context, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("error creating webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range s.cfg.Headers {
+		req.Header.Set(k, v)
+	}
+	if s.cfg.Secret != "" {
+		ts := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set(TimestampHeader, ts)
+		req.Header.Set(SignatureHeader, "sha256="+Sign(s.cfg.Secret, ts, body))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return retry, fmt.Errorf("webhook returned status %s", resp.Status)
+}
+
+// Sign returns the hex HMAC-SHA256 of timestamp + "." + body, as sent in the
+// X-Suitop-Signature header. Receivers recompute it to authenticate requests.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func toJSON(v interface{}) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
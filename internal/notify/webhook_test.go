@@ -0,0 +1,182 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"suitop/internal/events"
+)
+
+// recorder is an HTTP endpoint that answers with the given statuses in turn,
+// then 200, and keeps the requests it got
+type recorder struct {
+	mu       sync.Mutex
+	statuses []int
+	bodies   [][]byte
+	headers  []http.Header
+}
+
+func (rec *recorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	rec.bodies = append(rec.bodies, body)
+	rec.headers = append(rec.headers, r.Header.Clone())
+	status := http.StatusOK
+	if len(rec.statuses) > 0 {
+		status, rec.statuses = rec.statuses[0], rec.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+func testNotification() Notification {
+	ev := events.Event{
+		ID:        7,
+		Kind:      events.KindValidatorStatus,
+		Epoch:     42,
+		Sequence:  1000,
+		Validator: &events.ValidatorRef{Name: "Alpha", Address: "0xa"},
+		Status:    events.StatusMissing,
+	}
+	return Notification{Event: ev, Network: "testnet", Text: Text("testnet", ev)}
+}
+
+func TestWebhookPayloads(t *testing.T) {
+	tests := []struct {
+		name     string
+		kind     SinkType
+		template string
+		check    func(t *testing.T, body map[string]interface{})
+	}{
+		{"webhook", SinkWebhook, "", func(t *testing.T, body map[string]interface{}) {
+			if body["kind"] != "validator_status" || body["network"] != "testnet" || body["epoch"] != 42.0 {
+				t.Errorf("body = %v, want the whole notification", body)
+			}
+		}},
+		{"template", SinkWebhook, `{"summary": {{json .Text}}, "seq": {{.Sequence}}}`, func(t *testing.T, body map[string]interface{}) {
+			if body["summary"] != "[testnet] Alpha started missing checkpoints at 1000" || body["seq"] != 1000.0 {
+				t.Errorf("body = %v, want the rendered template", body)
+			}
+		}},
+		{"slack", SinkSlack, "", func(t *testing.T, body map[string]interface{}) {
+			if len(body) != 1 || body["text"] != "[testnet] Alpha started missing checkpoints at 1000" {
+				t.Errorf("body = %v, want a Slack text message", body)
+			}
+		}},
+		{"discord", SinkDiscord, "", func(t *testing.T, body map[string]interface{}) {
+			if len(body) != 1 || body["content"] != "[testnet] Alpha started missing checkpoints at 1000" {
+				t.Errorf("body = %v, want a Discord content message", body)
+			}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &recorder{}
+			server := httptest.NewServer(rec)
+			defer server.Close()
+
+			sink, err := newWebhookSink(tt.kind, WebhookConfig{
+				URL:      server.URL,
+				Template: tt.template,
+				Headers:  map[string]string{"X-Team": "validators"},
+				Timeout:  time.Second,
+			})
+			if err != nil {
+				t.Fatalf("newWebhookSink: %v", err)
+			}
+			if err := sink.Send(context.Background(), testNotification()); err != nil {
+				t.Fatalf("Send: %v", err)
+			}
+			if len(rec.bodies) != 1 {
+				t.Fatalf("got %d requests, want 1", len(rec.bodies))
+			}
+			if got := rec.headers[0].Get("Content-Type"); got != "application/json" {
+				t.Errorf("Content-Type = %q, want application/json", got)
+			}
+			if got := rec.headers[0].Get("X-Team"); got != "validators" {
+				t.Errorf("X-Team = %q, want validators", got)
+			}
+			var body map[string]interface{}
+			if err := json.Unmarshal(rec.bodies[0], &body); err != nil {
+				t.Fatalf("body is not JSON: %v: %s", err, rec.bodies[0])
+			}
+			tt.check(t, body)
+		})
+	}
+}
+
+func TestWebhookInvalidTemplate(t *testing.T) {
+	sink, err := newWebhookSink(SinkWebhook, WebhookConfig{URL: "http://localhost", Template: `{"text": {{.Text}}}`})
+	if err != nil {
+		t.Fatalf("newWebhookSink: %v", err)
+	}
+	if err := sink.Send(context.Background(), testNotification()); err == nil {
+		t.Error("Send with a template producing invalid JSON succeeded")
+	}
+}
+
+func TestWebhookSignature(t *testing.T) {
+	rec := &recorder{}
+	server := httptest.NewServer(rec)
+	defer server.Close()
+
+	sink, err := newWebhookSink(SinkWebhook, WebhookConfig{URL: server.URL, Secret: "s3cret", Timeout: time.Second})
+	if err != nil {
+		t.Fatalf("newWebhookSink: %v", err)
+	}
+	if err := sink.Send(context.Background(), testNotification()); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	ts := rec.headers[0].Get(TimestampHeader)
+	if ts == "" {
+		t.Fatal("no timestamp header")
+	}
+	if got, want := rec.headers[0].Get(SignatureHeader), "sha256="+Sign("s3cret", ts, rec.bodies[0]); got != want {
+		t.Errorf("signature = %q, want %q", got, want)
+	}
+}
+
+func TestWebhookRetries(t *testing.T) {
+	defer func(d time.Duration) { retryBaseDelay = d }(retryBaseDelay)
+	retryBaseDelay = time.Millisecond
+
+	tests := []struct {
+		name       string
+		statuses   []int
+		maxRetries int
+		wantErr    bool
+		requests   int
+	}{
+		{"success", nil, 3, false, 1},
+		{"rate limited then accepted", []int{429, 429}, 3, false, 3},
+		{"server errors then accepted", []int{500, 503}, 2, false, 3},
+		{"retries exhausted", []int{502, 502, 502}, 2, true, 3},
+		{"no retries", []int{429}, 0, true, 1},
+		{"client error not retried", []int{400}, 3, true, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &recorder{statuses: tt.statuses}
+			server := httptest.NewServer(rec)
+			defer server.Close()
+
+			sink, err := newWebhookSink(SinkSlack, WebhookConfig{URL: server.URL, MaxRetries: tt.maxRetries, Timeout: time.Second})
+			if err != nil {
+				t.Fatalf("newWebhookSink: %v", err)
+			}
+			err = sink.Send(context.Background(), testNotification())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Send() error = %v, want error %v", err, tt.wantErr)
+			}
+			if len(rec.bodies) != tt.requests {
+				t.Errorf("got %d requests, want %d", len(rec.bodies), tt.requests)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+)
+
+// JSONRPCResponseChainIdentifier wraps the sui_getChainIdentifier result.
+type JSONRPCResponseChainIdentifier struct {
+	BaseJSONRPCResponse
+	Result string `json:"result,omitempty"`
+}
+
+// GetChainIdentifier returns the node's chain identifier: the first 4 bytes
+// of the genesis checkpoint digest in hex.
+func (c *Client) GetChainIdentifier(ctx context.Context) (string, error) {
+	var response JSONRPCResponseChainIdentifier
+	if err := c.Call(ctx, "sui_getChainIdentifier", []interface{}{}, &response); err != nil {
+		return "", fmt.Errorf("sui_getChainIdentifier call failed: %w", err)
+	}
+	if response.Result == "" {
+		return "", fmt.Errorf("empty chain identifier in sui_getChainIdentifier response")
+	}
+	return response.Result, nil
+}
@@ -7,24 +7,37 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 
 	"suitop/internal/config" // For RPCClientConfig
+	"suitop/internal/metrics"
 	// valmodel "suitop/internal/validator" // No longer needed for these specific structs
 )
 
 // --- JSON Data Structs Moved from validator/model.go ---
 
 // ActiveValidatorJSON is part of the suix_getLatestSuiSystemState response.
+// Numbers are decimal strings, amounts in MIST.
 type ActiveValidatorJSON struct {
-	SuiAddress          string `json:"suiAddress"`
-	Name                string `json:"name"`
-	ProtocolPubkeyBytes string `json:"protocolPubkeyBytes"`
+	SuiAddress            string `json:"suiAddress"`
+	Name                  string `json:"name"`
+	ProtocolPubkeyBytes   string `json:"protocolPubkeyBytes"`
+	VotingPower           string `json:"votingPower"`
+	StakingPoolSuiBalance string `json:"stakingPoolSuiBalance"`
+	CommissionRate        string `json:"commissionRate"` // Basis points
+	GasPrice              string `json:"gasPrice"`
 }
 
 // SuiSystemStateResult is the 'result' field of suix_getLatestSuiSystemState response.
 type SuiSystemStateResult struct {
-	Epoch            string                `json:"epoch"`
-	ActiveValidators []ActiveValidatorJSON `json:"activeValidators"`
+	Epoch                 string                `json:"epoch"`
+	ProtocolVersion       string                `json:"protocolVersion"`
+	SystemStateVersion    string                `json:"systemStateVersion"`
+	ReferenceGasPrice     string                `json:"referenceGasPrice"`
+	TotalStake            string                `json:"totalStake"`
+	EpochStartTimestampMs string                `json:"epochStartTimestampMs"`
+	EpochDurationMs       string                `json:"epochDurationMs"`
+	ActiveValidators      []ActiveValidatorJSON `json:"activeValidators"`
 }
 
 // CommitteeValidatorEntryJSON represents a single validator entry in suix_getCommitteeInfo response.
@@ -89,7 +102,12 @@ func NewClient(cfg config.RPCClientConfig) *Client {
 // Call performs a JSON-RPC request and unmarshals the response.
 // The `result` parameter should be a pointer to the specific expected result structure (e.g., *valmodel.SuiSystemStateResult).
 // This generic method can be used by specific methods like GetCommitteeInfo or GetLatestSuiSystemState.
-func (c *Client) Call(ctx context.Context, method string, params []interface{}, result interface{}) error {
+func (c *Client) Call(ctx context.Context, method string, params []interface{}, result interface{}) (err error) {
+	start := time.Now()
+	defer func() {
+		metrics.ObserveRPC("jsonrpc", method, time.Since(start).Seconds(), err)
+	}()
+
 	requestPayload := JSONRPCRequest{
 		JSONRPC: "2.0",
 		ID:      1, // Simple ID, could be made more robust if needed
@@ -0,0 +1,287 @@
+package tui
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"suitop/internal/history"
+	"suitop/internal/types"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// chartMode is what one column of the participation charts covers
+type chartMode int
+
+const (
+	chartCheckpoints chartMode = iota // One checkpoint
+	chartMinutes                      // The average of one minute
+)
+
+// Bounds of the chart height in lines, set with '+' and '-'
+const (
+	defaultChartHeight = 6
+	minChartHeight     = 3
+	maxChartHeight     = 20
+)
+
+// chartAxisWidth is the width of the value labels left of a chart
+const chartAxisWidth = 7
+
+// barLevels are the glyphs of a partly filled chart cell, by eighths
+var barLevels = []rune(" ▁▂▃▄▅▆▇█")
+
+// chartPoint is the participation of one chart column
+type chartPoint struct {
+	signedPower float64
+	totalPower  float64
+	signers     float64
+	committee   float64 // Committee size; 0 if unknown
+}
+
+// SetHistory feeds the participation charts from the history store, which
+// reaches further back than the checkpoints the TUI keeps, and lets the
+// heatmap scroll back through it. A nil store leaves the charts and the
+// heatmap on the TUI's own checkpoints.
+func (m *Model) SetHistory(store *history.Store) {
+	m.history = store
+}
+
+// chartColumns returns the number of columns of each chart
+func (m Model) chartColumns() int {
+	return max((m.width-4)/2-2-chartAxisWidth, 1) // Two panels with border and padding
+}
+
+// refreshCharts reloads the points of the participation charts, if shown
+func (m *Model) refreshCharts() {
+	if !m.charts {
+		return
+	}
+	n := m.chartColumns()
+	var points []chartPoint
+	switch {
+	case m.history != nil && m.chartMode == chartCheckpoints:
+		for _, r := range m.history.Latest(n) {
+			points = append(points, chartPoint{
+				signedPower: float64(r.SignedPower),
+				totalPower:  float64(r.TotalPower),
+				signers:     float64(r.Signers.Count()),
+			})
+		}
+	case m.history != nil:
+		to := time.Now().Truncate(time.Minute).Add(time.Minute)
+		for _, a := range m.history.Minutes(to.Add(-time.Duration(n)*time.Minute), to) {
+			if a.Checkpoints == 0 {
+				continue
+			}
+			signed := uint64(0)
+			for _, count := range a.SignedCounts {
+				signed += count
+			}
+			points = append(points, chartPoint{
+				signedPower: a.AvgSignedPower(),
+				totalPower:  float64(a.TotalPower),
+				signers:     float64(signed) / float64(a.Checkpoints),
+			})
+		}
+	default:
+		points = m.checkpointPoints(n)
+	}
+	for i := range points {
+		if points[i].committee == 0 {
+			points[i].committee = float64(len(m.committee))
+		}
+	}
+	m.chartPoints = points
+}
+
+// checkpointPoints returns up to n points from the checkpoints kept for the
+// heatmap, per checkpoint or per minute
+func (m Model) checkpointPoints(n int) []chartPoint {
+	seqs := make([]uint64, 0, len(m.checkpoints))
+	for seq := range m.checkpoints {
+		seqs = append(seqs, seq)
+	}
+	sort.Slice(seqs, func(i, j int) bool { return seqs[i] < seqs[j] })
+
+	var points []chartPoint
+	var minute time.Time
+	count := 0.0
+	for _, seq := range seqs {
+		cp := m.checkpoints[seq]
+		p := chartPoint{
+			signedPower: float64(cp.SignedPower),
+			totalPower:  float64(cp.TotalPower),
+			signers:     float64(len(cp.Signers)),
+			committee:   float64(cp.ValidatorCount),
+		}
+		if m.chartMode == chartCheckpoints {
+			points = append(points, p)
+			continue
+		}
+
+		// Average the checkpoints of each minute
+		at := time.UnixMilli(cp.Timestamp).Truncate(time.Minute)
+		if len(points) == 0 || !at.Equal(minute) {
+			minute, count = at, 0
+			points = append(points, chartPoint{})
+		}
+		last := &points[len(points)-1]
+		count++
+		last.signedPower += (p.signedPower - last.signedPower) / count
+		last.totalPower += (p.totalPower - last.totalPower) / count
+		last.signers += (p.signers - last.signers) / count
+		last.committee = p.committee
+	}
+	if len(points) > n {
+		points = points[len(points)-n:]
+	}
+	return points
+}
+
+// handleChartKey shows, hides, resizes and switches the participation
+// charts. It reports whether the key was used.
+func (m *Model) handleChartKey(msg tea.KeyMsg) bool {
+	switch msg.String() {
+	case "c":
+		m.charts = !m.charts
+	case "+", "=":
+		if !m.charts {
+			return false
+		}
+		m.chartHeight = min(m.chartHeight+1, maxChartHeight)
+	case "-":
+		if !m.charts {
+			return false
+		}
+		m.chartHeight = max(m.chartHeight-1, minChartHeight)
+	case "m":
+		if !m.charts {
+			return false
+		}
+		m.chartMode = (m.chartMode + 1) % 2
+	default:
+		return false
+	}
+	m.refreshCharts()
+	m.moveCursor(0) // The table height changed
+	return true
+}
+
+// renderChartsRow shows the signed voting power and signer count charts side
+// by side
+func renderChartsRow(m Model) string {
+	unit := "checkpoints"
+	if m.chartMode == chartMinutes {
+		unit = "minutes"
+	}
+	points := m.chartPoints
+	if len(points) == 0 {
+		return chartPanelStyle.Width(m.width - 2).Render(mutedStyle.Render("Waiting for checkpoints..."))
+	}
+	latest := points[len(points)-1]
+
+	power := make([]float64, len(points))
+	signers := make([]float64, len(points))
+	lowPower, lowSigners, committee := 100.0, math.Inf(1), 0.0
+	for i, p := range points {
+		if p.totalPower > 0 {
+			power[i] = p.signedPower / p.totalPower * 100
+		}
+		signers[i] = p.signers
+		lowPower = min(lowPower, power[i])
+		lowSigners = min(lowSigners, p.signers)
+		committee = max(committee, p.committee, p.signers)
+	}
+	quorum := 0.0
+	if latest.totalPower > 0 {
+		quorum = float64(types.QuorumThreshold(int(latest.totalPower))) / latest.totalPower * 100
+	}
+
+	// Leave room below the quorum line and the lowest point
+	powerFloor := math.Max(math.Floor(min(lowPower, quorum)/10)*10-10, 0)
+	signersFloor := math.Max(math.Floor(min(lowSigners, committee*2/3)*0.9), 0)
+
+	powerTitle := fmt.Sprintf("Signed power, last %d %s: %.2f%% · min %.2f%% · quorum %.2f%%",
+		len(points), unit, power[len(power)-1], lowPower, quorum)
+	// Quorum is a share of the voting power, not a number of signers, so the
+	// signer count has no threshold line
+	signersTitle := fmt.Sprintf("Signers, last %d %s: %.0f of %.0f · min %.0f · no quorum line, quorum is by stake",
+		len(points), unit, latest.signers, committee, lowSigners)
+
+	powerChart := renderChart(power, powerFloor, 100, quorum, m.chartHeight, lipgloss.NewStyle().Foreground(powerBarColor),
+		func(v float64) string { return fmt.Sprintf("%5.1f%%", v) })
+	signersChart := renderChart(signers, signersFloor, committee, -1, m.chartHeight, lipgloss.NewStyle().Foreground(validatorBarColor),
+		func(v float64) string { return fmt.Sprintf("%6.0f", v) })
+
+	legend := warningStyle.Render("───") + mutedStyle.Render(" quorum (2f+1); bars below it in red")
+	keys := mutedStyle.Render("c hide · +/- resize · m per checkpoint or minute")
+	return lipgloss.JoinHorizontal(lipgloss.Top,
+		chartPanelStyle.Render(lipgloss.JoinVertical(lipgloss.Left, cut(powerTitle, m), powerChart, cut(legend, m))),
+		chartPanelStyle.Render(lipgloss.JoinVertical(lipgloss.Left, cut(signersTitle, m), signersChart, cut(keys, m))),
+	)
+}
+
+// cut shortens a chart title to the panel width
+func cut(s string, m Model) string {
+	return lipgloss.NewStyle().MaxWidth(m.chartColumns() + chartAxisWidth).Render(s)
+}
+
+// renderChart draws values as vertical bars between low and high, height
+// lines tall, with the value labels on the left. Values below line, if it is
+// not negative, are drawn in the error color and the line itself across the
+// empty cells.
+func renderChart(values []float64, low, high, line float64, height int, style lipgloss.Style, label func(float64) string) string {
+	if high <= low {
+		high = low + 1
+	}
+	level := func(v float64) int { // In eighths of a line
+		return int(math.Round((v - low) / (high - low) * float64(height*8)))
+	}
+	lineRow := -1
+	if line >= 0 {
+		lineRow = min(max(level(line)/8, 0), height-1)
+	}
+
+	rows := make([]string, height)
+	for r := 0; r < height; r++ {
+		row := height - 1 - r // From the bottom
+		axis := strings.Repeat(" ", chartAxisWidth-1)
+		switch row {
+		case lineRow:
+			axis = label(line)
+		case height - 1:
+			axis = label(high)
+		case 0:
+			axis = label(low)
+		}
+		var b strings.Builder
+		b.WriteString(mutedStyle.Render(fmt.Sprintf("%*s┤", chartAxisWidth-1, axis)))
+
+		// Cells are styled in runs of the same style
+		var run []rune
+		runStyle := style
+		for i, v := range values {
+			fill := min(max(level(v)-row*8, 0), 8)
+			cell, cellStyle := barLevels[fill], style
+			switch {
+			case fill == 0 && row == lineRow:
+				cell, cellStyle = '─', warningStyle
+			case line >= 0 && v < line:
+				cellStyle = missedCellStyle
+			}
+			if i > 0 && cellStyle.GetForeground() != runStyle.GetForeground() {
+				b.WriteString(runStyle.Render(string(run)))
+				run = run[:0]
+			}
+			run, runStyle = append(run, cell), cellStyle
+		}
+		b.WriteString(runStyle.Render(string(run)))
+		rows[r] = b.String()
+	}
+	return strings.Join(rows, "\n")
+}
@@ -0,0 +1,140 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"suitop/internal/types"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// timedCheckpoints returns checkpoints 1, 2, ... signed by the given numbers of
+// validators of 1000 voting power each, at the given offsets from start
+func timedCheckpoints(start time.Time, offsets []time.Duration, signers []int) map[uint64]types.CheckpointInfo {
+	cps := make(map[uint64]types.CheckpointInfo)
+	for i, n := range signers {
+		cp := types.CheckpointInfo{
+			Sequence:    uint64(i + 1),
+			Timestamp:   start.Add(offsets[i]).UnixMilli(),
+			SignedPower: n * 1000,
+			TotalPower:  10000,
+		}
+		for j := 0; j < n; j++ {
+			cp.Signers = append(cp.Signers, uint32(j))
+		}
+		cps[cp.Sequence] = cp
+	}
+	return cps
+}
+
+func TestCheckpointPoints(t *testing.T) {
+	start := time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+	offsets := []time.Duration{0, 20 * time.Second, 40 * time.Second, 70 * time.Second}
+	checkpoints := timedCheckpoints(start, offsets, []int{6, 8, 10, 9})
+
+	tests := []struct {
+		name string
+		mode chartMode
+		n    int
+		want []float64 // Signed power of each point
+	}{
+		{"per checkpoint", chartCheckpoints, 10, []float64{6000, 8000, 10000, 9000}},
+		{"per checkpoint, latest only", chartCheckpoints, 2, []float64{10000, 9000}},
+		{"per minute", chartMinutes, 10, []float64{8000, 9000}},
+		{"per minute, latest only", chartMinutes, 1, []float64{9000}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Model{chartMode: tt.mode, checkpoints: checkpoints}
+			points := m.checkpointPoints(tt.n)
+			if len(points) != len(tt.want) {
+				t.Fatalf("checkpointPoints() = %+v, want %d points", points, len(tt.want))
+			}
+			for i, p := range points {
+				if p.signedPower != tt.want[i] || p.totalPower != 10000 || p.signers != tt.want[i]/1000 {
+					t.Errorf("point %d = %+v, want signed power %.0f of 10000 by %.0f signers", i, p, tt.want[i], tt.want[i]/1000)
+				}
+			}
+		})
+	}
+}
+
+func TestRefreshCharts(t *testing.T) {
+	start := time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+	checkpoints := timedCheckpoints(start, []time.Duration{0, time.Second}, []int{7, 9})
+
+	tests := []struct {
+		name          string
+		charts        bool
+		width         int
+		wantPoints    int
+		wantCommittee float64
+	}{
+		{"hidden", false, 100, 0, 0},
+		{"shown", true, 100, 2, 4},
+		{"one column", true, 0, 1, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Model{charts: tt.charts, width: tt.width, checkpoints: checkpoints, committee: testCommittee(4)}
+			m.refreshCharts()
+			if len(m.chartPoints) != tt.wantPoints {
+				t.Fatalf("chart points = %+v, want %d", m.chartPoints, tt.wantPoints)
+			}
+			for _, p := range m.chartPoints {
+				// Without a validator count the committee size comes from the TUI's committee
+				if p.committee != tt.wantCommittee {
+					t.Errorf("committee = %.0f, want %.0f", p.committee, tt.wantCommittee)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderChart(t *testing.T) {
+	profile := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.ANSI256)
+	defer lipgloss.SetColorProfile(profile)
+
+	style := lipgloss.NewStyle().Foreground(powerBarColor)
+	label := func(v float64) string { return fmt.Sprintf("%5.1f%%", v) }
+	values := []float64{100, 25, 100}
+
+	tests := []struct {
+		name        string
+		line        float64
+		wantLineRow int  // From the top, -1 for none
+		wantDash    bool // The line shows across empty cells
+		wantRed     bool
+	}{
+		{"quorum line", 50, 1, true, true},
+		{"quorum line at the top", 99, 0, true, true},
+		{"quorum line hidden by full bars", 10, 3, false, false},
+		{"no line", -1, -1, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows := strings.Split(renderChart(values, 0, 100, tt.line, 4, style, label), "\n")
+			if len(rows) != 4 {
+				t.Fatalf("chart has %d rows, want 4", len(rows))
+			}
+			for i, row := range rows {
+				hasLine := strings.Contains(row, warningStyle.Render("─"))
+				if want := i == tt.wantLineRow && tt.wantDash; hasLine != want {
+					t.Errorf("row %d has the line = %v, want %v:\n%s", i, hasLine, want, row)
+				}
+				if i == tt.wantLineRow && !strings.Contains(row, label(tt.line)) {
+					t.Errorf("row %d lacks the line label %q:\n%s", i, label(tt.line), row)
+				}
+			}
+			// The bar of 25 fills the bottom row
+			if red := strings.Contains(rows[3], missedCellStyle.Render("█")); red != tt.wantRed {
+				t.Errorf("bar below the line red = %v, want %v:\n%s", red, tt.wantRed, rows[3])
+			}
+		})
+	}
+}
@@ -0,0 +1,323 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"suitop/internal/types"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// focusHistory is how far back the single-validator dashboard keeps the
+// validator's signatures, for its last-hour uptime
+const focusHistory = time.Hour
+
+// stripLength is the number of recent checkpoints in the signature strip
+const stripLength = 600
+
+// markState is a validator's signature on one checkpoint
+type markState uint8
+
+const (
+	markSigned  markState = iota
+	markMissed            // Missed outside maintenance windows
+	markPlanned           // Missed inside a maintenance window
+)
+
+// mark records the dashboard validator's signature on one checkpoint
+type mark struct {
+	seq   uint64
+	epoch uint64
+	at    time.Time
+	state markState
+}
+
+// focus is the state of the single-validator dashboard
+type focus struct {
+	address string
+	marks   []mark // Oldest first; the last hour, and at least the strip
+	meta    types.ValidatorMeta
+}
+
+// record adds the validator's signature on the snapshot's checkpoint. It is
+// skipped while the validator is not in the committee.
+func (f *focus) record(msg SnapshotMsg) {
+	v, ok := findValidator(msg.Committee, f.address)
+	if !ok {
+		return
+	}
+	mk := newMark(msg, v)
+	f.marks = append(f.marks, mk)
+
+	cut := 0
+	for cut < len(f.marks)-stripLength && mk.at.Sub(f.marks[cut].at) > focusHistory {
+		cut++
+	}
+	f.marks = f.marks[cut:]
+}
+
+// newMark returns the validator's signature on the snapshot's checkpoint
+func newMark(msg SnapshotMsg, v types.ValidatorInfo) mark {
+	state := markMissed
+	for _, idx := range msg.Checkpoint.Signers {
+		if int(idx) == v.BitmapIndex {
+			state = markSigned
+			break
+		}
+	}
+	if state == markMissed && msg.Stats[v.SuiAddress].InMaintenance {
+		state = markPlanned
+	}
+	return mark{
+		seq:   msg.Checkpoint.Sequence,
+		epoch: msg.Checkpoint.Epoch,
+		at:    time.UnixMilli(msg.Checkpoint.Timestamp),
+		state: state,
+	}
+}
+
+// findValidator returns the committee member with the given address
+func findValidator(committee []types.ValidatorInfo, address string) (types.ValidatorInfo, bool) {
+	for _, v := range committee {
+		if v.SuiAddress == address {
+			return v, true
+		}
+	}
+	return types.ValidatorInfo{}, false
+}
+
+// markUptime returns the share of marks signed, leaving out misses inside
+// maintenance windows, rendered as a percentage or N/A if no mark counts, and
+// the number of marks
+func markUptime(marks []mark) (string, int) {
+	signed, counted := 0, 0
+	for _, mk := range marks {
+		switch mk.state {
+		case markSigned:
+			signed++
+			counted++
+		case markMissed:
+			counted++
+		}
+	}
+	if counted == 0 {
+		return "N/A", len(marks)
+	}
+	return fmt.Sprintf("%.2f%%", float64(signed)/float64(counted)*100), len(marks)
+}
+
+// renderDashboard shows the single-validator dashboard below the header
+func renderDashboard(m Model) string {
+	v, ok := findValidator(m.committee, m.focus.address)
+	if !ok {
+		return dashboardPanelStyle.Render(warningStyle.Render(
+			fmt.Sprintf("Validator %s is not in the committee of epoch %d", m.focus.address, m.epoch)))
+	}
+	stats, hasStats := m.stats[v.SuiAddress]
+	return lipgloss.JoinVertical(lipgloss.Left,
+		renderFocusInfo(m, v, stats, hasStats),
+		renderStrip(m.focus.marks, m.width),
+		renderFocusStats(m, stats, hasStats),
+	)
+}
+
+// renderFocusInfo shows who the validator is, its ranks and its staking metadata
+func renderFocusInfo(m Model, v types.ValidatorInfo, stats types.ValidatorStats, hasStats bool) string {
+	icon := "❓"
+	if hasStats {
+		icon = statusIcon(stats)
+	}
+	title := fmt.Sprintf("%s %s", icon, lipgloss.NewStyle().Bold(true).Render(v.Name))
+	if v.ChainName != "" {
+		title += mutedStyle.Render(fmt.Sprintf("  (on-chain: %s)", v.ChainName))
+	}
+	if v.Group != "" {
+		title += mutedStyle.Render("  ◆ " + v.Group)
+	}
+
+	totalPower := 0
+	for _, c := range m.committee {
+		totalPower += c.VotingPower
+	}
+	share := 0.0
+	if totalPower > 0 {
+		share = float64(v.VotingPower) / float64(totalPower) * 100
+	}
+	n := len(m.committee)
+	powerRank := rank(m.committee, v.SuiAddress, func(c types.ValidatorInfo) float64 { return float64(c.VotingPower) })
+	uptimeRank := rank(m.committee, v.SuiAddress, func(c types.ValidatorInfo) float64 {
+		return m.stats[c.SuiAddress].Uptime(m.totalWithSig)
+	})
+
+	lines := []string{
+		title,
+		fmt.Sprintf("Address: %s · Bitmap index: %d", v.SuiAddress, v.BitmapIndex),
+		fmt.Sprintf("Voting power: %d (%.2f%%) · Rank #%d of %d by voting power, #%d of %d by uptime",
+			v.VotingPower, share, powerRank, n, uptimeRank, n),
+		renderMeta(m.focus.meta),
+	}
+	return dashboardPanelStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+// renderMeta shows the validator's stake, commission and gas price vote
+func renderMeta(meta types.ValidatorMeta) string {
+	if meta.FetchedAt.IsZero() {
+		if meta.Error != "" {
+			return warningStyle.Render("⚠ Staking metadata: " + meta.Error)
+		}
+		return mutedStyle.Render("Loading staking metadata...")
+	}
+	stakeShare := 0.0
+	if meta.TotalStake > 0 {
+		stakeShare = float64(meta.Stake) / float64(meta.TotalStake) * 100
+	}
+	line := fmt.Sprintf("Stake: %s SUI (%.2f%%) · Commission: %.2f%% · Gas price vote: %d MIST (reference %d)",
+		formatMIST(meta.Stake), stakeShare, float64(meta.CommissionRate)/100, meta.GasPrice, meta.ReferenceGasPrice)
+	if meta.Error != "" {
+		line += warningStyle.Render(fmt.Sprintf("  ⚠ as of %s: %s", meta.FetchedAt.Format("15:04"), meta.Error))
+	}
+	return line
+}
+
+// renderStrip shows a validator's signatures on the most recent checkpoints,
+// oldest first, wrapped to the panel width
+func renderStrip(marks []mark, totalWidth int) string {
+	if len(marks) > stripLength {
+		marks = marks[len(marks)-stripLength:]
+	}
+	if len(marks) == 0 {
+		return dashboardPanelStyle.Render(mutedStyle.Render("Waiting for checkpoints..."))
+	}
+
+	missed, planned := 0, 0
+	for _, mk := range marks {
+		switch mk.state {
+		case markMissed:
+			missed++
+		case markPlanned:
+			planned++
+		}
+	}
+	title := fmt.Sprintf("Last %d checkpoints (%d–%d): %d signed, %d missed",
+		len(marks), marks[0].seq, marks[len(marks)-1].seq, len(marks)-missed-planned, missed)
+	if planned > 0 {
+		title += fmt.Sprintf(", %d in maintenance", planned)
+	}
+
+	width := totalWidth - 4 // Border and padding
+	if width < 1 {
+		width = 1
+	}
+	lines := []string{title}
+	for start := 0; start < len(marks); start += width {
+		end := start + width
+		if end > len(marks) {
+			end = len(marks)
+		}
+		lines = append(lines, renderMarks(marks[start:end]))
+	}
+	return dashboardPanelStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+// renderMarks renders one cell per mark, styling runs of the same state at once
+func renderMarks(marks []mark) string {
+	var b strings.Builder
+	for i := 0; i < len(marks); {
+		j := i
+		for j < len(marks) && marks[j].state == marks[i].state {
+			j++
+		}
+		run := strings.Repeat("█", j-i)
+		switch marks[i].state {
+		case markSigned:
+			b.WriteString(signedCellStyle.Render(run))
+		case markMissed:
+			b.WriteString(missedCellStyle.Render(run))
+		default:
+			b.WriteString(plannedCellStyle.Render(run))
+		}
+		i = j
+	}
+	return b.String()
+}
+
+// renderFocusStats shows the validator's miss streaks, uptimes and last signature
+func renderFocusStats(m Model, stats types.ValidatorStats, hasStats bool) string {
+	if !hasStats {
+		return dashboardPanelStyle.Render(mutedStyle.Render("No signatures processed yet"))
+	}
+	marks := m.focus.marks
+
+	streak := fmt.Sprintf("Miss streak: current %d · longest %d since start", stats.MissStreak, stats.LongestMissStreak)
+	if stats.MissStreak > 0 && !stats.InMaintenance {
+		streak = inactiveStyle.Render(streak)
+	}
+
+	var epochMarks []mark
+	for _, mk := range marks {
+		if mk.epoch == m.epoch {
+			epochMarks = append(epochMarks, mk)
+		}
+	}
+	epochUptime, epochCount := markUptime(epochMarks)
+
+	hourLabel := "last hour"
+	hourMarks := marks
+	if len(marks) > 0 {
+		latest := marks[len(marks)-1].at
+		from := 0
+		for from < len(marks) && latest.Sub(marks[from].at) > focusHistory {
+			from++
+		}
+		hourMarks = marks[from:]
+		if span := latest.Sub(marks[0].at); from == 0 && span < focusHistory {
+			hourLabel = "last " + span.Truncate(time.Minute).String()
+		}
+	}
+	hourUptime, hourCount := markUptime(hourMarks)
+
+	uptime := fmt.Sprintf("Uptime: epoch %d %s over %d checkpoints · %s %s over %d · since start %s over %d",
+		m.epoch, epochUptime, epochCount, hourLabel, hourUptime, hourCount, formatUptime(stats, m.totalWithSig), m.totalWithSig)
+
+	lastSigned := "Last signed: none since start"
+	if stats.LastSignedSeq > 0 {
+		lastSigned = fmt.Sprintf("Last signed: checkpoint %d", stats.LastSignedSeq)
+		for i := len(marks) - 1; i >= 0; i-- {
+			if marks[i].seq == stats.LastSignedSeq {
+				at := marks[i].at
+				lastSigned += fmt.Sprintf(" at %s (%s ago)", at.Format("15:04:05"), time.Since(at).Truncate(time.Second))
+				break
+			}
+		}
+	}
+
+	return dashboardPanelStyle.Render(lipgloss.JoinVertical(lipgloss.Left, streak, uptime, lastSigned))
+}
+
+// rank returns the 1-based position of the validator at address when the
+// committee is ordered by key, highest first. Ties share a position.
+func rank(committee []types.ValidatorInfo, address string, key func(types.ValidatorInfo) float64) int {
+	v, ok := findValidator(committee, address)
+	if !ok {
+		return 0
+	}
+	own := key(v)
+	r := 1
+	for _, c := range committee {
+		if key(c) > own {
+			r++
+		}
+	}
+	return r
+}
+
+// formatMIST renders an amount in MIST as whole SUI with thousands separators
+func formatMIST(mist uint64) string {
+	s := fmt.Sprint(mist / 1_000_000_000)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
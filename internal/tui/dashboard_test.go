@@ -0,0 +1,185 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"suitop/internal/types"
+)
+
+func TestFocusRecord(t *testing.T) {
+	committee := testCommittee(3)
+	start := time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		address  string
+		count    int
+		interval time.Duration
+		want     int
+	}{
+		{"within the hour", "0x1", 100, time.Second, 100},
+		{"older than an hour trimmed", "0x1", 2000, 2 * time.Second, 1801}, // The mark exactly an hour old stays
+		{"strip kept past the hour", "0x1", 700, 10 * time.Second, stripLength},
+		{"not in the committee", "0x9", 10, time.Second, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := focus{address: tt.address}
+			for i := 0; i < tt.count; i++ {
+				msg := snapshot(committee, 1, uint64(i+1), nil)
+				msg.Checkpoint.Timestamp = start.Add(time.Duration(i) * tt.interval).UnixMilli()
+				f.record(msg)
+			}
+			if len(f.marks) != tt.want {
+				t.Fatalf("kept %d marks, want %d", len(f.marks), tt.want)
+			}
+			if tt.want > 0 && f.marks[len(f.marks)-1].seq != uint64(tt.count) {
+				t.Errorf("latest mark is checkpoint %d, want %d", f.marks[len(f.marks)-1].seq, tt.count)
+			}
+		})
+	}
+}
+
+func TestNewMark(t *testing.T) {
+	committee := testCommittee(3)
+	tests := []struct {
+		name        string
+		missing     []int
+		maintenance []int
+		want        markState
+	}{
+		{"signed", nil, nil, markSigned},
+		{"missed", []int{1}, nil, markMissed},
+		{"missed in maintenance", []int{1}, []int{1}, markPlanned},
+		{"signed in maintenance", nil, []int{1}, markSigned},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mk := newMark(snapshot(committee, 1, 10, tt.missing, tt.maintenance...), committee[1])
+			if mk.state != tt.want || mk.seq != 10 || mk.epoch != 1 {
+				t.Errorf("newMark() = %+v, want state %d for checkpoint 10", mk, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarkUptime(t *testing.T) {
+	tests := []struct {
+		name      string
+		states    []markState
+		want      string
+		wantCount int
+	}{
+		{"no marks", nil, "N/A", 0},
+		{"all signed", []markState{markSigned, markSigned}, "100.00%", 2},
+		{"planned misses left out", []markState{markSigned, markMissed, markPlanned, markSigned}, "66.67%", 4},
+		{"only planned", []markState{markPlanned, markPlanned}, "N/A", 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			marks := make([]mark, len(tt.states))
+			for i, s := range tt.states {
+				marks[i].state = s
+			}
+			got, count := markUptime(marks)
+			if got != tt.want || count != tt.wantCount {
+				t.Errorf("markUptime() = %s, %d, want %s, %d", got, count, tt.want, tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestFocusStatsUptime(t *testing.T) {
+	start := time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time { return start.Add(time.Duration(minutes) * time.Minute) }
+	current := []mark{
+		{seq: 5, epoch: 5, at: at(70), state: markSigned},
+		{seq: 6, epoch: 5, at: at(80), state: markMissed},
+		{seq: 7, epoch: 5, at: at(90), state: markPlanned},
+		{seq: 8, epoch: 5, at: at(100), state: markSigned},
+	}
+	tests := []struct {
+		name  string
+		marks []mark
+		want  []string
+	}{
+		{
+			name: "previous epoch in the last hour",
+			marks: append([]mark{
+				{seq: 1, epoch: 4, at: at(0), state: markMissed}, // More than an hour before the latest
+				{seq: 2, epoch: 4, at: at(50), state: markMissed},
+			}, current...),
+			want: []string{"epoch 5 66.67% over 4 checkpoints", "last hour 50.00% over 5"},
+		},
+		{
+			name:  "less than an hour of marks",
+			marks: current,
+			want:  []string{"epoch 5 66.67% over 4 checkpoints", "last 30m0s 66.67% over 4"},
+		},
+		{
+			name: "only planned misses",
+			marks: []mark{
+				{seq: 1, epoch: 5, at: at(0), state: markPlanned},
+				{seq: 2, epoch: 5, at: at(1), state: markPlanned},
+			},
+			want: []string{"epoch 5 N/A over 2 checkpoints", "last 1m0s N/A over 2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Model{epoch: 5, focus: &focus{address: "0x1", marks: tt.marks}}
+			got := renderFocusStats(m, types.ValidatorStats{}, true)
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("renderFocusStats() lacks %q:\n%s", want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestRank(t *testing.T) {
+	committee := testCommittee(4)
+	for i, power := range []int{3000, 2000, 2000, 1000} {
+		committee[i].VotingPower = power
+	}
+	byPower := func(v types.ValidatorInfo) float64 { return float64(v.VotingPower) }
+	tests := []struct {
+		address string
+		want    int
+	}{
+		{"0x0", 1},
+		{"0x1", 2},
+		{"0x2", 2}, // Ties share a position
+		{"0x3", 4},
+		{"0x9", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			if got := rank(committee, tt.address, byPower); got != tt.want {
+				t.Errorf("rank() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatMIST(t *testing.T) {
+	tests := []struct {
+		mist uint64
+		want string
+	}{
+		{0, "0"},
+		{999_999_999, "0"},
+		{1_000_000_000, "1"},
+		{999_000_000_000, "999"},
+		{1_234_000_000_000, "1,234"},
+		{100_000_000_000_000, "100,000"},
+		{1_234_567_890_000_000_000, "1,234,567,890"},
+	}
+	for _, tt := range tests {
+		if got := formatMIST(tt.mist); got != tt.want {
+			t.Errorf("formatMIST(%d) = %q, want %q", tt.mist, got, tt.want)
+		}
+	}
+}
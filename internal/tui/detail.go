@@ -0,0 +1,185 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"suitop/internal/types"
+	"suitop/internal/validator"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxMissSpans caps how many spans of consecutive misses are kept per validator
+const maxMissSpans = 8
+
+// missSpan is a run of consecutive checkpoints a validator missed
+type missSpan struct {
+	from, to   uint64 // First and last missed checkpoint
+	misses     int    // Checkpoints missed; less than to-from+1 when samples are skipped
+	start, end time.Time
+	planned    bool // Every miss was inside a maintenance window
+}
+
+// activity is the recent signatures of one validator, shown by the detail view
+type activity struct {
+	marks      []mark     // The last stripLength checkpoints, oldest first
+	spans      []missSpan // The last maxMissSpans spans of misses, oldest first
+	lastSigned mark       // Zero until the validator signs
+}
+
+// record adds the validator's signature on one checkpoint
+func (a *activity) record(mk mark) {
+	a.marks = append(a.marks, mk)
+	if len(a.marks) > stripLength {
+		a.marks = a.marks[len(a.marks)-stripLength:]
+	}
+	if mk.state == markSigned {
+		a.lastSigned = mk
+		return
+	}
+
+	// A miss right after a miss extends the latest span
+	if n := len(a.marks); n > 1 && a.marks[n-2].state != markSigned && len(a.spans) > 0 {
+		span := &a.spans[len(a.spans)-1]
+		span.to, span.end = mk.seq, mk.at
+		span.misses++
+		span.planned = span.planned && mk.state == markPlanned
+		return
+	}
+	a.spans = append(a.spans, missSpan{from: mk.seq, to: mk.seq, misses: 1, start: mk.at, end: mk.at, planned: mk.state == markPlanned})
+	if len(a.spans) > maxMissSpans {
+		a.spans = a.spans[len(a.spans)-maxMissSpans:]
+	}
+}
+
+// recordActivity adds every committee member's signature on the snapshot's
+// checkpoint. Validators that left the committee are dropped at epoch changes.
+func (m *Model) recordActivity(msg SnapshotMsg) {
+	if msg.Epoch != m.epoch {
+		for address := range m.activity {
+			if _, ok := findValidator(msg.Committee, address); !ok {
+				delete(m.activity, address)
+			}
+		}
+	}
+	for _, v := range msg.Committee {
+		a, ok := m.activity[v.SuiAddress]
+		if !ok {
+			a = &activity{}
+			m.activity[v.SuiAddress] = a
+		}
+		a.record(newMark(msg, v))
+	}
+}
+
+// renderDetail shows the validator opened from the table
+func renderDetail(m Model) string {
+	v, ok := findValidator(m.committee, m.detail)
+	if !ok {
+		return dashboardPanelStyle.Render(warningStyle.Render(
+			fmt.Sprintf("Validator %s is not in the committee of epoch %d", m.detail, m.epoch)) +
+			"\n" + mutedStyle.Render("esc back · q quit"))
+	}
+	a := m.activity[v.SuiAddress]
+	if a == nil {
+		a = &activity{}
+	}
+	stats, hasStats := m.stats[v.SuiAddress]
+	return lipgloss.JoinVertical(lipgloss.Left,
+		renderDetailInfo(m, v, stats, hasStats),
+		renderStrip(a.marks, m.width),
+		renderDetailStats(m, a, stats, hasStats),
+	)
+}
+
+// renderDetailInfo shows who the validator is and its share of the committee
+func renderDetailInfo(m Model, v types.ValidatorInfo, stats types.ValidatorStats, hasStats bool) string {
+	icon := "❓"
+	if hasStats {
+		icon = statusIcon(stats)
+	}
+	title := fmt.Sprintf("%s %s", icon, lipgloss.NewStyle().Bold(true).Render(v.Name))
+	if v.ChainName != "" {
+		title += mutedStyle.Render(fmt.Sprintf("  (on-chain: %s)", v.ChainName))
+	}
+	if v.Group != "" {
+		title += mutedStyle.Render("  ◆ " + v.Group)
+	}
+	if len(v.Tags) > 0 {
+		title += mutedStyle.Render("  #" + strings.Join(v.Tags, " #"))
+	}
+
+	totalPower := 0
+	for _, c := range m.committee {
+		totalPower += c.VotingPower
+	}
+	share := 0.0
+	if totalPower > 0 {
+		share = float64(v.VotingPower) / float64(totalPower) * 100
+	}
+
+	lines := []string{
+		title,
+		fmt.Sprintf("Address: %s", v.SuiAddress),
+		fmt.Sprintf("Protocol pubkey: %s… · Bitmap index: %d", validator.ShortPubKey(v.ProtocolPubkeyBytes), v.BitmapIndex),
+		// Voting power is proportional to stake, so its share is the stake share
+		fmt.Sprintf("Voting power: %d · Stake share: %.2f%% of the committee", v.VotingPower, share),
+	}
+	return dashboardPanelStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+// renderDetailStats shows the validator's miss streaks, last signature and
+// recent spans of misses
+func renderDetailStats(m Model, a *activity, stats types.ValidatorStats, hasStats bool) string {
+	footer := mutedStyle.Render("esc back · q quit")
+	if !hasStats {
+		return dashboardPanelStyle.Render(lipgloss.JoinVertical(lipgloss.Left,
+			mutedStyle.Render("No signatures processed yet"), footer))
+	}
+
+	streak := fmt.Sprintf("Miss streak: current %d · longest %d since start", stats.MissStreak, stats.LongestMissStreak)
+	if stats.MissStreak > 0 && !stats.InMaintenance {
+		streak = inactiveStyle.Render(streak)
+	}
+	uptime := fmt.Sprintf("Uptime since start: %s over %d checkpoints", formatUptime(stats, m.totalWithSig), m.totalWithSig)
+
+	lastSigned := "Last signed: none since start"
+	if stats.LastSignedSeq > 0 {
+		lastSigned = fmt.Sprintf("Last signed: checkpoint %d", stats.LastSignedSeq)
+		if a.lastSigned.seq == stats.LastSignedSeq {
+			at := a.lastSigned.at
+			lastSigned += fmt.Sprintf(" at %s (%s ago)", at.Format("15:04:05"), time.Since(at).Truncate(time.Second))
+		}
+	}
+
+	lines := []string{streak, uptime, lastSigned, ""}
+	if len(a.spans) == 0 {
+		lines = append(lines, "Recent misses: none since start")
+	} else {
+		lines = append(lines, "Recent misses, newest first:")
+		ongoing := len(a.marks) > 0 && a.marks[len(a.marks)-1].state != markSigned
+		for i := len(a.spans) - 1; i >= 0; i-- {
+			lines = append(lines, renderMissSpan(a.spans[i], ongoing && i == len(a.spans)-1))
+		}
+	}
+	lines = append(lines, "", footer)
+	return dashboardPanelStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+// renderMissSpan shows one span of misses, with its checkpoints and times
+func renderMissSpan(s missSpan, ongoing bool) string {
+	line := fmt.Sprintf("  %d–%d · %d checkpoint(s) · %s–%s (%s)", s.from, s.to, s.misses,
+		s.start.Format("15:04:05"), s.end.Format("15:04:05"), s.end.Sub(s.start).Truncate(time.Second))
+	if ongoing {
+		line += " · ongoing"
+	}
+	switch {
+	case s.planned:
+		return plannedCellStyle.Render(line + " · maintenance")
+	case ongoing:
+		return inactiveStyle.Render(line)
+	}
+	return line
+}
@@ -0,0 +1,67 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestActivityRecordSpans(t *testing.T) {
+	at := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		marks  []mark
+		wantN  int
+		want   missSpan // The latest span
+		render string
+	}{
+		{
+			name:   "consecutive misses",
+			marks:  []mark{{seq: 10, state: markMissed}, {seq: 11, state: markMissed}, {seq: 12, state: markMissed}},
+			wantN:  1,
+			want:   missSpan{from: 10, to: 12, misses: 3},
+			render: "10–12 · 3 checkpoint(s)",
+		},
+		{
+			name:   "skipped samples are not counted",
+			marks:  []mark{{seq: 10, state: markMissed}, {seq: 25, state: markMissed}},
+			wantN:  1,
+			want:   missSpan{from: 10, to: 25, misses: 2},
+			render: "10–25 · 2 checkpoint(s)",
+		},
+		{
+			name:   "a signature ends the span",
+			marks:  []mark{{seq: 10, state: markMissed}, {seq: 11, state: markSigned}, {seq: 12, state: markPlanned}},
+			wantN:  2,
+			want:   missSpan{from: 12, to: 12, misses: 1, planned: true},
+			render: "12–12 · 1 checkpoint(s)",
+		},
+		{
+			name:   "unplanned miss in a planned span",
+			marks:  []mark{{seq: 10, state: markPlanned}, {seq: 11, state: markMissed}},
+			wantN:  1,
+			want:   missSpan{from: 10, to: 11, misses: 2},
+			render: "10–11 · 2 checkpoint(s)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var a activity
+			for _, mk := range tt.marks {
+				mk.at = at
+				a.record(mk)
+			}
+			if len(a.spans) != tt.wantN {
+				t.Fatalf("record() kept %d spans, want %d", len(a.spans), tt.wantN)
+			}
+			got := a.spans[len(a.spans)-1]
+			tt.want.start, tt.want.end = at, at
+			if got != tt.want {
+				t.Errorf("latest span = %+v, want %+v", got, tt.want)
+			}
+			if line := renderMissSpan(got, false); !strings.Contains(line, tt.render) {
+				t.Errorf("renderMissSpan() = %q, want it to contain %q", line, tt.render)
+			}
+		})
+	}
+}
@@ -0,0 +1,322 @@
+package tui
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"strings"
+
+	"suitop/internal/history"
+	"suitop/internal/types"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// heatmapHistory is the number of recent checkpoints kept for the heatmap
+const heatmapHistory = 3600
+
+// heatmapLabelWidth is the width of the validator names left of the heatmap
+const heatmapLabelWidth = 18
+
+// correlatedMissShare is the share of the committee that must miss a
+// checkpoint for it to be highlighted, and minCorrelatedMisses the least
+// number of validators
+const (
+	correlatedMissShare = 0.1
+	minCorrelatedMisses = 2
+)
+
+// heatCell is a validator's signature on one checkpoint of the heatmap
+type heatCell uint8
+
+const (
+	heatUnknown    heatCell = iota // Checkpoint not kept nor in the history store, or its committee is unknown
+	heatSigned                     // Signed
+	heatMissed                     // Missed
+	heatPlanned                    // Missed inside a maintenance window
+	heatCorrelated                 // Missed along with many other validators
+)
+
+// heatColumn is one checkpoint of the heatmap
+type heatColumn struct {
+	known   bool           // False if the checkpoint is neither kept nor in the history store
+	signers history.Bitset // Signers by bitmap index
+	size    int            // Committee size
+	index   map[string]int // Bitmap indices by address for another epoch's committee; nil for the current one
+	planned history.Bitset // Missed inside a maintenance window, by bitmap index
+	hot     bool           // Missed by many validators outside maintenance windows
+}
+
+// scrollback holds the checkpoints of a heatmap scrolled back past the kept
+// ones, read from the history store
+type scrollback struct {
+	from, to uint64 // Range read; records holds the stored checkpoints in it
+	records  map[uint64]history.CheckpointRecord
+}
+
+// recordCheckpoint keeps the snapshot's checkpoint for the heatmap, with the
+// validators that missed it in maintenance, dropping the ones older than
+// heatmapHistory. A heatmap scrolled back stays on the same checkpoints.
+func (m *Model) recordCheckpoint(msg SnapshotMsg) {
+	cp := msg.Checkpoint
+	m.checkpoints[cp.Sequence] = cp
+	var planned history.Bitset
+	for _, v := range msg.Committee {
+		if s := msg.Stats[v.SuiAddress]; s.InMaintenance && !s.SignedCurrent {
+			planned.Set(v.BitmapIndex)
+		}
+	}
+	if planned != nil {
+		m.planned[cp.Sequence] = planned
+	}
+	if cp.Sequence > m.latestSeq {
+		if m.heatmapBack > 0 && m.latestSeq > 0 {
+			m.heatmapBack += int(cp.Sequence - m.latestSeq)
+		}
+		m.latestSeq = cp.Sequence
+	}
+	if len(m.checkpoints) > heatmapHistory {
+		for seq := range m.checkpoints {
+			if seq+heatmapHistory <= m.latestSeq {
+				delete(m.checkpoints, seq)
+				delete(m.planned, seq)
+			}
+		}
+	}
+}
+
+// heatmapDepth returns how many checkpoints back the heatmap can show: the
+// kept ones, or those in the history store if it reaches further
+func (m Model) heatmapDepth() int {
+	depth := len(m.checkpoints)
+	if m.history != nil {
+		if first, ok := m.history.First(); ok && first <= m.latestSeq {
+			depth = max(depth, int(m.latestSeq-first+1))
+		}
+	}
+	return depth
+}
+
+// loadScrollback reads the checkpoints on screen that are not kept from the
+// history store, unless they were read already. It is called when the window
+// moves back, not on new checkpoints, which are kept.
+func (m *Model) loadScrollback() {
+	if m.history == nil {
+		return
+	}
+	oldest, newest := m.heatmapWindow()
+	from, to, missing := uint64(0), uint64(0), false
+	for seq := oldest; seq <= newest; seq++ {
+		if _, ok := m.checkpoints[seq]; !ok {
+			if !missing {
+				from, missing = seq, true
+			}
+			to = seq
+		}
+	}
+	if !missing || (m.scrollback.records != nil && from >= m.scrollback.from && to <= m.scrollback.to) {
+		return
+	}
+	records, err := m.history.Range(from, to)
+	if err != nil {
+		log.Printf("Warning: failed to read checkpoints %d-%d from history: %v", from, to, err)
+		return
+	}
+	m.scrollback = scrollback{from: from, to: to, records: make(map[uint64]history.CheckpointRecord, len(records))}
+	for _, r := range records {
+		m.scrollback.records[r.Sequence] = r
+	}
+}
+
+// heatColumn returns the signatures on one checkpoint, kept or read from the
+// history store. committees caches the bitmap indices of earlier epochs.
+func (m Model) heatColumn(seq uint64, threshold int, committees map[uint64]map[string]int) heatColumn {
+	var col heatColumn
+	var epoch uint64
+	if cp, ok := m.checkpoints[seq]; ok {
+		epoch = cp.Epoch
+		col = heatColumn{known: true, signers: history.NewBitset(cp.Signers), size: int(cp.ValidatorCount), planned: m.planned[seq]}
+	} else if r, ok := m.scrollback.records[seq]; ok {
+		epoch = r.Epoch
+		col = heatColumn{known: true, signers: r.Signers, size: len(m.committee), planned: r.Planned}
+	} else {
+		return heatColumn{}
+	}
+
+	if epoch != m.epoch {
+		index, ok := committees[epoch]
+		if !ok && m.history != nil {
+			index = make(map[string]int)
+			for i, member := range m.history.Committee(epoch) {
+				index[member.Address] = i
+			}
+			committees[epoch] = index
+		}
+		if len(index) == 0 {
+			return heatColumn{} // Committee not recorded
+		}
+		col.index, col.size = index, len(index)
+	}
+	col.hot = col.size-col.signers.Count()-col.planned.Count() >= threshold
+	return col
+}
+
+// cell returns a validator's signature on the column's checkpoint
+func (c heatColumn) cell(v types.ValidatorInfo) heatCell {
+	idx := v.BitmapIndex
+	if c.index != nil {
+		i, ok := c.index[v.SuiAddress]
+		if !ok {
+			return heatUnknown
+		}
+		idx = i
+	}
+	switch {
+	case !c.known || idx < 0 || idx >= c.size:
+		return heatUnknown
+	case c.signers.Has(idx):
+		return heatSigned
+	case c.planned.Has(idx):
+		return heatPlanned
+	case c.hot:
+		return heatCorrelated
+	default:
+		return heatMissed
+	}
+}
+
+// correlatedMisses returns how many validators must miss a checkpoint for it
+// to be highlighted
+func correlatedMisses(committeeSize int) int {
+	return max(minCorrelatedMisses, int(math.Ceil(float64(committeeSize)*correlatedMissShare)))
+}
+
+// heatmapWidth returns the number of checkpoints that fit on screen
+func (m Model) heatmapWidth() int {
+	return max(m.width-4-heatmapLabelWidth, 1) // Border and padding
+}
+
+// heatmapWindow returns the oldest and newest checkpoint on screen
+func (m Model) heatmapWindow() (uint64, uint64) {
+	cols := m.heatmapWidth()
+	back := uint64(clamp(m.heatmapBack, 0, max(m.heatmapDepth()-cols, 0)))
+	if back > m.latestSeq {
+		back = m.latestSeq
+	}
+	newest := m.latestSeq - back
+	oldest := uint64(0)
+	if newest >= uint64(cols) {
+		oldest = newest - uint64(cols) + 1
+	}
+	return oldest, newest
+}
+
+// handleHeatmapKey scrolls the heatmap through the kept checkpoints, and the
+// older ones of the history store if any. It reports whether the key was used.
+func (m *Model) handleHeatmapKey(msg tea.KeyMsg) bool {
+	page := m.heatmapWidth()
+	switch msg.String() {
+	case "left":
+		m.heatmapBack++
+	case "right":
+		m.heatmapBack--
+	case "[":
+		m.heatmapBack += page
+	case "]":
+		m.heatmapBack -= page
+	default:
+		return false
+	}
+	m.heatmapBack = clamp(m.heatmapBack, 0, max(m.heatmapDepth()-page, 0))
+	m.loadScrollback()
+	return true
+}
+
+// renderHeatmap shows the signatures of the validators in the table, one row
+// each, on the checkpoints in the scroll window, oldest first
+func renderHeatmap(m Model) string {
+	if len(m.checkpoints) == 0 {
+		return dashboardPanelStyle.Render(mutedStyle.Render("Waiting for checkpoints..."))
+	}
+	oldest, newest := m.heatmapWindow()
+	threshold := correlatedMisses(len(m.committee))
+
+	columns := make([]heatColumn, 0, newest-oldest+1)
+	markers := make([]string, 0, newest-oldest+1)
+	committees := make(map[uint64]map[string]int)
+	correlated := 0
+	for seq := oldest; seq <= newest; seq++ {
+		col := m.heatColumn(seq, threshold, committees)
+		columns = append(columns, col)
+		if col.hot {
+			correlated++
+			markers = append(markers, "▼")
+		} else {
+			markers = append(markers, " ")
+		}
+	}
+
+	label := lipgloss.NewStyle().Width(heatmapLabelWidth).MaxWidth(heatmapLabelWidth).Inline(true)
+	lines := []string{label.Render("") + inactiveStyle.Render(strings.Join(markers, ""))}
+
+	height := m.tableHeight()
+	for i := m.offset; i < len(m.rows) && i < m.offset+height; i++ {
+		r := m.rows[i]
+		name := r.validator.Name
+		if r.watched {
+			name = "★ " + name
+		}
+		name = label.Render(name)
+		if i == m.cursor {
+			name = selectedRowStyle.Render(name)
+		}
+
+		cells := make([]heatCell, len(columns))
+		for j, col := range columns {
+			cells[j] = col.cell(r.validator)
+		}
+		lines = append(lines, name+renderHeatCells(cells))
+	}
+	for len(lines) < height+1 {
+		lines = append(lines, "")
+	}
+
+	position := "live"
+	if newest < m.latestSeq {
+		position = fmt.Sprintf("%d behind", m.latestSeq-newest)
+	}
+	status := []string{
+		fmt.Sprintf("Checkpoints %d–%d (%s)", oldest, newest, position),
+		fmt.Sprintf("%d ▼ with %d+ validators missing outside maintenance", correlated, threshold),
+		mutedStyle.Render("←/→ scroll · [/] page · h table"),
+	}
+	lines = append(lines, statusLine(m, status))
+	return dashboardPanelStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+// renderHeatCells renders one cell per checkpoint, styling runs of the same
+// cell at once
+func renderHeatCells(cells []heatCell) string {
+	var b strings.Builder
+	for i := 0; i < len(cells); {
+		j := i
+		for j < len(cells) && cells[j] == cells[i] {
+			j++
+		}
+		switch cells[i] {
+		case heatSigned:
+			b.WriteString(signedCellStyle.Render(strings.Repeat("█", j-i)))
+		case heatMissed:
+			b.WriteString(missedCellStyle.Render(strings.Repeat("█", j-i)))
+		case heatPlanned:
+			b.WriteString(plannedCellStyle.Render(strings.Repeat("█", j-i)))
+		case heatCorrelated:
+			b.WriteString(correlatedCellStyle.Render(strings.Repeat("█", j-i)))
+		default:
+			b.WriteString(mutedStyle.Render(strings.Repeat("·", j-i)))
+		}
+		i = j
+	}
+	return b.String()
+}
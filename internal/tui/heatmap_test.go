@@ -0,0 +1,185 @@
+package tui
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"suitop/internal/config"
+	"suitop/internal/history"
+	"suitop/internal/types"
+)
+
+// testCommittee returns n validators with addresses 0x0, 0x1, ... in bitmap
+// index order
+func testCommittee(n int) []types.ValidatorInfo {
+	committee := make([]types.ValidatorInfo, n)
+	for i := range committee {
+		committee[i] = types.ValidatorInfo{Name: fmt.Sprintf("v%d", i), SuiAddress: fmt.Sprintf("0x%d", i), BitmapIndex: i, VotingPower: 1000}
+	}
+	return committee
+}
+
+// snapshot returns the snapshot of a checkpoint the committee signed except
+// for the missing indices, of which those in maintenance are listed
+func snapshot(committee []types.ValidatorInfo, epoch, seq uint64, missing []int, maintenance ...int) SnapshotMsg {
+	stats := make(map[string]types.ValidatorStats)
+	cp := types.CheckpointInfo{Sequence: seq, Epoch: epoch, ValidatorCount: uint64(len(committee))}
+	for i, v := range committee {
+		s := types.ValidatorStats{SignedCurrent: true}
+		for _, idx := range missing {
+			if idx == i {
+				s.SignedCurrent = false
+			}
+		}
+		for _, idx := range maintenance {
+			if idx == i {
+				s.InMaintenance = true
+			}
+		}
+		if s.SignedCurrent {
+			cp.Signers = append(cp.Signers, uint32(i))
+		}
+		stats[v.SuiAddress] = s
+	}
+	return SnapshotMsg{Epoch: epoch, CheckpointSeq: seq, Committee: committee, Stats: stats, Checkpoint: cp}
+}
+
+func TestHeatmapCells(t *testing.T) {
+	committee := testCommittee(10) // Two misses make a correlated checkpoint
+	tests := []struct {
+		name        string
+		missing     []int
+		maintenance []int
+		wantHot     bool
+		want        map[int]heatCell // By bitmap index
+	}{
+		{
+			name: "all signed",
+			want: map[int]heatCell{0: heatSigned, 9: heatSigned},
+		},
+		{
+			name:    "single miss",
+			missing: []int{3},
+			want:    map[int]heatCell{3: heatMissed, 4: heatSigned},
+		},
+		{
+			name:    "correlated misses",
+			missing: []int{3, 4},
+			wantHot: true,
+			want:    map[int]heatCell{3: heatCorrelated, 4: heatCorrelated},
+		},
+		{
+			name:        "planned misses are not correlated",
+			missing:     []int{3, 4},
+			maintenance: []int{3},
+			want:        map[int]heatCell{3: heatPlanned, 4: heatMissed},
+		},
+		{
+			name:        "in maintenance but signed",
+			missing:     []int{3, 4},
+			maintenance: []int{5},
+			wantHot:     true,
+			want:        map[int]heatCell{3: heatCorrelated, 5: heatSigned},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := New(1, committee, "test")
+			m.applySnapshot(snapshot(committee, 1, 100, tt.missing, tt.maintenance...))
+			col := m.heatColumn(100, correlatedMisses(len(committee)), map[uint64]map[string]int{})
+			if col.hot != tt.wantHot {
+				t.Errorf("hot = %v, want %v", col.hot, tt.wantHot)
+			}
+			for idx, want := range tt.want {
+				if got := col.cell(committee[idx]); got != want {
+					t.Errorf("cell(%s) = %v, want %v", committee[idx].Name, got, want)
+				}
+			}
+			if got := m.heatColumn(99, 2, nil).cell(committee[0]); got != heatUnknown {
+				t.Errorf("cell of a checkpoint not kept = %v, want %v", got, heatUnknown)
+			}
+		})
+	}
+}
+
+func TestHeatmapScrollback(t *testing.T) {
+	store, err := history.Open(config.HistoryConfig{Enabled: true, Folder: t.TempDir()})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer store.Close()
+
+	// Epoch 1 had the committee in reverse bitmap order, and only its first
+	// member (0x2) signed, so the other two missed together, except from
+	// checkpoint 41 on, when 0x1 (index 1) was in maintenance
+	committee := testCommittee(3)
+	if err := store.SetCommittee(1, []history.CommitteeMember{{Address: "0x2"}, {Address: "0x1"}, {Address: "0x0"}}); err != nil {
+		t.Fatalf("SetCommittee: %v", err)
+	}
+	start := time.Now().Add(-time.Hour)
+	for seq := uint64(1); seq <= 50; seq++ {
+		r := history.CheckpointRecord{Sequence: seq, Epoch: 1, Timestamp: start.Add(time.Duration(seq) * time.Second), Signers: history.NewBitset([]uint32{0})}
+		if seq > 40 {
+			r.Planned = history.NewBitset([]uint32{1})
+		}
+		if err := store.Record(r); err != nil {
+			t.Fatalf("Record: %v", err)
+		}
+	}
+
+	m := New(2, committee, "test")
+	m.SetHistory(store)
+	m.width = 4 + heatmapLabelWidth + 10 // Ten checkpoints on screen
+	m.heatmap = true
+	for seq := uint64(51); seq <= 60; seq++ {
+		m.applySnapshot(snapshot(committee, 2, seq, nil))
+	}
+
+	tests := []struct {
+		name           string
+		back           int
+		oldest, newest uint64
+		want           []heatCell // Of 0x0, 0x1 and 0x2 on the oldest checkpoint
+		wantHot        bool
+		read           [2]uint64 // Range read from the history store
+	}{
+		{"live", 0, 51, 60, []heatCell{heatSigned, heatSigned, heatSigned}, false, [2]uint64{}},
+		{"planned miss from history", 10, 41, 50, []heatCell{heatMissed, heatPlanned, heatSigned}, false, [2]uint64{41, 50}},
+		{"clamped to the oldest stored", 1000, 1, 10, []heatCell{heatCorrelated, heatCorrelated, heatSigned}, true, [2]uint64{1, 10}},
+		{"only the range not kept", 5, 46, 55, []heatCell{heatMissed, heatPlanned, heatSigned}, false, [2]uint64{46, 50}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m.heatmapBack = tt.back
+			m.scrollback = scrollback{}
+			m.loadScrollback()
+			oldest, newest := m.heatmapWindow()
+			if oldest != tt.oldest || newest != tt.newest {
+				t.Fatalf("heatmapWindow() = %d, %d; want %d, %d", oldest, newest, tt.oldest, tt.newest)
+			}
+			if read := [2]uint64{m.scrollback.from, m.scrollback.to}; read != tt.read {
+				t.Errorf("read checkpoints %d-%d from history, want %d-%d", read[0], read[1], tt.read[0], tt.read[1])
+			}
+			col := m.heatColumn(oldest, correlatedMisses(len(committee)), map[uint64]map[string]int{})
+			if col.hot != tt.wantHot {
+				t.Errorf("hot = %v, want %v", col.hot, tt.wantHot)
+			}
+			for i, want := range tt.want {
+				if got := col.cell(committee[i]); got != want {
+					t.Errorf("cell(%s) = %v, want %v", committee[i].SuiAddress, got, want)
+				}
+			}
+		})
+	}
+
+	// New checkpoints are kept, so they do not read the history store again,
+	// although the window starts before the kept ones
+	m.heatmapBack = 0
+	m.width = 4 + heatmapLabelWidth + 20
+	m.scrollback = scrollback{}
+	next, _ := m.Update(snapshot(committee, 2, 61, nil))
+	if got := next.(Model).scrollback; got.records != nil {
+		t.Errorf("a new checkpoint read checkpoints %d-%d from history", got.from, got.to)
+	}
+}
@@ -6,3 +6,13 @@ import (
 
 // Alias the SnapshotMsg from types to use it in this package
 type SnapshotMsg = types.SnapshotMsg
+
+// AlertsMsg carries the currently pending and firing alerts
+type AlertsMsg []types.AlertInfo
+
+// NodeHealthMsg carries the result of the latest node health poll
+type NodeHealthMsg types.NodeHealth
+
+// ValidatorMetaMsg carries the staking metadata of the validator shown by the
+// single-validator dashboard
+type ValidatorMetaMsg types.ValidatorMeta
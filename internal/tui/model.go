@@ -1,7 +1,9 @@
 package tui
 
 import (
+	"suitop/internal/history"
 	"suitop/internal/types"
+	"suitop/internal/watch"
 
 	"github.com/charmbracelet/bubbles/progress"
 	tea "github.com/charmbracelet/bubbletea"
@@ -25,6 +27,33 @@ type Model struct {
 	ready                              bool
 	leftWidth, rightWidth, middleWidth int
 	NetworkName                        string // Added to display the current network
+	alerts                             []types.AlertInfo
+	node                               types.NodeHealth // Zero until the first node health poll
+	watch                              *watch.List      // Optional; watched validators are pinned above the table
+	focus                              *focus           // Set in single-validator mode, replacing the table with a dashboard
+
+	// Validator table
+	rows        []tableRow // Shown validators, filtered and sorted
+	cursor      int        // Index of the selected row
+	offset      int        // Index of the first row on screen
+	selected    string     // Address of the selected validator, kept when the rows are rebuilt
+	sortBy      sortKey
+	sortReverse bool
+	filtering   bool                      // Typing the filter after '/'
+	filter      string                    // Shows only validators whose name or address contains it
+	detail      string                    // Address of the validator in the detail view; empty when closed
+	activity    map[string]*activity      // Recent signatures by validator address, for the detail view
+	heatmap     bool                      // Show the signature heatmap instead of the table
+	heatmapBack int                       // Checkpoints the heatmap is scrolled back; 0 follows new ones
+	planned     map[uint64]history.Bitset // Validators that missed each kept checkpoint in maintenance, by bitmap index
+	scrollback  scrollback                // Checkpoints read from the history store for the heatmap
+
+	// Participation charts
+	history     *history.Store // Optional; feeds the charts and the heatmap beyond the kept checkpoints
+	charts      bool
+	chartMode   chartMode
+	chartHeight int
+	chartPoints []chartPoint
 
 	// Calculated fields for progress bars
 	signedValidators  int
@@ -57,6 +86,9 @@ func New(epochID uint64, validators []types.ValidatorInfo, networkName string) M
 		validatorBar:      validatorBar,
 		votingPowerBar:    votingPowerBar,
 		checkpoints:       make(map[uint64]types.CheckpointInfo),
+		planned:           make(map[uint64]history.Bitset),
+		activity:          make(map[string]*activity),
+		chartHeight:       defaultChartHeight,
 		width:             0,
 		height:            0,
 		ready:             false,
@@ -68,6 +100,18 @@ func New(epochID uint64, validators []types.ValidatorInfo, networkName string) M
 	}
 }
 
+// SetWatchlist pins the listed validators above the validator table and, if
+// the list shows only watched validators, hides the others.
+func (m *Model) SetWatchlist(l *watch.List) {
+	m.watch = l
+}
+
+// SetFocus replaces the validator table with a dashboard of the validator at
+// address. It must be called before the program starts.
+func (m *Model) SetFocus(address string) {
+	m.focus = &focus{address: address}
+}
+
 // Init initializes the bubble tea model
 func (m Model) Init() tea.Cmd {
 	return nil
@@ -75,6 +119,8 @@ func (m Model) Init() tea.Cmd {
 
 // applySnapshot updates the model's state with new snapshot data
 func (m *Model) applySnapshot(msg SnapshotMsg) {
+	m.recordActivity(msg)
+	m.recordCheckpoint(msg)
 	m.epoch = msg.Epoch
 	m.checkpointSeq = msg.CheckpointSeq
 	m.totalWithSig = msg.TotalWithSig
@@ -82,6 +128,9 @@ func (m *Model) applySnapshot(msg SnapshotMsg) {
 	m.totalPower = msg.TotalPower
 	m.committee = msg.Committee
 	m.stats = msg.Stats
+	if m.focus != nil {
+		m.focus.record(msg)
+	}
 
 	// Update calculated fields
 	m.totalValidators = len(m.committee)
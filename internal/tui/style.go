@@ -78,6 +78,59 @@ var (
 	warningStyle = lipgloss.NewStyle().
 			Foreground(warningColor)
 
+	mutedStyle = lipgloss.NewStyle().
+			Foreground(mutedColor)
+
+	// Alerts panel style, shown between the header and the validator table when alerts are active
+	alertPanelStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(errorColor).
+			Padding(0, 1)
+
+	// Node health panel style, shown below the header once the node has been polled
+	nodePanelStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(primaryColor).
+			Padding(0, 1)
+
+	// Watch panel style, pinning the watched validators above the validator table
+	watchPanelStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(primaryColor).
+			Padding(0, 1)
+
+	// Groups panel style, one aggregate row per operator group of the labels file
+	groupsPanelStyle = lipgloss.NewStyle().
+				Border(lipgloss.RoundedBorder()).
+				BorderForeground(primaryColor).
+				Padding(0, 1)
+
+	// Single-validator dashboard panel style
+	dashboardPanelStyle = lipgloss.NewStyle().
+				Border(lipgloss.RoundedBorder()).
+				BorderForeground(primaryColor).
+				Padding(0, 1)
+
+	// Selected row of the validator table
+	selectedRowStyle = lipgloss.NewStyle().
+				Foreground(textColor).
+				Background(primaryColor).
+				Bold(true)
+
+	// Participation chart panel style, two side by side
+	chartPanelStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(primaryColor).
+			Padding(0, 1)
+
+	// Signature strip cells
+	signedCellStyle  = lipgloss.NewStyle().Foreground(successColor)
+	missedCellStyle  = lipgloss.NewStyle().Foreground(errorColor)
+	plannedCellStyle = lipgloss.NewStyle().Foreground(warningColor)
+
+	// Heatmap cells missed along with many other validators
+	correlatedCellStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#ED64A6"))
+
 	// Progress bar style variants
 	validatorBarStyle   = lipgloss.NewStyle().Foreground(validatorBarColor)
 	votingPowerBarStyle = lipgloss.NewStyle().Foreground(powerBarColor)
@@ -109,6 +162,12 @@ func AdjustStyles(total, left, middle, right int) {
 	// Set width for the new main content container
 	// It spans the full available width, accounting for its own padding/border.
 	mainContentContainerStyle = mainContentContainerStyle.Width(total - 2)
+	alertPanelStyle = alertPanelStyle.Width(total - 2)
+	nodePanelStyle = nodePanelStyle.Width(total - 2)
+	watchPanelStyle = watchPanelStyle.Width(total - 2)
+	groupsPanelStyle = groupsPanelStyle.Width(total - 2)
+	dashboardPanelStyle = dashboardPanelStyle.Width(total - 2)
+	chartPanelStyle = chartPanelStyle.Width(boxWidth / 2)
 	// Height for mainContentContainerStyle will be determined by its content (the tables).
 
 	// Make header panels same height and width
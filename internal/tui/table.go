@@ -0,0 +1,437 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"suitop/internal/types"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// sortKey orders the validator table
+type sortKey int
+
+const (
+	sortName sortKey = iota
+	sortUptime
+	sortPower
+	sortStatus
+	sortStreak
+	numSortKeys
+)
+
+var sortKeyNames = [...]string{"name", "uptime", "voting power", "status", "miss streak"}
+
+// sortColumns are the table columns of the sort keys, and sortDescending
+// whether a key's natural order is descending
+var (
+	sortColumns    = [...]int{1, 2, 3, 0, 4}
+	sortDescending = [...]bool{false, false, true, false, true}
+)
+
+// tableRow is one validator of the validator table
+type tableRow struct {
+	validator types.ValidatorInfo
+	stats     types.ValidatorStats
+	hasStats  bool
+	watched   bool
+	firing    bool
+}
+
+// statusRank orders validators by status, the ones needing attention first
+func (r tableRow) statusRank() int {
+	switch {
+	case !r.hasStats:
+		return 2
+	case r.stats.SignedCurrent:
+		return 3
+	case r.stats.InMaintenance:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// less orders rows by key. Each key has its natural order, worst or largest
+// first except for names; reverse flips it. Rows without a measured uptime
+// come last when sorting by uptime, either way. Ties are ordered by name.
+func (m Model) less(a, b tableRow) bool {
+	if a.watched != b.watched {
+		return a.watched // Watched validators stay pinned at the top
+	}
+	var cmp int
+	switch m.sortBy {
+	case sortUptime:
+		if measured := m.hasUptime(a); measured != m.hasUptime(b) {
+			return measured
+		}
+		cmp = compareFloat(a.stats.Uptime(m.totalWithSig), b.stats.Uptime(m.totalWithSig))
+	case sortPower:
+		cmp = b.validator.VotingPower - a.validator.VotingPower
+	case sortStatus:
+		cmp = a.statusRank() - b.statusRank()
+	case sortStreak:
+		cmp = compareFloat(float64(b.stats.MissStreak), float64(a.stats.MissStreak))
+	}
+	if m.sortReverse {
+		cmp = -cmp
+	}
+	if cmp != 0 {
+		return cmp < 0
+	}
+	if m.sortBy == sortName && m.sortReverse {
+		return a.validator.Name > b.validator.Name
+	}
+	return a.validator.Name < b.validator.Name
+}
+
+// hasUptime reports whether the row has an uptime to sort by, rather than N/A
+func (m Model) hasUptime(r tableRow) bool {
+	return r.hasStats && r.stats.HasUptime(m.totalWithSig)
+}
+
+func compareFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// matchesFilter reports whether the validator's name or address contains the
+// filter, ignoring case
+func matchesFilter(v types.ValidatorInfo, filter string) bool {
+	if filter == "" {
+		return true
+	}
+	filter = strings.ToLower(filter)
+	return strings.Contains(strings.ToLower(v.Name), filter) ||
+		strings.Contains(strings.ToLower(v.ChainName), filter) ||
+		strings.Contains(strings.ToLower(v.SuiAddress), filter)
+}
+
+// refreshTable rebuilds the rows of the validator table from the committee,
+// keeping the selected validator under the cursor
+func (m *Model) refreshTable() {
+	firing := firingValidators(*m)
+	rows := make([]tableRow, 0, len(m.committee))
+	for _, v := range m.committee {
+		if !m.watch.Shown(v) || !matchesFilter(v, m.filter) {
+			continue
+		}
+		stats, ok := m.stats[v.SuiAddress]
+		rows = append(rows, tableRow{
+			validator: v,
+			stats:     stats,
+			hasStats:  ok,
+			watched:   m.watch.Watched(v),
+			firing:    firing[v.SuiAddress],
+		})
+	}
+	sort.SliceStable(rows, func(i, j int) bool { return m.less(rows[i], rows[j]) })
+	m.rows = rows
+
+	m.cursor = 0
+	for i, r := range rows {
+		if r.validator.SuiAddress == m.selected {
+			m.cursor = i
+			break
+		}
+	}
+	m.moveCursor(0)
+}
+
+// moveCursor moves the cursor by n rows and scrolls it into view
+func (m *Model) moveCursor(n int) {
+	m.cursor = clamp(m.cursor+n, 0, len(m.rows)-1)
+	if len(m.rows) == 0 {
+		m.selected = ""
+		m.offset = 0
+		return
+	}
+	m.selected = m.rows[m.cursor].validator.SuiAddress
+
+	height := m.tableHeight()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+height {
+		m.offset = m.cursor - height + 1
+	}
+	m.offset = clamp(m.offset, 0, max(len(m.rows)-height, 0))
+}
+
+func clamp(v, low, high int) int {
+	return max(low, min(v, high))
+}
+
+// tableHeight returns the number of validator rows that fit on screen, in
+// the table or the heatmap
+func (m Model) tableHeight() int {
+	used := lipgloss.Height(lipgloss.JoinVertical(lipgloss.Left, m.sectionsAbove()...))
+	if m.heatmap {
+		// Panel border, the marker line and the status line
+		return max(m.height-used-4, 1)
+	}
+	// Container border and padding, the column headers and the status line
+	return max(m.height-used-6, 1)
+}
+
+// tableTop returns the screen line of the first validator row
+func (m Model) tableTop() int {
+	used := lipgloss.Height(lipgloss.JoinVertical(lipgloss.Left, m.sectionsAbove()...))
+	if m.heatmap {
+		// Panel border and the marker line
+		return used + 2
+	}
+	// Container border, its top padding and the column headers
+	return used + 3
+}
+
+// handleFilterKey edits the filter typed after '/'
+func (m *Model) handleFilterKey(msg tea.KeyMsg) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.filtering = false
+	case tea.KeyEsc:
+		m.filtering = false
+		m.filter = ""
+	case tea.KeyBackspace:
+		if r := []rune(m.filter); len(r) > 0 {
+			m.filter = string(r[:len(r)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		m.filter += string(msg.Runes)
+	}
+	m.refreshTable()
+}
+
+// handleTableKey moves through and reorders the validator table. It reports
+// whether the key was used.
+func (m *Model) handleTableKey(msg tea.KeyMsg) bool {
+	page := m.tableHeight()
+	switch msg.String() {
+	case "up", "k":
+		m.moveCursor(-1)
+	case "down", "j":
+		m.moveCursor(1)
+	case "pgup", "b":
+		m.moveCursor(-page)
+	case "pgdown", "f", " ":
+		m.moveCursor(page)
+	case "home", "g":
+		m.moveCursor(-len(m.rows))
+	case "end", "G":
+		m.moveCursor(len(m.rows))
+	case "s":
+		m.sortBy = (m.sortBy + 1) % numSortKeys
+		m.sortReverse = false
+		m.refreshTable()
+	case "r":
+		m.sortReverse = !m.sortReverse
+		m.refreshTable()
+	case "/":
+		m.filtering = true
+	case "h":
+		m.heatmap = !m.heatmap
+		m.moveCursor(0)
+		if m.heatmap {
+			m.loadScrollback()
+		}
+	case "enter":
+		if m.selected != "" {
+			m.detail = m.selected
+		}
+	case "esc":
+		if m.filter != "" {
+			m.filter = ""
+			m.refreshTable()
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+// handleMouse scrolls the validator table with the wheel and selects the
+// clicked row; clicking the selected row opens its detail view
+func (m *Model) handleMouse(msg tea.MouseMsg) {
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		m.moveCursor(-1)
+	case tea.MouseButtonWheelDown:
+		m.moveCursor(1)
+	case tea.MouseButtonLeft:
+		if msg.Action != tea.MouseActionPress {
+			return
+		}
+		line := msg.Y - m.tableTop()
+		if line < 0 || line >= m.tableHeight() || m.offset+line >= len(m.rows) {
+			return
+		}
+		if m.offset+line == m.cursor {
+			m.detail = m.selected
+			return
+		}
+		m.moveCursor(m.offset + line - m.cursor)
+	}
+}
+
+// tableColumn is a column of the validator table
+type tableColumn struct {
+	title  string
+	width  int
+	render func(r tableRow) string
+}
+
+// tableColumns returns the columns that fit the terminal width
+func (m Model) tableColumns() []tableColumn {
+	totalPower := 0
+	for _, v := range m.committee {
+		totalPower += v.VotingPower
+	}
+	columns := []tableColumn{
+		{"Status", 7, func(r tableRow) string {
+			status := "❓"
+			if r.hasStats {
+				status = statusIcon(r.stats)
+			}
+			if r.firing {
+				status += "🔔"
+			}
+			return status
+		}},
+		{"Validator", 30, func(r tableRow) string {
+			if r.watched {
+				return "★ " + r.validator.Name
+			}
+			return r.validator.Name
+		}},
+		{"Signed %", 20, func(r tableRow) string {
+			if !r.hasStats {
+				return renderBar(0) + " N/A"
+			}
+			uptime := r.stats.Uptime(m.totalWithSig)
+			return fmt.Sprintf("%s %s", renderBar(uptime), formatUptime(r.stats, m.totalWithSig))
+		}},
+		{"Power", 14, func(r tableRow) string {
+			share := 0.0
+			if totalPower > 0 {
+				share = float64(r.validator.VotingPower) / float64(totalPower) * 100
+			}
+			return fmt.Sprintf("%5d %5.2f%%", r.validator.VotingPower, share)
+		}},
+		{"Streak", 8, func(r tableRow) string {
+			if !r.hasStats || r.stats.MissStreak == 0 {
+				return ""
+			}
+			return fmt.Sprint(r.stats.MissStreak)
+		}},
+	}
+	if hasPlannedDowntime(m) {
+		columns = append(columns, tableColumn{"Down P/U", 10, func(r tableRow) string {
+			if !r.hasStats {
+				return "N/A"
+			}
+			return fmt.Sprintf("%d/%d", r.stats.PlannedMisses, r.stats.UnplannedMisses(m.totalWithSig))
+		}})
+	}
+
+	// The address takes the remaining width, if there is enough of it
+	used := 0
+	for _, c := range columns {
+		used += c.width + 2 // Cell padding
+	}
+	if rest := m.width - 6 - used - 2; rest >= 20 {
+		columns = append(columns, tableColumn{"Address", rest, func(r tableRow) string {
+			return r.validator.SuiAddress
+		}})
+	}
+	return columns
+}
+
+// renderMainContent shows the validator table with a status line below it
+func renderMainContent(m Model) string {
+	// Only show the table once we have committee data
+	if len(m.committee) == 0 {
+		return mainContentContainerStyle.Render("Waiting for validator data...")
+	}
+
+	columns := m.tableColumns()
+	cell := func(c tableColumn, s string) string {
+		return lipgloss.NewStyle().Width(c.width).MaxWidth(c.width).Inline(true).Render(s)
+	}
+
+	headers := make([]string, len(columns))
+	for i, c := range columns {
+		title := c.title
+		if i == sortColumns[m.sortBy] {
+			title += m.sortArrow()
+		}
+		headers[i] = tableHeaderStyle.Render(cell(c, title))
+	}
+	lines := []string{lipgloss.JoinHorizontal(lipgloss.Top, headers...)}
+
+	height := m.tableHeight()
+	for i := m.offset; i < len(m.rows) && i < m.offset+height; i++ {
+		r := m.rows[i]
+		cells := make([]string, len(columns))
+		for j, c := range columns {
+			cells[j] = tableCellStyle.Render(cell(c, c.render(r)))
+		}
+		line := lipgloss.JoinHorizontal(lipgloss.Top, cells...)
+		if i == m.cursor {
+			line = selectedRowStyle.Render(line)
+		} else if r.hasStats && !r.stats.SignedCurrent && !r.stats.InMaintenance {
+			line = inactiveStyle.Render(line)
+		}
+		lines = append(lines, line)
+	}
+	for len(lines) < height+1 {
+		lines = append(lines, "")
+	}
+	lines = append(lines, renderTableStatus(m))
+
+	return mainContentContainerStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+// sortArrow marks the sorted column with the direction of the order
+func (m Model) sortArrow() string {
+	desc := sortDescending[m.sortBy]
+	if m.sortReverse {
+		desc = !desc
+	}
+	if desc {
+		return " ▼"
+	}
+	return " ▲"
+}
+
+// renderTableStatus shows the position in the table, the sort order, the
+// filter and the keys
+func renderTableStatus(m Model) string {
+	position := fmt.Sprintf("%d/%d", min(m.cursor+1, len(m.rows)), len(m.rows))
+	if len(m.rows) == 0 {
+		position = "0/0"
+	}
+	parts := []string{position, "sort: " + sortKeyNames[m.sortBy]}
+	switch {
+	case m.filtering:
+		parts = append(parts, activeStyle.Render("/"+m.filter+"█"))
+	case m.filter != "":
+		parts = append(parts, "filter: "+m.filter+" (esc clears)")
+	}
+	parts = append(parts, mutedStyle.Render("↑/↓ move · enter details · / filter · s sort · r reverse · h heatmap · q quit"))
+	return statusLine(m, parts)
+}
+
+// statusLine joins the parts of a status line, cut to the panel width so that
+// it never wraps
+func statusLine(m Model, parts []string) string {
+	return lipgloss.NewStyle().MaxWidth(max(m.width-6, 1)).Render(strings.Join(parts, " · "))
+}
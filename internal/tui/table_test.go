@@ -0,0 +1,149 @@
+package tui
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"suitop/internal/types"
+)
+
+// tableRows returns rows named a to e with distinct uptimes, voting power,
+// status and miss streaks
+func tableRows() []tableRow {
+	row := func(name string, power int, stats types.ValidatorStats, hasStats bool) tableRow {
+		return tableRow{validator: types.ValidatorInfo{Name: name, SuiAddress: "0x" + name, VotingPower: power}, stats: stats, hasStats: hasStats}
+	}
+	return []tableRow{
+		row("c", 300, types.ValidatorStats{AttestedCount: 80, MissStreak: 2}, true),
+		row("a", 100, types.ValidatorStats{AttestedCount: 100, SignedCurrent: true}, true),
+		row("e", 500, types.ValidatorStats{}, false),
+		row("b", 200, types.ValidatorStats{AttestedCount: 90, MissStreak: 5, InMaintenance: true}, true),
+		row("d", 400, types.ValidatorStats{AttestedCount: 95, SignedCurrent: true}, true),
+	}
+}
+
+func TestTableSort(t *testing.T) {
+	tests := []struct {
+		name    string
+		sortBy  sortKey
+		reverse bool
+		watched string
+		want    string
+	}{
+		{"name", sortName, false, "", "abcde"},
+		{"name reversed", sortName, true, "", "edcba"},
+		{"uptime, lowest first", sortUptime, false, "", "cbdae"}, // N/A last
+		{"uptime reversed", sortUptime, true, "", "adbce"},
+		{"voting power, largest first", sortPower, false, "", "edcba"},
+		{"status, missing first", sortStatus, false, "", "cbead"},
+		{"status reversed", sortStatus, true, "", "adebc"},
+		{"miss streak, longest first", sortStreak, false, "", "bcade"},
+		{"miss streak ties by name", sortStreak, true, "", "adecb"},
+		{"watched pinned", sortName, false, "d", "dabce"},
+		{"watched pinned when reversed", sortName, true, "b", "bedca"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Model{sortBy: tt.sortBy, sortReverse: tt.reverse, totalWithSig: 100}
+			rows := tableRows()
+			for i := range rows {
+				rows[i].watched = rows[i].validator.Name == tt.watched
+			}
+			sort.SliceStable(rows, func(i, j int) bool { return m.less(rows[i], rows[j]) })
+			got := ""
+			for _, r := range rows {
+				got += r.validator.Name
+			}
+			if got != tt.want {
+				t.Errorf("sorted by %s = %s, want %s", sortKeyNames[tt.sortBy], got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTableSortUptimeNA(t *testing.T) {
+	rows := tableRows()
+	// All of f's checkpoints were missed in maintenance, so its uptime is N/A too
+	rows = append(rows, tableRow{validator: types.ValidatorInfo{Name: "f", SuiAddress: "0xf"}, stats: types.ValidatorStats{PlannedMisses: 100}, hasStats: true})
+	tests := []struct {
+		reverse bool
+		want    string
+	}{
+		{false, "cbdaef"},
+		{true, "adbcef"},
+	}
+	for _, tt := range tests {
+		m := Model{sortBy: sortUptime, sortReverse: tt.reverse, totalWithSig: 100}
+		sorted := append([]tableRow(nil), rows...)
+		sort.SliceStable(sorted, func(i, j int) bool { return m.less(sorted[i], sorted[j]) })
+		got := ""
+		for _, r := range sorted {
+			got += r.validator.Name
+		}
+		if got != tt.want {
+			t.Errorf("sorted by uptime (reverse %v) = %s, want %s", tt.reverse, got, tt.want)
+		}
+	}
+}
+
+func TestMatchesFilter(t *testing.T) {
+	v := types.ValidatorInfo{Name: "Acme Staking", ChainName: "acme-validator-1", SuiAddress: "0xABCdef"}
+	tests := []struct {
+		filter string
+		want   bool
+	}{
+		{"", true},
+		{"acme", true},
+		{"STAKING", true},
+		{"validator-1", true},
+		{"0xabc", true},
+		{"def", true},
+		{"other", false},
+		{"acme staking ", false},
+	}
+	for _, tt := range tests {
+		if got := matchesFilter(v, tt.filter); got != tt.want {
+			t.Errorf("matchesFilter(%q) = %v, want %v", tt.filter, got, tt.want)
+		}
+	}
+}
+
+func TestRefreshTableKeepsSelection(t *testing.T) {
+	committee := []types.ValidatorInfo{
+		{Name: "alpha", SuiAddress: "0x1"},
+		{Name: "beta", SuiAddress: "0x2"},
+		{Name: "gamma", SuiAddress: "0x3"},
+		{Name: "alphabet", SuiAddress: "0x4"},
+	}
+	tests := []struct {
+		name       string
+		selected   string
+		filter     string
+		wantRows   []string
+		wantCursor int
+	}{
+		{"no filter", "0x3", "", []string{"alpha", "alphabet", "beta", "gamma"}, 3},
+		{"selection kept by the filter", "0x4", "alph", []string{"alpha", "alphabet"}, 1},
+		{"selection filtered out", "0x3", "alph", []string{"alpha", "alphabet"}, 0},
+		{"nothing matches", "0x3", "zeta", nil, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := New(1, committee, "test")
+			m.height = 40
+			m.selected, m.filter = tt.selected, tt.filter
+			m.refreshTable()
+			var got []string
+			for _, r := range m.rows {
+				got = append(got, r.validator.Name)
+			}
+			if !reflect.DeepEqual(got, tt.wantRows) {
+				t.Errorf("rows = %v, want %v", got, tt.wantRows)
+			}
+			if m.cursor != tt.wantCursor {
+				t.Errorf("cursor = %d, want %d", m.cursor, tt.wantCursor)
+			}
+		})
+	}
+}
@@ -14,9 +14,27 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		// Handle key presses
-		switch msg.String() {
-		case "q", "ctrl+c":
+		switch {
+		case msg.String() == "ctrl+c":
 			return m, tea.Quit
+		case m.filtering:
+			m.handleFilterKey(msg)
+		case msg.String() == "q":
+			return m, tea.Quit
+		case m.detail != "":
+			if msg.String() == "esc" {
+				m.detail = ""
+			}
+		case m.handleChartKey(msg):
+		case m.focus == nil:
+			if !m.heatmap || !m.handleHeatmapKey(msg) {
+				m.handleTableKey(msg)
+			}
+		}
+
+	case tea.MouseMsg:
+		if m.focus == nil && m.detail == "" {
+			m.handleMouse(msg)
 		}
 
 	case tea.WindowSizeMsg:
@@ -45,10 +63,30 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		AdjustStyles(m.width, m.leftWidth, m.middleWidth, m.rightWidth)
 
 		m.ready = true
+		m.refreshCharts()
+		m.refreshTable()
+		if m.heatmap {
+			m.loadScrollback()
+		}
 
 	case SnapshotMsg:
 		// Apply the snapshot to the model state
 		m.applySnapshot(msg)
+		m.refreshCharts()
+		m.refreshTable()
+
+	case AlertsMsg:
+		m.alerts = msg
+		m.refreshTable()
+
+	case NodeHealthMsg:
+		m.node = types.NodeHealth(msg)
+		m.refreshTable()
+
+	case ValidatorMetaMsg:
+		if m.focus != nil {
+			m.focus.meta = types.ValidatorMeta(msg)
+		}
 	}
 
 	// Handle progress bar updates
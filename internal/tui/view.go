@@ -3,9 +3,12 @@ package tui
 import (
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
-	"github.com/charmbracelet/bubbles/table"
+	"suitop/internal/labels"
+	"suitop/internal/types"
+
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -17,14 +20,43 @@ func (m Model) View() string {
 
 	AdjustStyles(m.width, m.leftWidth, m.middleWidth, m.rightWidth)
 
-	headerRow := renderHeaderRow(m)
-	mainContent := renderMainContent(m)
+	sections := m.sectionsAbove()
+	switch {
+	case m.focus != nil:
+		// Single-validator mode: the dashboard replaces the validator panels
+		sections = append(sections, renderDashboard(m))
+	case m.detail != "":
+		sections = append(sections, renderDetail(m))
+	case m.heatmap:
+		sections = append(sections, renderHeatmap(m))
+	default:
+		sections = append(sections, renderMainContent(m))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, sections...)
+}
 
-	return lipgloss.JoinVertical(
-		lipgloss.Left,
-		headerRow,
-		mainContent,
-	)
+// sectionsAbove renders the panels above the validator table, dashboard or
+// detail view
+func (m Model) sectionsAbove() []string {
+	sections := []string{renderHeaderRow(m)}
+	if m.charts {
+		sections = append(sections, renderChartsRow(m))
+	}
+	if !m.node.PolledAt.IsZero() {
+		sections = append(sections, renderNodePanel(m))
+	}
+	if m.focus == nil {
+		if m.watch.Len() > 0 {
+			sections = append(sections, renderWatchPanel(m))
+		}
+		if groups := m.groups(); len(groups) > 0 {
+			sections = append(sections, renderGroupsPanel(m, groups))
+		}
+	}
+	if len(m.alerts) > 0 {
+		sections = append(sections, renderAlertsPanel(m))
+	}
+	return sections
 }
 
 // renderHeaderRow creates the top row with two panels side by side
@@ -113,170 +145,221 @@ func renderProgressPanel(m Model) string {
 	)
 }
 
-func renderBar(uptime float64) string {
-	// Define the total width of the bar content (excluding brackets)
-	const barWidth = 10
+// maxAlertLines caps how many alerts are listed in the alerts panel
+const maxAlertLines = 4
 
-	// Calculate how many filled characters to show based on uptime percentage
-	filled := int(uptime * barWidth)
-	if filled > barWidth {
-		filled = barWidth // Cap at maximum width
+// renderAlertsPanel lists the pending and firing alerts
+func renderAlertsPanel(m Model) string {
+	var lines []string
+	for i, a := range m.alerts {
+		if i == maxAlertLines {
+			lines = append(lines, mutedStyle.Render(fmt.Sprintf("... and %d more", len(m.alerts)-maxAlertLines)))
+			break
+		}
+		icon := "🔔"
+		style := inactiveStyle
+		if a.State != "firing" {
+			icon = "⏳"
+			style = warningStyle
+		}
+		since := time.Since(a.ActiveAt).Truncate(time.Second)
+		lines = append(lines, style.Render(fmt.Sprintf("%s [%s] %s: %s (for %s)", icon, a.Severity, a.Rule, a.Summary, since)))
 	}
+	return alertPanelStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
 
-	// Build the bar string with 'x' for filled parts and spaces for empty parts
-	bar := "["
-	for i := 0; i < barWidth; i++ {
-		if i < filled {
-			bar += "▓"
-		} else {
-			bar += " "
+// renderNodePanel shows the health of the node suitop reads from
+func renderNodePanel(m Model) string {
+	n := m.node
+	lag := "N/A"
+	if !n.NodeTime.IsZero() {
+		lag = n.Lag.Truncate(100 * time.Millisecond).String()
+	}
+	summary := fmt.Sprintf("Node: %s · chain %s · height %d · lag %s · lowest available %d",
+		valueOr(n.ServerVersion, "unknown version"), valueOr(n.Chain, "unknown"), n.CheckpointHeight, lag, n.LowestAvailableCheckpoint)
+
+	lines := []string{summary}
+	style := nodePanelStyle
+	if len(n.Warnings) > 0 {
+		style = style.BorderForeground(warningColor)
+		for _, w := range n.Warnings {
+			lines = append(lines, warningStyle.Render("⚠ "+w))
 		}
 	}
-	bar += "]"
+	return style.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
 
-	return bar
+// maxWatchLines caps how many validators are listed in the watch panel
+const maxWatchLines = 8
+
+// watchedValidators returns the watched committee members sorted by name
+func watchedValidators(m Model) []types.ValidatorInfo {
+	var out []types.ValidatorInfo
+	for _, v := range m.committee {
+		if m.watch.Watched(v) {
+			out = append(out, v)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
 }
 
-// renderMainContent creates the main body with the validator table in two columns
-func renderMainContent(m Model) string {
-	// Only initialize the table if we have committee data
-	if m.committee == nil || len(m.committee) == 0 {
-		return mainContentContainerStyle.Render("Waiting for validator data...")
+// renderWatchPanel lists the watched validators with their status and uptime
+func renderWatchPanel(m Model) string {
+	watched := watchedValidators(m)
+	signing := 0
+	for _, v := range watched {
+		if m.stats[v.SuiAddress].SignedCurrent {
+			signing++
+		}
+	}
+	lines := []string{fmt.Sprintf("Watched: %d/%d signing", signing, len(watched))}
+	if len(watched) == 0 {
+		lines[0] = warningStyle.Render("Watched: no watched validator is in the committee")
+	}
+	for i, v := range watched {
+		if i == maxWatchLines {
+			lines = append(lines, mutedStyle.Render(fmt.Sprintf("... and %d more", len(watched)-maxWatchLines)))
+			break
+		}
+		stats, ok := m.stats[v.SuiAddress]
+		if !ok {
+			lines = append(lines, fmt.Sprintf("★ ❓ %-30s N/A", v.Name))
+			continue
+		}
+		uptime := stats.Uptime(m.totalWithSig)
+		line := fmt.Sprintf("★ %s %-30s %s %7s", statusIcon(stats), v.Name, renderBar(uptime), formatUptime(stats, m.totalWithSig))
+		style := lipgloss.NewStyle()
+		if !stats.SignedCurrent && !stats.InMaintenance {
+			line += fmt.Sprintf("  missed the last %d", stats.MissStreak)
+			style = inactiveStyle
+		}
+		lines = append(lines, style.Render(line))
 	}
+	return watchPanelStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
 
-	// Build all validator rows
-	var allRows []table.Row
-	for _, validator := range m.committee {
-		id := validator.SuiAddress
-		stats, ok := m.stats[id]
-
-		// Default values if stats not found
-		status := "❓"
-		uptimePercent := "N/A"
-		//signedRatio := "N/A"
-		var uptime float64 = 0
-
-		if ok {
-			// Calculate uptime percentage
-			if m.totalWithSig > 0 {
-				uptime = float64(stats.AttestedCount) / float64(m.totalWithSig)
-			}
-			uptimePercent = fmt.Sprintf("%.2f%%", uptime*100)
+// maxGroupLines caps how many operator groups are listed in the groups panel,
+// and maxGroupMissing how many missing members are named per group
+const (
+	maxGroupLines   = 8
+	maxGroupMissing = 3
+)
 
-			// Format signed ratio
-			//signedRatio = fmt.Sprintf("%d/%d", stats.AttestedCount, m.totalWithSig)
+// groups aggregates the committee's stats by operator group
+func (m Model) groups() []labels.Group {
+	return labels.Groups(m.committee, m.stats, m.totalWithSig)
+}
 
-			// Set status emoji based on signature presence for current checkpoint
-			if stats.SignedCurrent {
-				status = "✅"
-			} else {
-				status = "❌"
+// renderGroupsPanel shows one aggregate row per operator group
+func renderGroupsPanel(m Model, groups []labels.Group) string {
+	totalPower := 0
+	for _, v := range m.committee {
+		totalPower += v.VotingPower
+	}
+	var lines []string
+	for i, g := range groups {
+		if i == maxGroupLines {
+			lines = append(lines, mutedStyle.Render(fmt.Sprintf("... and %d more groups", len(groups)-maxGroupLines)))
+			break
+		}
+		share := 0.0
+		if totalPower > 0 {
+			share = float64(g.VotingPower) / float64(totalPower) * 100
+		}
+		uptime := "N/A"
+		if g.HasUptime {
+			uptime = fmt.Sprintf("%.2f%%", g.Uptime*100)
+		}
+		line := fmt.Sprintf("◆ %-28s %3d/%-3d signing  power %5.2f%%  %s %7s",
+			g.Name, g.Signing, g.Members, share, renderBar(g.Uptime), uptime)
+		style := lipgloss.NewStyle()
+		if missing := g.Missing; len(missing) > 0 {
+			more := ""
+			if len(missing) > maxGroupMissing {
+				more = fmt.Sprintf(" and %d more", len(missing)-maxGroupMissing)
+				missing = missing[:maxGroupMissing]
 			}
+			line += "  missing: " + strings.Join(missing, ", ") + more
+			style = inactiveStyle
 		}
+		lines = append(lines, style.Render(line))
+	}
+	return groupsPanelStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
 
-		// Add the row
-		allRows = append(allRows, table.Row{
-			status,
-			validator.Name,
-			renderBar(uptime) + " " + uptimePercent,
-			//signedRatio,
-			//renderBar(uptime),
-		})
+// statusIcon shows whether a validator signed the latest checkpoint
+func statusIcon(stats types.ValidatorStats) string {
+	switch {
+	case stats.SignedCurrent:
+		return "✅"
+	case stats.InMaintenance:
+		return "🔧"
+	default:
+		return "❌"
 	}
+}
 
-	// Sort rows by validator name
-	sort.Slice(allRows, func(i, j int) bool {
-		return allRows[i][1] < allRows[j][1]
-	})
-
-	// Split rows into three groups for three columns
-	totalRows := len(allRows)
-	rowsPerColumn := totalRows / 3
-	remainder := totalRows % 3
-
-	leftRows := allRows[:rowsPerColumn]
-	middleRows := allRows[rowsPerColumn : 2*rowsPerColumn]
-	rightRows := allRows[2*rowsPerColumn:]
-
-	// Adjust for remainder
-	if remainder == 1 {
-		rightRows = allRows[2*rowsPerColumn+1:]
-		middleRows = allRows[rowsPerColumn : 2*rowsPerColumn+1]
-	} else if remainder == 2 {
-		rightRows = allRows[2*rowsPerColumn+2:]
-		middleRows = allRows[rowsPerColumn : 2*rowsPerColumn+1]
-		leftRows = allRows[:rowsPerColumn+1]
+func valueOr(s, fallback string) string {
+	if s == "" {
+		return fallback
 	}
+	return s
+}
 
-	// Table columns definition
-	columns := []table.Column{
-		{Title: "Status", Width: 6},
-		{Title: "Validator", Width: 20},
-		{Title: "Signed %", Width: 20},
-		//{Title: "Signed/Total", Width: 15},
-		//{Title: "Bar", Width: 30},
+// hasPlannedDowntime reports whether any validator has missed checkpoints
+// during maintenance, in which case planned and unplanned downtime are shown.
+func hasPlannedDowntime(m Model) bool {
+	for _, stats := range m.stats {
+		if stats.PlannedMisses > 0 {
+			return true
+		}
 	}
+	return false
+}
 
-	// Calculate height for tables inside the container.
-	// The container (mainContentContainerStyle) is a copy of boxStyle, which has Padding(1,2).
-	// This means 1 line top padding and 1 line bottom padding.
-	// So, tables should be 2 lines shorter than before to fit inside.
-	tableHeight := m.height - 14
-
-	// Create left table
-	leftTable := table.New(
-		table.WithColumns(columns),
-		table.WithRows(leftRows),
-		table.WithHeight(tableHeight),
-	)
+// firingValidators returns the addresses of validators with a firing alert
+func firingValidators(m Model) map[string]bool {
+	firing := make(map[string]bool)
+	for _, a := range m.alerts {
+		if a.State == "firing" && a.ValidatorAddress != "" {
+			firing[a.ValidatorAddress] = true
+		}
+	}
+	return firing
+}
 
-	// Create middle table
-	middleTable := table.New(
-		table.WithColumns(columns),
-		table.WithRows(middleRows),
-		table.WithHeight(tableHeight),
-	)
+// formatUptime renders the validator's uptime as a percentage, or N/A if every
+// checkpoint was missed during planned maintenance
+func formatUptime(stats types.ValidatorStats, totalWithSig uint64) string {
+	if !stats.HasUptime(totalWithSig) {
+		return "N/A"
+	}
+	return fmt.Sprintf("%.2f%%", stats.Uptime(totalWithSig)*100)
+}
 
-	// Create right table
-	rightTable := table.New(
-		table.WithColumns(columns),
-		table.WithRows(rightRows),
-		table.WithHeight(tableHeight),
-	)
+func renderBar(uptime float64) string {
+	// Define the total width of the bar content (excluding brackets)
+	const barWidth = 10
 
-	// Style tables (internal styling)
-	leftTable.SetStyles(table.Styles{
-		Header: tableHeaderStyle,
-		Cell:   tableCellStyle,
-	})
-
-	middleTable.SetStyles(table.Styles{
-		Header: tableHeaderStyle,
-		Cell:   tableCellStyle,
-	})
-
-	rightTable.SetStyles(table.Styles{
-		Header: tableHeaderStyle,
-		Cell:   tableCellStyle,
-	})
-
-	// Get raw string views of the tables
-	leftTableView := leftTable.View()
-	middleTableView := middleTable.View()
-	rightTableView := rightTable.View()
-
-	// Join table views horizontally with a single space separator
-	joinedTablesView := lipgloss.JoinHorizontal(
-		lipgloss.Top,
-		leftTableView,
-		" ", // Spacer
-		middleTableView,
-		" ", // Spacer
-		rightTableView,
-	)
+	// Calculate how many filled characters to show based on uptime percentage
+	filled := int(uptime * barWidth)
+	if filled > barWidth {
+		filled = barWidth // Cap at maximum width
+	}
 
-	// Render the joined tables inside the single main container
-	return mainContentContainerStyle.Render(joinedTablesView)
+	// Build the bar string with 'x' for filled parts and spaces for empty parts
+	bar := "["
+	for i := 0; i < barWidth; i++ {
+		if i < filled {
+			bar += "▓"
+		} else {
+			bar += " "
+		}
+	}
+	bar += "]"
+
+	return bar
 }
 
 // countSignaturesForCheckpoint counts how many validators have signed the current checkpoint
@@ -1,5 +1,7 @@
 package types
 
+import "time"
+
 // ValidatorInfo holds static information about a validator in a specific epoch's committee.
 type ValidatorInfo struct {
 	Name                string
@@ -7,20 +9,109 @@ type ValidatorInfo struct {
 	ProtocolPubkeyBytes string // BLS key from committee info / system state
 	BitmapIndex         int    // Index from suix_getCommitteeInfo (0 to N-1), for bitmap lookup
 	VotingPower         int    // Voting power from committee info
+
+	// Set from the labels file, if it lists the validator
+	ChainName string   // On-chain name, if the label renames the validator
+	Group     string   // Operator group
+	Tags      []string // Free-form tags
 }
 
 // ValidatorStats tracks the uptime statistics for a validator.
 type ValidatorStats struct {
 	AttestedCount uint64
-	SignedCurrent bool // Did they sign the most recently processed checkpoint?
+	SignedCurrent bool   // Did they sign the most recently processed checkpoint?
+	MissStreak    uint64 // Consecutive checkpoints missed up to the most recent one
+	LastSignedSeq uint64 // Sequence number of the last checkpoint they signed (0 if none)
+	PlannedMisses uint64 // Checkpoints missed inside a maintenance window
+	InMaintenance bool   // Did they miss the most recent checkpoint inside a maintenance window?
+
+	LongestMissStreak uint64 // Longest run of consecutive misses since suitop started
+}
+
+// Uptime returns the share of checkpoints signed out of totalWithSig,
+// leaving out checkpoints missed during planned maintenance. It is 0 when
+// HasUptime is false.
+func (s ValidatorStats) Uptime(totalWithSig uint64) float64 {
+	if !s.HasUptime(totalWithSig) {
+		return 0
+	}
+	return float64(s.AttestedCount) / float64(totalWithSig-s.PlannedMisses)
+}
+
+// HasUptime reports whether any of the totalWithSig checkpoints counts towards
+// the uptime, that is whether not all of them were missed during planned
+// maintenance. Without any, the uptime is unknown and shown as N/A.
+func (s ValidatorStats) HasUptime(totalWithSig uint64) bool {
+	return totalWithSig > s.PlannedMisses
+}
+
+// UnplannedMisses returns the checkpoints missed outside maintenance windows.
+func (s ValidatorStats) UnplannedMisses(totalWithSig uint64) uint64 {
+	if totalWithSig < s.AttestedCount+s.PlannedMisses {
+		return 0
+	}
+	return totalWithSig - s.AttestedCount - s.PlannedMisses
+}
+
+// QuorumThreshold returns the voting power required for a quorum certificate (2f+1).
+func QuorumThreshold(totalPower int) int {
+	return totalPower*2/3 + 1
+}
+
+// ValidityThreshold returns the voting power that includes at least one honest
+// validator (f+1).
+func ValidityThreshold(totalPower int) int {
+	return (totalPower + 2) / 3
 }
 
 // CheckpointInfo contains information about a processed checkpoint
 type CheckpointInfo struct {
 	Sequence        uint64
-	Timestamp       int64
+	Epoch           uint64
+	Timestamp       int64 // Unix milliseconds; falls back to processing time if the node did not send one
 	SignaturesCount uint64
 	ValidatorCount  uint64
+	SignedPower     int
+	TotalPower      int
+	Signers         []uint32 // Bitmap indices of the validators that signed
+}
+
+// AlertInfo is a pending or firing alert as displayed by the UI.
+type AlertInfo struct {
+	Rule             string
+	Severity         string
+	State            string // "pending" or "firing"
+	ValidatorName    string // Empty for network-wide alerts
+	ValidatorAddress string
+	Summary          string
+	ActiveAt         time.Time
+}
+
+// NodeHealth describes the full node suitop reads from, as of the last poll of
+// its service info.
+type NodeHealth struct {
+	Chain                     string
+	ServerVersion             string
+	Epoch                     uint64
+	CheckpointHeight          uint64
+	LowestAvailableCheckpoint uint64
+	NodeTime                  time.Time     // Timestamp of the node's latest checkpoint
+	Lag                       time.Duration // Wall clock minus NodeTime
+	PolledAt                  time.Time
+	Error                     string   // Set if the last poll failed; the other fields are from the last successful one
+	Warnings                  []string // Problems that need attention, e.g. the node falling behind
+}
+
+// ValidatorMeta is a validator's staking metadata from the latest Sui system state.
+type ValidatorMeta struct {
+	Epoch             uint64
+	Stake             uint64 // Staking pool balance in MIST
+	TotalStake        uint64 // Stake of all active validators in MIST
+	CommissionRate    uint64 // Basis points
+	GasPrice          uint64 // The validator's gas price vote in MIST
+	ReferenceGasPrice uint64
+	FetchedAt         time.Time
+	Error             string // Set if the last fetch failed; the other fields are from the last successful one
 }
 
 // SnapshotMsg represents a state snapshot from the core logic that is sent to the UI
@@ -32,4 +123,5 @@ type SnapshotMsg struct {
 	TotalPower    int // Sum of voting power of all validators in the committee
 	Committee     []ValidatorInfo
 	Stats         map[string]ValidatorStats
+	Checkpoint    CheckpointInfo // The checkpoint this snapshot was taken after
 }
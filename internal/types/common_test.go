@@ -0,0 +1,55 @@
+package types
+
+import "testing"
+
+func TestValidatorStatsUptime(t *testing.T) {
+	tests := []struct {
+		name         string
+		stats        ValidatorStats
+		totalWithSig uint64
+		hasUptime    bool
+		uptime       float64
+		unplanned    uint64
+	}{
+		{"all signed", ValidatorStats{AttestedCount: 10}, 10, true, 1, 0},
+		{"half missed", ValidatorStats{AttestedCount: 5}, 10, true, 0.5, 5},
+		{"planned misses left out", ValidatorStats{AttestedCount: 6, PlannedMisses: 2}, 10, true, 0.75, 2},
+		{"every miss planned", ValidatorStats{PlannedMisses: 10}, 10, false, 0, 0},
+		{"nothing processed", ValidatorStats{}, 0, false, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.stats.HasUptime(tt.totalWithSig); got != tt.hasUptime {
+				t.Errorf("HasUptime() = %v, want %v", got, tt.hasUptime)
+			}
+			if got := tt.stats.Uptime(tt.totalWithSig); got != tt.uptime {
+				t.Errorf("Uptime() = %v, want %v", got, tt.uptime)
+			}
+			if got := tt.stats.UnplannedMisses(tt.totalWithSig); got != tt.unplanned {
+				t.Errorf("UnplannedMisses() = %v, want %v", got, tt.unplanned)
+			}
+		})
+	}
+}
+
+func TestThresholds(t *testing.T) {
+	tests := []struct {
+		total    int
+		quorum   int
+		validity int
+	}{
+		{0, 1, 0},
+		{3, 3, 1},
+		{4, 3, 2},
+		{100, 67, 34},
+		{10000, 6667, 3334},
+	}
+	for _, tt := range tests {
+		if got := QuorumThreshold(tt.total); got != tt.quorum {
+			t.Errorf("QuorumThreshold(%d) = %d, want %d", tt.total, got, tt.quorum)
+		}
+		if got := ValidityThreshold(tt.total); got != tt.validity {
+			t.Errorf("ValidityThreshold(%d) = %d, want %d", tt.total, got, tt.validity)
+		}
+	}
+}
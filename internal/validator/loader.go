@@ -5,14 +5,19 @@ import (
 	"fmt"
 	"log"
 	"strconv"
+	"strings"
+	"time"
 
 	"suitop/internal/config"
+	"suitop/internal/labels"
 	"suitop/internal/rpc"
+	"suitop/internal/types"
 )
 
 // Loader handles loading validator information.
 type Loader struct {
 	rpcClient *rpc.Client
+	labels    *labels.Set // Optional; applied to every loaded committee
 }
 
 // NewLoader creates a new validator loader.
@@ -22,35 +27,39 @@ func NewLoader(rpcCfg config.RPCClientConfig) *Loader {
 	}
 }
 
-// LoadEpochValidatorData fetches committee and validator metadata for a given epoch.
-// If targetEpoch is 0, it first fetches the latest epoch.
-func (l *Loader) LoadEpochValidatorData(ctx context.Context, targetEpoch uint64) ([]ValidatorInfo, uint64, error) {
-	log.Println("Loading epoch validator data...")
-
-	var epochToQueryStr string
-	var actualEpoch uint64
+// SetLabels gives the validators of every committee loaded afterwards the
+// names, groups and tags of the labels.
+func (l *Loader) SetLabels(set *labels.Set) {
+	l.labels = set
+}
 
-	// Step 0: Determine current epoch if targetEpoch is not specified
-	if targetEpoch == 0 {
-		log.Println("Target epoch not specified, fetching latest Sui system state to determine current epoch...")
-		systemState, err := l.rpcClient.GetLatestSuiSystemState(ctx)
-		if err != nil {
-			return nil, 0, fmt.Errorf("error fetching system state (pre-fetch): %w", err)
-		}
-		if systemState.Epoch == "" {
-			return nil, 0, fmt.Errorf("epoch not found in pre-fetch system state response")
-		}
-		actualEpoch, err = strconv.ParseUint(systemState.Epoch, 10, 64)
-		if err != nil {
-			return nil, 0, fmt.Errorf("error parsing epoch from pre-fetch system state response: %w", err)
-		}
-		epochToQueryStr = systemState.Epoch
-		log.Printf("Determined latest epoch to be %d for querying committee info.", actualEpoch)
-	} else {
-		actualEpoch = targetEpoch
-		epochToQueryStr = strconv.FormatUint(targetEpoch, 10)
-		log.Printf("Using specified target epoch %d for querying committee info.", actualEpoch)
+// LoadLatestValidatorData fetches committee and validator metadata for the
+// current epoch and returns it with the epoch.
+func (l *Loader) LoadLatestValidatorData(ctx context.Context) ([]ValidatorInfo, uint64, error) {
+	log.Println("Fetching latest Sui system state to determine current epoch...")
+	systemState, err := l.rpcClient.GetLatestSuiSystemState(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error fetching system state (pre-fetch): %w", err)
+	}
+	if systemState.Epoch == "" {
+		return nil, 0, fmt.Errorf("epoch not found in pre-fetch system state response")
 	}
+	epoch, err := strconv.ParseUint(systemState.Epoch, 10, 64)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error parsing epoch from pre-fetch system state response: %w", err)
+	}
+	log.Printf("Determined latest epoch to be %d for querying committee info.", epoch)
+	return l.LoadEpochValidatorData(ctx, epoch)
+}
+
+// LoadEpochValidatorData fetches committee and validator metadata for a given
+// epoch, which may be 0, the genesis epoch. Use LoadLatestValidatorData for the
+// current epoch.
+func (l *Loader) LoadEpochValidatorData(ctx context.Context, targetEpoch uint64) ([]ValidatorInfo, uint64, error) {
+	log.Printf("Loading validator data for epoch %d...", targetEpoch)
+
+	actualEpoch := targetEpoch
+	epochToQueryStr := strconv.FormatUint(targetEpoch, 10)
 
 	// Step 1: Call suix_getCommitteeInfo for the determined/specified epoch
 	committeeInfo, err := l.rpcClient.GetCommitteeInfo(ctx, epochToQueryStr)
@@ -116,6 +125,51 @@ func (l *Loader) LoadEpochValidatorData(ctx context.Context, targetEpoch uint64)
 		committee = append(committee, NewValidatorInfo(meta.Name, meta.SuiAddress, pubKey, bitmapIdx, committeeVotingPowersOrdered[bitmapIdx]))
 	}
 
+	for i, v := range committee {
+		if label, ok := l.labels.Lookup(v.SuiAddress, v.ProtocolPubkeyBytes); ok {
+			committee[i] = v.WithLabel(label)
+		}
+	}
+
 	log.Printf("Successfully loaded and merged data for %d validators for epoch %d.", len(committee), actualEpoch)
 	return committee, actualEpoch, nil
 }
+
+// LoadMeta fetches the staking metadata of the validator at address from the
+// latest system state.
+func (l *Loader) LoadMeta(ctx context.Context, address string) (types.ValidatorMeta, error) {
+	state, err := l.rpcClient.GetLatestSuiSystemState(ctx)
+	if err != nil {
+		return types.ValidatorMeta{}, fmt.Errorf("error fetching system state: %w", err)
+	}
+	var parseErr error
+	parse := func(field, value string) uint64 {
+		n, err := strconv.ParseUint(value, 10, 64)
+		if err != nil && parseErr == nil {
+			parseErr = fmt.Errorf("error parsing %s from system state response: %w", field, err)
+		}
+		return n
+	}
+	meta := types.ValidatorMeta{
+		FetchedAt:         time.Now(),
+		Epoch:             parse("epoch", state.Epoch),
+		TotalStake:        parse("total stake", state.TotalStake),
+		ReferenceGasPrice: parse("reference gas price", state.ReferenceGasPrice),
+	}
+	for _, v := range state.ActiveValidators {
+		if !strings.EqualFold(v.SuiAddress, address) {
+			continue
+		}
+		meta.Stake = parse("stake", v.StakingPoolSuiBalance)
+		meta.CommissionRate = parse("commission rate", v.CommissionRate)
+		meta.GasPrice = parse("gas price", v.GasPrice)
+		if parseErr != nil {
+			return types.ValidatorMeta{}, parseErr
+		}
+		return meta, nil
+	}
+	if parseErr != nil {
+		return types.ValidatorMeta{}, parseErr
+	}
+	return types.ValidatorMeta{}, fmt.Errorf("validator %s is not an active validator in epoch %s", address, state.Epoch)
+}
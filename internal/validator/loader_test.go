@@ -0,0 +1,62 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"suitop/internal/config"
+)
+
+// systemStateServer answers suix_getLatestSuiSystemState with one active
+// validator at 0xab whose stake is the given string
+func systemStateServer(t *testing.T, totalStake, stake string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"jsonrpc": "2.0", "id": 1, "result": {"epoch": "42", "referenceGasPrice": "750", "totalStake": %q,
+			"activeValidators": [{"suiAddress": "0xab", "stakingPoolSuiBalance": %q, "commissionRate": "200", "gasPrice": "800"}]}}`, totalStake, stake)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestLoadMeta(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	tests := []struct {
+		name       string
+		totalStake string
+		stake      string
+		address    string
+		wantErr    bool
+	}{
+		{"active validator", "1000000", "25000", "0xAB", false},
+		{"not active", "1000000", "25000", "0xcd", true},
+		{"bad total stake", "lots", "25000", "0xab", true},
+		{"bad validator stake", "1000000", "-1", "0xab", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := systemStateServer(t, tt.totalStake, tt.stake)
+			l := NewLoader(config.RPCClientConfig{URL: server.URL, Timeout: 5 * time.Second})
+			meta, err := l.LoadMeta(context.Background(), tt.address)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadMeta() error = %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if meta.Epoch != 42 || meta.TotalStake != 1000000 || meta.ReferenceGasPrice != 750 ||
+				meta.Stake != 25000 || meta.CommissionRate != 200 || meta.GasPrice != 800 {
+				t.Errorf("LoadMeta() = %+v", meta)
+			}
+		})
+	}
+}
@@ -2,6 +2,8 @@ package validator
 
 import (
 	"strings"
+
+	"suitop/internal/labels"
 	"suitop/internal/types"
 )
 
@@ -13,6 +15,11 @@ type ValidatorInfo struct {
 	ProtocolPubkeyBytes string // BLS key from committee info / system state
 	BitmapIndex         int    // Index from suix_getCommitteeInfo (0 to N-1), for bitmap lookup
 	VotingPower         int    // Voting power from committee info
+
+	// Set from the labels file, if it lists the validator
+	ChainName string   // On-chain name, if the label renames the validator
+	Group     string   // Operator group
+	Tags      []string // Free-form tags
 }
 
 // ToTypesInfo converts this ValidatorInfo to a types.ValidatorInfo
@@ -23,6 +30,9 @@ func (v ValidatorInfo) ToTypesInfo() types.ValidatorInfo {
 		ProtocolPubkeyBytes: v.ProtocolPubkeyBytes,
 		BitmapIndex:         v.BitmapIndex,
 		VotingPower:         v.VotingPower,
+		ChainName:           v.ChainName,
+		Group:               v.Group,
+		Tags:                v.Tags,
 	}
 }
 
@@ -34,6 +44,9 @@ func FromTypesInfo(v types.ValidatorInfo) ValidatorInfo {
 		ProtocolPubkeyBytes: v.ProtocolPubkeyBytes,
 		BitmapIndex:         v.BitmapIndex,
 		VotingPower:         v.VotingPower,
+		ChainName:           v.ChainName,
+		Group:               v.Group,
+		Tags:                v.Tags,
 	}
 }
 
@@ -57,3 +70,40 @@ func NewValidatorInfo(name, suiAddress, protocolPubkeyBytes string, bitmapIndex
 		VotingPower:         votingPower,
 	}
 }
+
+// Matches reports whether spec identifies the validator: its name, its on-chain
+// name, its Sui address (in any case) or its protocol pubkey.
+func (v ValidatorInfo) Matches(spec string) bool {
+	return spec == v.Name || (v.ChainName != "" && spec == v.ChainName) ||
+		strings.EqualFold(spec, v.SuiAddress) || spec == v.ProtocolPubkeyBytes
+}
+
+// OnChainName returns the name the validator has on chain, even if a label
+// renames it.
+func (v ValidatorInfo) OnChainName() string {
+	if v.ChainName != "" {
+		return v.ChainName
+	}
+	return v.Name
+}
+
+// Names returns the validator's display name and, if a label renames it, its
+// on-chain name, for matching files that may use either.
+func (v ValidatorInfo) Names() []string {
+	if v.ChainName != "" {
+		return []string{v.Name, v.ChainName}
+	}
+	return []string{v.Name}
+}
+
+// WithLabel returns the validator with the name, group and tags of a label.
+// The on-chain name is kept in ChainName.
+func (v ValidatorInfo) WithLabel(l labels.Label) ValidatorInfo {
+	if l.Name != "" && l.Name != v.Name {
+		v.ChainName = v.Name
+		v.Name = l.Name
+	}
+	v.Group = l.Group
+	v.Tags = l.Tags
+	return v
+}
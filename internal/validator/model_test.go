@@ -0,0 +1,57 @@
+package validator
+
+import (
+	"reflect"
+	"testing"
+
+	"suitop/internal/labels"
+)
+
+func TestWithLabel(t *testing.T) {
+	v := ValidatorInfo{Name: "alpha-validator", SuiAddress: "0xAB", ProtocolPubkeyBytes: "pkA"}
+	tests := []struct {
+		name      string
+		label     labels.Label
+		wantName  string
+		chainName string
+		names     []string
+	}{
+		{"renamed", labels.Label{Name: "Alpha (fra-1)", Group: "Ops"}, "Alpha (fra-1)", "alpha-validator", []string{"Alpha (fra-1)", "alpha-validator"}},
+		{"group only", labels.Label{Group: "Ops"}, "alpha-validator", "", []string{"alpha-validator"}},
+		{"same name", labels.Label{Name: "alpha-validator"}, "alpha-validator", "", []string{"alpha-validator"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := v.WithLabel(tt.label)
+			if got.Name != tt.wantName || got.ChainName != tt.chainName || got.Group != tt.label.Group {
+				t.Errorf("WithLabel() = %+v, want name %q on chain %q", got, tt.wantName, tt.chainName)
+			}
+			if got.OnChainName() != "alpha-validator" {
+				t.Errorf("OnChainName() = %q, want alpha-validator", got.OnChainName())
+			}
+			if !reflect.DeepEqual(got.Names(), tt.names) {
+				t.Errorf("Names() = %q, want %q", got.Names(), tt.names)
+			}
+		})
+	}
+}
+
+func TestMatches(t *testing.T) {
+	v := ValidatorInfo{Name: "alpha-validator", SuiAddress: "0xAB", ProtocolPubkeyBytes: "pkA"}.WithLabel(labels.Label{Name: "Alpha"})
+	tests := []struct {
+		spec string
+		want bool
+	}{
+		{"Alpha", true},
+		{"alpha-validator", true},
+		{"0xab", true},
+		{"pkA", true},
+		{"alpha", false},
+		{"pka", false},
+	}
+	for _, tt := range tests {
+		if got := v.Matches(tt.spec); got != tt.want {
+			t.Errorf("Matches(%q) = %v, want %v", tt.spec, got, tt.want)
+		}
+	}
+}
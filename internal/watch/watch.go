@@ -0,0 +1,122 @@
+// Package watch holds the validators an operator follows: their own and
+// their peers'. Watched validators are pinned in the TUI and summarised
+// separately in plain mode.
+package watch
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"suitop/internal/config"
+	"suitop/internal/types"
+	val "suitop/internal/validator"
+)
+
+// List is a set of validators given by name, address or protocol pubkey.
+// A nil *List watches nothing and shows everything.
+type List struct {
+	specs []string
+	only  bool
+}
+
+// file is the on-disk format of a watch file.
+type file struct {
+	Validators []string `yaml:"validators"`
+}
+
+// ReadFile loads the validators listed in path.
+func ReadFile(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading watch file: %w", err)
+	}
+	// Unknown keys are rejected so that a typo does not silently watch nothing
+	var f file
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&f); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("error parsing watch file %s: %w", path, err)
+	}
+	for i, spec := range f.Validators {
+		if strings.TrimSpace(spec) == "" {
+			return nil, fmt.Errorf("empty validator %d in watch file %s", i, path)
+		}
+	}
+	return f.Validators, nil
+}
+
+// Load builds the list from the configured validators and watch file. It
+// returns nil if no validators are watched.
+func Load(cfg config.WatchConfig) (*List, error) {
+	specs := append([]string(nil), cfg.Validators...)
+	if cfg.File != "" {
+		more, err := ReadFile(cfg.File)
+		if err != nil {
+			return nil, err
+		}
+		specs = append(specs, more...)
+	}
+	if len(specs) == 0 {
+		if cfg.Only {
+			return nil, fmt.Errorf("watch only is set but no validators are watched")
+		}
+		return nil, nil
+	}
+	return &List{specs: specs, only: cfg.Only}, nil
+}
+
+// Len returns the number of entries in the list.
+func (l *List) Len() int {
+	if l == nil {
+		return 0
+	}
+	return len(l.specs)
+}
+
+// Watched reports whether the validator is on the list.
+func (l *List) Watched(v types.ValidatorInfo) bool {
+	if l == nil {
+		return false
+	}
+	info := val.FromTypesInfo(v)
+	for _, spec := range l.specs {
+		if info.Matches(spec) {
+			return true
+		}
+	}
+	return false
+}
+
+// Shown reports whether the validator is displayed: every validator unless
+// only watched validators are shown.
+func (l *List) Shown(v types.ValidatorInfo) bool {
+	return l == nil || !l.only || l.Watched(v)
+}
+
+// Unmatched returns the entries that match no validator of the committee,
+// which are usually typos.
+func (l *List) Unmatched(committee []types.ValidatorInfo) []string {
+	if l == nil {
+		return nil
+	}
+	var out []string
+	for _, spec := range l.specs {
+		found := false
+		for _, v := range committee {
+			if val.FromTypesInfo(v).Matches(spec) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			out = append(out, spec)
+		}
+	}
+	return out
+}
@@ -0,0 +1,114 @@
+package watch
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"suitop/internal/config"
+	"suitop/internal/types"
+)
+
+func writeWatchFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "watch.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestReadFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+		wantErr string
+	}{
+		{"list", "validators:\n  - alpha\n  - 0xB\n", []string{"alpha", "0xB"}, ""},
+		{"empty file", "", nil, ""},
+		{"unknown key", "validator:\n  - alpha\n", nil, "field validator not found"},
+		{"empty entry", "validators:\n  - alpha\n  - ' '\n", nil, "empty validator 1"},
+		{"not a list", "validators: alpha\n", nil, "error parsing"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadFile(writeWatchFile(t, tt.content))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ReadFile() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReadFile: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadFile() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := writeWatchFile(t, "validators: [gamma]\n")
+	tests := []struct {
+		name    string
+		cfg     config.WatchConfig
+		wantLen int
+		wantErr bool
+	}{
+		{"nothing watched", config.WatchConfig{}, 0, false},
+		{"only without validators", config.WatchConfig{Only: true}, 0, true},
+		{"flags and file", config.WatchConfig{Validators: []string{"alpha"}, File: path}, 2, false},
+		{"missing file", config.WatchConfig{File: path + ".missing"}, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, err := Load(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := l.Len(); got != tt.wantLen {
+				t.Errorf("Len() = %d, want %d", got, tt.wantLen)
+			}
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	alpha := types.ValidatorInfo{Name: "alpha", SuiAddress: "0xaa", ProtocolPubkeyBytes: "pkA"}
+	beta := types.ValidatorInfo{Name: "beta", SuiAddress: "0xbb", ProtocolPubkeyBytes: "pkB"}
+	gamma := types.ValidatorInfo{Name: "gamma", SuiAddress: "0xcc", ProtocolPubkeyBytes: "pkC"}
+
+	l, err := Load(config.WatchConfig{Validators: []string{"alpha", "0xBB", "typo"}, Only: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		v       types.ValidatorInfo
+		watched bool
+	}{
+		{alpha, true}, // By name
+		{beta, true},  // By address, in any case
+		{gamma, false},
+	}
+	for _, tt := range tests {
+		if got := l.Watched(tt.v); got != tt.watched {
+			t.Errorf("Watched(%s) = %v, want %v", tt.v.Name, got, tt.watched)
+		}
+		if got := l.Shown(tt.v); got != tt.watched {
+			t.Errorf("Shown(%s) with only = %v, want %v", tt.v.Name, got, tt.watched)
+		}
+	}
+	if got := l.Unmatched([]types.ValidatorInfo{alpha, beta, gamma}); !reflect.DeepEqual(got, []string{"typo"}) {
+		t.Errorf("Unmatched() = %q, want [typo]", got)
+	}
+
+	var none *List
+	if none.Watched(alpha) || !none.Shown(alpha) || none.Unmatched([]types.ValidatorInfo{alpha}) != nil {
+		t.Error("a nil list must watch nothing and show everything")
+	}
+}
@@ -84,7 +84,7 @@ func main() {
 
 		if resp.Checkpoint != nil && resp.Checkpoint.Signature != nil {
 			sig := resp.Checkpoint.Signature
-			fmt.Printf("Received Checkpoint - Cursor: %d, Epoch: %d, Signature: %s, Bitmap: %s\n",
+			fmt.Printf("Received Checkpoint - Cursor: %d, Epoch: %d, Signature: %s, Bitmap: %v\n",
 				resp.GetCursor(), // Use GetCursor to safely access optional field
 				sig.Epoch,
 				hex.EncodeToString(sig.Signature),